@@ -0,0 +1,147 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/finality"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/gql"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	rpcserver "github.com/dusk-network/dusk-blockchain/pkg/rpc/server"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"google.golang.org/grpc"
+)
+
+// setupLight creates the Server of a light client. It syncs and verifies the
+// block headers, without Rusk, mempool nor consensus, and serves the headers
+// and their finality proofs over gRPC and GraphQL. The provisioners are
+// fetched from the configured trusted node.
+func setupLight() *Server {
+	parentCtx, parentCancel := context.WithCancel(context.Background())
+
+	eventBus := eventbus.New()
+	rpcBus := rpcbus.New()
+
+	driver, db := heavy.CreateDBConnection()
+
+	processor := peer.NewMessageProcessor(eventBus)
+	registerLightPeerServices(processor, db, rpcBus)
+
+	trustedConn, err := dialTrustedNode(parentCtx)
+	if err != nil {
+		log.WithError(err).Fatal("could not connect to the trusted node")
+	}
+
+	log.Info("grpc connection with trusted node established")
+
+	provisionersFn := func(ctx context.Context, height uint64) (user.Provisioners, error) {
+		return finality.GetProvisioners(ctx, trustedConn, height)
+	}
+
+	l := chain.NewDBLoader(db, genesis.Decode())
+
+	c, err := chain.NewLight(parentCtx, db, l, l, provisionersFn)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// Perform database sanity check to ensure that it is rational before
+	// bootstrapping all node subsystems
+	if err := l.SanityCheckBlockchain(0, 10); err != nil {
+		log.Panic(err)
+	}
+
+	processor.Register(topics.Block, c.ProcessBlockFromNetwork)
+
+	// Instantiate the gRPC server exposing the headers and the proofs
+	var grpcServer *grpc.Server
+
+	if cfg.Get().RPC.Enabled {
+		if grpcServer, err = rpcserver.SetupGRPC(rpcserver.FromCfg()); err != nil {
+			log.WithError(err).Error("could not setup gRPC server")
+		}
+	}
+
+	if grpcServer != nil {
+		finality.Register(grpcServer, finality.NewService(db))
+		serveGRPC(grpcServer)
+	}
+
+	// Instantiate GraphQL server
+	var gqlServer *gql.Server
+
+	if cfg.Get().Gql.Enabled {
+		var e error
+		if gqlServer, e = gql.NewHTTPServer(eventBus, rpcBus); e != nil {
+			log.WithError(e).Error("graphq server failed to run")
+		} else {
+			if e = gqlServer.Start(parentCtx); e != nil {
+				log.WithError(e).Error("graphq server failed to run")
+			}
+		}
+	}
+
+	srv := &Server{
+		eventBus:      eventBus,
+		rpcBus:        rpcBus,
+		light:         c,
+		gossip:        protocol.NewGossip(),
+		gqlServer:     gqlServer,
+		grpcServer:    grpcServer,
+		trustedConn:   trustedConn,
+		readerFactory: peer.NewReaderFactory(processor),
+		dbDriver:      driver,
+		ctx:           parentCtx,
+		cancel:        parentCancel,
+	}
+
+	if cfg.Get().Kadcast.Enabled {
+		srv.launchKadcastPeer(parentCtx, processor, srv.gossip)
+	}
+
+	log.Info("running as light client")
+
+	return srv
+}
+
+// registerLightPeerServices registers the peer services needed to sync the
+// blocks. As a light client stores headers only, it does not serve blocks,
+// transactions nor candidates to the other peers.
+func registerLightPeerServices(processor *peer.MessageProcessor, db database.DB, rpcBus *rpcbus.RPCBus) {
+	dataRequestor := responding.NewDataRequestor(db, rpcBus)
+
+	processor.Register(topics.Ping, responding.ProcessPing)
+	processor.Register(topics.Pong, responding.ProcessPong)
+	processor.Register(topics.Inv, dataRequestor.RequestMissingItems)
+	processor.Register(topics.Challenge, responding.CompleteChallenge)
+}
+
+func dialTrustedNode(ctx context.Context) (*grpc.ClientConn, error) {
+	conf := cfg.Get().Light.Trusted
+
+	addr := conf.Address
+	if conf.Network == "unix" {
+		addr = "unix://" + conf.Address
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, time.Duration(conf.DialTimeout)*time.Second)
+	defer cancel()
+
+	return grpc.DialContext(dialCtx, addr, grpc.WithInsecure(), grpc.WithBlock())
+}
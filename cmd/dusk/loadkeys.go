@@ -10,14 +10,104 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	consensuskey "github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+// loadConsensusSigner connects to the remote signing daemon, if one is
+// configured. Otherwise, it loads the consensus keys and signs in process.
+// The returned keys hold the secret key only in the latter case.
+//
+// Votes are checked against the slashing-protection database, if any. The
+// returned closers release the signer resources.
+//
+// In observer mode, no signer is returned, as the node never signs.
+func loadConsensusSigner() (consensuskey.Keys, signer.Signer, []io.Closer, error) {
+	var (
+		keys    consensuskey.Keys
+		sgn     signer.Signer
+		closers []io.Closer
+	)
+
+	ccfg := cfg.Get().Consensus
+	if ccfg.Observer {
+		keys, err := loadObserverKeys()
+		return keys, nil, nil, err
+	}
+
+	protectionFile := ccfg.ProtectionFile
+
+	if len(ccfg.SignerAddress) == 0 {
+		var err error
+
+		keys, err = loadConsensusKeys()
+		if err != nil {
+			return consensuskey.Keys{}, nil, nil, err
+		}
+
+		sgn = signer.NewGuard(signer.NewInMemory(keys))
+
+		// Nodes sharing the keys file share the slashing-protection
+		// database too, so that only one of them can sign.
+		if len(protectionFile) == 0 {
+			protectionFile = ccfg.KeysFile + ".protection"
+		}
+	} else {
+		timeout := time.Duration(ccfg.SignerTimeoutMilli) * time.Millisecond
+		if timeout <= 0 {
+			timeout = 2 * time.Second
+		}
+
+		remote, err := signer.NewRemote(ccfg.SignerAddress, timeout)
+		if err != nil {
+			return consensuskey.Keys{}, nil, nil, err
+		}
+
+		log.WithField("pubkey", util.StringifyBytes(remote.PublicKeyBLS())).
+			WithField("address", ccfg.SignerAddress).
+			Info("connected to remote signer")
+
+		keys = consensuskey.Keys{BLSPubKey: remote.PublicKeyBLS()}
+		sgn = remote
+		closers = append(closers, remote)
+	}
+
+	if len(protectionFile) > 0 {
+		p, err := signer.OpenProtection(protectionFile)
+		if err != nil {
+			for _, c := range closers {
+				_ = c.Close()
+			}
+
+			return consensuskey.Keys{}, nil, nil, err
+		}
+
+		sgn = signer.NewProtected(sgn, p)
+		closers = append(closers, p)
+	}
+
+	return keys, sgn, closers, nil
+}
+
+// loadObserverKeys loads the consensus keys used by an observer to tell in
+// which committees it would sit. If no keys file is configured, random keys
+// are generated, so that the observer never sits in a committee.
+func loadObserverKeys() (consensuskey.Keys, error) {
+	if len(cfg.Get().Consensus.KeysFile) == 0 {
+		log.Info("observer mode, using random consensus keys")
+		return consensuskey.NewRandKeys(), nil
+	}
+
+	return loadConsensusKeys()
+}
+
 // loadConsensusKeys tries to read and decrypt Consensus keys an external file defined in consensus.keysfile config.
 // if the external file does not exist, it generates a new one with user-defined password.
 func loadConsensusKeys() (consensuskey.Keys, error) {
@@ -8,6 +8,9 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
+	"io"
+	"net"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/api"
@@ -15,6 +18,11 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/evidence"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/finality"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/journal"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakes"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
@@ -23,10 +31,14 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/gql"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/addrbook"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/rpc/client"
+	rpcserver "github.com/dusk-network/dusk-blockchain/pkg/rpc/server"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"google.golang.org/grpc"
@@ -37,16 +49,22 @@ type Server struct {
 	eventBus *eventbus.EventBus
 	rpcBus   *rpcbus.RPCBus
 	c        *chain.Chain
+	light    *chain.LightChain
 	gossip   *protocol.Gossip
 
 	grpcServer *grpc.Server
 	gqlServer  *gql.Server
 
 	ruskConn      *grpc.ClientConn
+	trustedConn   *grpc.ClientConn
 	readerFactory *peer.ReaderFactory
 	kadPeer       *kadcast.Peer
+	connector     *peer.Connector
 
-	dbDriver database.Driver
+	dbDriver      database.Driver
+	evStore       evidence.Store
+	banStore      reputation.Store
+	signerClosers []io.Closer
 
 	// Parent context to all long-lived goroutines triggered by any subsystem.
 	ctx    context.Context
@@ -74,11 +92,147 @@ func LaunchChain(ctx context.Context, cl *loop.Consensus, proxy transactions.Pro
 	return chainProcess, nil
 }
 
+// serveGRPC starts serving the gRPC services on the configured address. The
+// services must be registered beforehand.
+func serveGRPC(srv *grpc.Server) {
+	conf := rpcserver.FromCfg()
+
+	l, err := net.Listen(conf.Network, conf.Address)
+	if err != nil {
+		log.WithError(err).Error("could not listen for gRPC connections")
+		return
+	}
+
+	go func() {
+		if err := srv.Serve(l); err != nil {
+			log.WithError(err).Warn("gRPC server stopped")
+		}
+	}()
+
+	log.WithField("address", conf.Address).Info("gRPC server listening")
+}
+
 func (s *Server) launchKadcastPeer(ctx context.Context, p *peer.MessageProcessor, g *protocol.Gossip) {
 	// launch kadcast client
 	kadPeer := kadcast.NewKadcastPeer(ctx, s.eventBus, p, g)
+	if scfg, ok := setupShaping(); ok {
+		kadPeer.EnableShaping(scfg)
+	}
+
 	kadPeer.Launch()
 	s.kadPeer = kadPeer
+
+	api.AddHealthCheck("kadcast", kadPeer.Health().Check)
+}
+
+// launchGossipPeers starts the gossip listener, and the Manager maintaining
+// the outbound connections out of the address book.
+func (s *Server) launchGossipPeers(ctx context.Context, p *peer.MessageProcessor, g *protocol.Gossip) {
+	pcfg := cfg.Get().Network.Peers
+
+	book, err := addrbook.New(pcfg.AddrBookFile)
+	if err != nil {
+		log.WithError(err).Fatal("could not load address book")
+	}
+
+	for _, seed := range pcfg.Seeds {
+		if _, err := book.Add(seed, "seed"); err != nil {
+			log.WithError(err).WithField("seed", seed).Warn("invalid seed address")
+		}
+	}
+
+	services := protocol.ServiceFlag(cfg.Get().Network.ServiceFlag)
+	if services == 0 {
+		services = protocol.FullNode
+	}
+
+	s.connector = peer.NewConnector(s.eventBus, g, pcfg.Port, p, services, peer.Create)
+
+	if len(pcfg.IdentityFile) > 0 {
+		id, err := peer.LoadIdentity(pcfg.IdentityFile)
+		if err != nil {
+			log.WithError(err).Fatal("could not load identity")
+		}
+
+		pins, err := peer.ParsePins(pcfg.PinnedPeers)
+		if err != nil {
+			log.WithError(err).Fatal("invalid pinned peers")
+		}
+
+		s.connector.EnableEncryption(id, pcfg.RequireEncryption, pins)
+
+		log.WithField("identity", hex.EncodeToString(id.PublicKey())).
+			Info("encrypted transport enabled")
+	}
+
+	if pcfg.Compression {
+		s.connector.EnableCompression()
+	}
+
+	if scfg, ok := setupShaping(); ok {
+		s.connector.EnableShaping(scfg)
+	}
+
+	go peer.NewManager(s.connector, book, pcfg.Outbound).Run(ctx)
+
+	log.WithField("port", pcfg.Port).Info("gossip peers listening")
+}
+
+// setupReputation opens the ban store and creates the Scorer keeping the
+// reputation of the peers.
+func setupReputation() (reputation.Store, *reputation.Scorer) {
+	rcfg := cfg.Get().Network.Reputation
+
+	store, err := reputation.NewStore(rcfg.BanFile)
+	if err != nil {
+		log.WithError(err).Fatal("could not open ban store")
+	}
+
+	scorer, err := reputation.NewScorer(store, reputation.Config{
+		Threshold:      rcfg.Threshold,
+		BanDuration:    time.Duration(rcfg.BanDuration) * time.Second,
+		DecayPerMinute: rcfg.DecayPerMinute,
+		TxFloodLimit:   rcfg.TxFloodLimit,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("could not load bans")
+	}
+
+	return store, scorer
+}
+
+// setupShaping returns the limits of the outbound traffic, if it is shaped.
+func setupShaping() (shaping.Config, bool) {
+	scfg := cfg.Get().Network.Shaping
+	if !scfg.Enabled {
+		return shaping.Config{}, false
+	}
+
+	c, b, t, i := scfg.Consensus, scfg.Blocks, scfg.Txs, scfg.Inventory
+
+	return shaping.Config{
+		Consensus: shapingLimit("consensus", c.Rate, c.Burst, c.QueueSize, c.MaxDelay, c.Policy),
+		Blocks:    shapingLimit("blocks", b.Rate, b.Burst, b.QueueSize, b.MaxDelay, b.Policy),
+		Txs:       shapingLimit("txs", t.Rate, t.Burst, t.QueueSize, t.MaxDelay, t.Policy),
+		Inventory: shapingLimit("inventory", i.Rate, i.Burst, i.QueueSize, i.MaxDelay, i.Policy),
+	}, true
+}
+
+// shapingLimit returns the Limit of a class, out of its configuration. The
+// max delay is in milliseconds.
+func shapingLimit(class string, rate, burst, queueSize, maxDelay int, policy string) shaping.Limit {
+	p, err := shaping.ParsePolicy(policy)
+	if err != nil {
+		log.WithError(err).WithField("class", class).Fatal("invalid shaping configuration")
+	}
+
+	return shaping.Limit{
+		Rate:      rate,
+		Burst:     burst,
+		QueueSize: queueSize,
+		MaxDelay:  time.Duration(maxDelay) * time.Millisecond,
+		Policy:    p,
+	}
 }
 
 // Setup creates a new EventBus, generates the BLS and the ED25519 Keys,
@@ -86,6 +240,10 @@ func (s *Server) launchKadcastPeer(ctx context.Context, p *peer.MessageProcessor
 // and launches a monitor client (if configuration demands it), and inits the
 // Stake and Blind Bid channels.
 func Setup() *Server {
+	if cfg.Get().Light.Enabled {
+		return setupLight()
+	}
+
 	parentCtx, parentCancel := context.WithCancel(context.Background())
 
 	eventBus := eventbus.New()
@@ -96,6 +254,9 @@ func Setup() *Server {
 	processor := peer.NewMessageProcessor(eventBus)
 	registerPeerServices(processor, db, eventBus, rpcBus)
 
+	banStore, scorer := setupReputation()
+	processor.SetScorer(scorer)
+
 	// Instantiate gRPC client
 	// TODO: get address from config
 	gctx, cancel := context.WithTimeout(parentCtx, time.Duration(cfg.Get().RPC.Rusk.ConnectionTimeout)*time.Millisecond)
@@ -123,26 +284,73 @@ func Setup() *Server {
 		}
 	}
 
-	keys, err := loadConsensusKeys()
+	keys, sgn, signerClosers, err := loadConsensusSigner()
 	if err != nil {
 		log.WithError(err).Fatal("could not load consensus keys")
 	}
 
+	evStore, err := evidence.NewStore(cfg.Get().Consensus.EvidenceFile)
+	if err != nil {
+		log.WithError(err).Fatal("could not open evidence store")
+	}
+
+	capi.SetEvidenceStore(evStore)
+
+	jrnl := journal.New(cfg.Get().Consensus.JournalSize)
+	capi.SetJournal(jrnl)
+	capi.SetDB(db)
+
+	// Report the stake events of the accepted blocks
+	stakes.NewReporter(eventBus, db).Run(parentCtx)
+
+	ccfg := cfg.Get().Consensus
+	timeouts := consensus.NewTimeouts(
+		time.Duration(ccfg.ConsensusTimeOut)*time.Second,
+		time.Duration(ccfg.MinTimeOutMilli)*time.Millisecond,
+		time.Duration(ccfg.MaxTimeOutMilli)*time.Millisecond,
+	)
+
+	capi.SetTimeouts(timeouts)
+
 	e := &consensus.Emitter{
 		EventBus:    eventBus,
 		RPCBus:      rpcBus,
 		Keys:        keys,
 		TimerLength: time.Duration(cfg.Get().Consensus.ConsensusTimeOut) * time.Second,
+		Detector:    evidence.NewDetector(evStore),
+		Journal:     jrnl,
+		Timeouts:    timeouts,
+		Signer:      sgn,
+		Observer:    cfg.Get().Consensus.Observer,
+		Reputation:  scorer,
 	}
 
 	cl := loop.New(e)
+	capi.SetQueues(cl.Queues())
 	processor.Register(topics.Candidate, cl.ProcessCandidate)
 
-	c, err := LaunchChain(parentCtx, cl, proxy, eventBus, rpcBus, nil, db)
+	// Instantiate the gRPC server exposing the node services
+	var grpcServer *grpc.Server
+
+	if cfg.Get().RPC.Enabled {
+		if grpcServer, err = rpcserver.SetupGRPC(rpcserver.FromCfg()); err != nil {
+			log.WithError(err).Error("could not setup gRPC server")
+		}
+	}
+
+	c, err := LaunchChain(parentCtx, cl, proxy, eventBus, rpcBus, grpcServer, db)
 	if err != nil {
 		log.Panic(err)
 	}
 
+	c.SetScorer(scorer)
+
+	if grpcServer != nil {
+		finality.Register(grpcServer, finality.NewService(db))
+		reputation.Register(grpcServer, reputation.NewService(scorer))
+		serveGRPC(grpcServer)
+	}
+
 	processor.Register(topics.Block, c.ProcessBlockFromNetwork)
 
 	// Instantiate GraphQL server
@@ -162,7 +370,6 @@ func Setup() *Server {
 	// Creating the peer factory
 	readerFactory := peer.NewReaderFactory(processor)
 
-	// Create the listener and contact the voucher seeder
 	gossip := protocol.NewGossip()
 
 	// creating the Server
@@ -172,10 +379,13 @@ func Setup() *Server {
 		c:             c,
 		gossip:        gossip,
 		gqlServer:     gqlServer,
-		grpcServer:    nil,
+		grpcServer:    grpcServer,
 		ruskConn:      ruskConn,
 		readerFactory: readerFactory,
 		dbDriver:      driver,
+		evStore:       evStore,
+		banStore:      banStore,
+		signerClosers: signerClosers,
 		ctx:           parentCtx,
 		cancel:        parentCancel,
 	}
@@ -186,6 +396,11 @@ func Setup() *Server {
 		srv.launchKadcastPeer(parentCtx, processor, gossip)
 	}
 
+	// Setting up and launch the gossip peers
+	if cfg.Get().Network.Peers.Port != "" {
+		srv.launchGossipPeers(parentCtx, processor, gossip)
+	}
+
 	// Schedule mempool updates requesting a few seconds after all components
 	// are fully launched
 	go func() {
@@ -219,19 +434,50 @@ func (s *Server) Close() {
 	}
 
 	// Close Rusk client connection
-	_ = s.ruskConn.Close()
+	if s.ruskConn != nil {
+		_ = s.ruskConn.Close()
+	}
+
+	// Close the connection to the trusted node of a light client
+	if s.trustedConn != nil {
+		_ = s.trustedConn.Close()
+	}
 
 	// kadcast client grpc
 	if s.kadPeer != nil {
 		s.kadPeer.Close()
 	}
 
+	if s.connector != nil {
+		if err := s.connector.Close(); err != nil {
+			log.WithError(err).Warn("failed to close gossip listener")
+		}
+	}
+
 	if s.dbDriver != nil {
 		if err := s.dbDriver.Close(); err != nil {
 			log.WithError(err).Warn("failed to close db driver")
 		}
 	}
 
+	if s.evStore != nil {
+		if err := s.evStore.Close(); err != nil {
+			log.WithError(err).Warn("failed to close evidence store")
+		}
+	}
+
+	if s.banStore != nil {
+		if err := s.banStore.Close(); err != nil {
+			log.WithError(err).Warn("failed to close ban store")
+		}
+	}
+
+	for _, c := range s.signerClosers {
+		if err := c.Close(); err != nil {
+			log.WithError(err).Warn("failed to close consensus signer")
+		}
+	}
+
 	s.rpcBus.Close()
 	s.eventBus.Close()
 }
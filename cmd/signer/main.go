@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Command signer is a reference signing daemon for the consensus keys of a
+// provisioner. It keeps the BLS secret key out of the node process, and
+// refuses to sign two different block hashes for the same round and step,
+// even across restarts.
+//
+// The daemon only listens on a unix socket, readable and writable by its
+// owner only, as the requests are not authenticated. Point the node to the
+// socket through the consensus.signerAddress configuration entry.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	keysFileFlag = cli.StringFlag{
+		Name:  "keysfile",
+		Usage: "encrypted consensus keys file, eg: --keysfile=/path/consensus.keys",
+	}
+
+	addressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "unix socket to listen on, eg: --address=/tmp/dusk-signer.sock",
+		Value: "/tmp/dusk-signer.sock",
+	}
+
+	protectionFlag = cli.StringFlag{
+		Name:  "protection",
+		Usage: "slashing-protection database, defaults to the keys file with a .protection suffix",
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "Dusk Signer"
+	app.Usage = "Signing daemon for the consensus keys of a provisioner"
+	app.Flags = []cli.Flag{
+		keysFileFlag,
+		addressFlag,
+		protectionFlag,
+	}
+	app.Action = run
+
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx *cli.Context) error {
+	path := ctx.String(keysFileFlag.Name)
+	if len(path) == 0 {
+		return errors.New("keys file not provided")
+	}
+
+	// As for the node, the password can be provided through the
+	// DUSK_CONSENSUS_KEYS_PASS environment variable.
+	pw, found := os.LookupEnv("DUSK_CONSENSUS_KEYS_PASS")
+	if !found {
+		b, err := readPassword()
+		if err != nil {
+			return err
+		}
+
+		pw = string(b)
+	}
+
+	keys, err := key.NewFromFile(pw, path)
+	if err != nil {
+		return fmt.Errorf("could not load consensus keys: %w", err)
+	}
+
+	protectionFile := ctx.String(protectionFlag.Name)
+	if len(protectionFile) == 0 {
+		protectionFile = path + ".protection"
+	}
+
+	// Votes are remembered across restarts of the daemon.
+	p, err := signer.OpenProtection(protectionFile)
+	if err != nil {
+		return err
+	}
+
+	defer p.Close()
+
+	address := ctx.String(addressFlag.Name)
+
+	// Remove a stale socket left by a previous run
+	_ = os.Remove(address)
+
+	l, err := net.Listen("unix", address)
+	if err != nil {
+		return err
+	}
+
+	// Anyone able to connect can ask for a signature
+	if err := os.Chmod(address, 0o600); err != nil {
+		_ = l.Close()
+		return err
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-interrupt
+		_ = l.Close()
+	}()
+
+	log.WithField("pubkey", util.StringifyBytes(keys.BLSPubKey)).
+		WithField("address", address).
+		Info("signer listening")
+
+	err = signer.Serve(l, signer.NewProtected(signer.NewGuard(signer.NewInMemory(*keys)), p))
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func readPassword() ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil, errors.New("password not provided")
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "Enter password:")
+	return terminal.ReadPassword(fd)
+}
@@ -7,12 +7,18 @@
 package main
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/dusk-network/dusk-blockchain/cmd/utils/grpcclient"
 	"github.com/dusk-network/dusk-blockchain/cmd/utils/mock"
+	"github.com/dusk-network/dusk-blockchain/cmd/utils/sortition"
 	"github.com/dusk-network/dusk-blockchain/cmd/utils/tps"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 
 	"github.com/dusk-network/dusk-blockchain/cmd/utils/metrics"
 
@@ -30,6 +36,7 @@ func main() {
 		setConfigCMD,
 		tpsCMD,
 		automateCMD,
+		sortitionCMD,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -116,6 +123,53 @@ var (
 		Value: 5,
 	}
 
+	provisionersFileFlag = cli.StringFlag{
+		Name:  "provisioners",
+		Usage: "JSON dump of the provisioner set, eg: --provisioners=provisioners.json",
+		Value: "",
+	}
+
+	ruskAddressFlag = cli.StringFlag{
+		Name:  "ruskaddr",
+		Usage: "Rusk gRPC address the provisioner set is fetched from, when no dump is provided, eg: --ruskaddr=127.0.0.1:10000",
+		Value: "127.0.0.1:10000",
+	}
+
+	seedFlag = cli.StringFlag{
+		Name:  "seed",
+		Usage: "hex encoded sortition seed, eg: --seed=b70189c7e7a347989f4fbc1205ce612f755dfc489ecf28f9f883800acf078bd5",
+		Value: "b70189c7e7a347989f4fbc1205ce612f755dfc489ecf28f9f883800acf078bd5",
+	}
+
+	fromRoundFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "first simulated round, eg: --from=1",
+		Value: 1,
+	}
+
+	toRoundFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "last simulated round, eg: --to=1000",
+		Value: 1000,
+	}
+
+	iterationsFlag = cli.IntFlag{
+		Name:  "iterations",
+		Usage: fmt.Sprintf("consensus iterations simulated per round, up to %d, eg: --iterations=1", sortition.MaxIterations),
+		Value: 1,
+	}
+
+	jsonFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "print the results as JSON",
+	}
+
+	dumpFlag = cli.StringFlag{
+		Name:  "dump",
+		Usage: "write the loaded provisioner set to a JSON dump, eg: --dump=provisioners.json",
+		Value: "",
+	}
+
 	metricsCMD = cli.Command{
 		Name:      "metrics",
 		Usage:     "expose a metrics endpoint",
@@ -183,6 +237,27 @@ var (
 		},
 		Description: `Automate consensus participation of a node until the process exits`,
 	}
+
+	// sortition command
+	// Example ./bin/utils sortition --provisioners=provisioners.json --from=1 --to=1000
+	//         ./bin/utils sortition --ruskaddr=127.0.0.1:10000 --dump=provisioners.json.
+	sortitionCMD = cli.Command{
+		Name:      "sortition",
+		Usage:     "simulate the committee extraction of a provisioner set",
+		Action:    sortitionAction,
+		ArgsUsage: "",
+		Flags: []cli.Flag{
+			provisionersFileFlag,
+			ruskAddressFlag,
+			seedFlag,
+			fromRoundFlag,
+			toRoundFlag,
+			iterationsFlag,
+			jsonFlag,
+			dumpFlag,
+		},
+		Description: `Report the expected and simulated rates at which each provisioner is extracted as block generator or reduction committee member`,
+	}
 )
 
 // metricsAction will expose the metrics endpoint.
@@ -228,3 +303,58 @@ func automateAction(ctx *cli.Context) error {
 	sendStakeTimeout := ctx.Int(sendStakeTimeoutFlag.Name)
 	return grpcclient.AutomateStakes(address, sendStakeTimeout)
 }
+
+func sortitionAction(ctx *cli.Context) error {
+	seed, err := hex.DecodeString(ctx.String(seedFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	var p user.Provisioners
+
+	if path := ctx.String(provisionersFileFlag.Name); path != "" {
+		p, err = sortition.LoadFromFile(path)
+	} else {
+		p, err = sortition.LoadFromRusk(ctx.String(ruskAddressFlag.Name), 10*time.Second)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if path := ctx.String(dumpFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		if err := sortition.Dump(f, p); err != nil {
+			return err
+		}
+	}
+
+	if p.Set.Len() == 0 {
+		return errors.New("no provisioners found")
+	}
+
+	rates, err := sortition.Simulate(p, sortition.Params{
+		Seed:       seed,
+		FromRound:  ctx.Uint64(fromRoundFlag.Name),
+		ToRound:    ctx.Uint64(toRoundFlag.Name),
+		Iterations: ctx.Int(iterationsFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(jsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(rates)
+	}
+
+	return sortition.Print(os.Stdout, rates)
+}
@@ -0,0 +1,285 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package sortition
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"google.golang.org/grpc"
+)
+
+// stepsPerIteration is the amount of steps of a consensus iteration
+// (selection, first and second reduction).
+const stepsPerIteration = 3
+
+// MaxIterations is the amount of consensus iterations of a round, bounded by
+// the max consensus step.
+const MaxIterations = int(config.ConsensusMaxStep) / stepsPerIteration
+
+type (
+	// Params of a sortition simulation.
+	Params struct {
+		// Seed used for every simulated round.
+		Seed []byte
+		// FromRound and ToRound delimit the simulated rounds (inclusive).
+		FromRound uint64
+		ToRound   uint64
+		// Iterations is the amount of consensus iterations simulated for
+		// each round, up to MaxIterations.
+		Iterations int
+	}
+
+	// MemberJSON is the representation of a provisioner in a JSON dump.
+	MemberJSON struct {
+		PublicKeyBLS string       `json:"bls_key"`
+		Stakes       []user.Stake `json:"stakes"`
+	}
+
+	// Rates of a provisioner being extracted by the sortition.
+	Rates struct {
+		PublicKeyBLS []byte `json:"-"`
+		BLSKey       string `json:"bls_key"`
+		Stake        uint64 `json:"stake"`
+
+		// Generator rates are the probabilities of being the block
+		// generator of a selection step.
+		ExpectedGenerator  float64 `json:"expected_generator"`
+		SimulatedGenerator float64 `json:"simulated_generator"`
+
+		// Member rates are the probabilities of having at least one seat
+		// in a reduction committee.
+		ExpectedMember  float64 `json:"expected_member"`
+		SimulatedMember float64 `json:"simulated_member"`
+
+		// Seats are the average amount of seats (votes) in a reduction
+		// committee.
+		ExpectedSeats  float64 `json:"expected_seats"`
+		SimulatedSeats float64 `json:"simulated_seats"`
+	}
+)
+
+// LoadFromFile reads a JSON dump of the provisioners. The dump is an array of
+// MemberJSON, whose BLS keys are hex encoded.
+func LoadFromFile(path string) (user.Provisioners, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return user.Provisioners{}, err
+	}
+
+	defer f.Close()
+
+	var members []MemberJSON
+	if err := json.NewDecoder(f).Decode(&members); err != nil {
+		return user.Provisioners{}, err
+	}
+
+	p := user.NewProvisioners()
+
+	for _, m := range members {
+		pk, err := hex.DecodeString(strings.TrimPrefix(m.PublicKeyBLS, "0x"))
+		if err != nil {
+			return user.Provisioners{}, fmt.Errorf("invalid bls key %s: %w", m.PublicKeyBLS, err)
+		}
+
+		for _, s := range m.Stakes {
+			if err := p.Add(pk, s.Value, s.Reward, s.Counter, s.Eligibility); err != nil {
+				return user.Provisioners{}, err
+			}
+		}
+	}
+
+	return *p, nil
+}
+
+// LoadFromRusk fetches the current provisioners from the Rusk state service
+// listening at addr.
+func LoadFromRusk(addr string, timeout time.Duration) (user.Provisioners, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithAuthority("dummy"))
+	if err != nil {
+		return user.Provisioners{}, fmt.Errorf("could not connect to rusk at %s: %w", addr, err)
+	}
+
+	defer conn.Close()
+
+	proxy := transactions.NewProxy(rusk.NewStateClient(conn), timeout, timeout)
+	return proxy.Executor().GetProvisioners(ctx)
+}
+
+// Dump writes the provisioners as a JSON dump, which can be read back with
+// LoadFromFile.
+func Dump(w io.Writer, p user.Provisioners) error {
+	members := make([]MemberJSON, 0, len(p.Members))
+
+	for _, pk := range p.Set {
+		m := p.GetMember(pk.Bytes())
+		members = append(members, MemberJSON{
+			PublicKeyBLS: hex.EncodeToString(m.PublicKeyBLS),
+			Stakes:       m.Stakes,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(members)
+}
+
+// Simulate runs the sortition for all steps of the first iterations of the
+// rounds in range, and returns the rates of each provisioner, sorted by
+// stake.
+//
+// Expected rates are computed from the stakes mature at FromRound. They
+// assume that each seat is extracted proportionally to the stake, which is a
+// good approximation as long as stakes are large compared to the 1 DUSK
+// deducted on each extraction.
+func Simulate(p user.Provisioners, params Params) ([]Rates, error) {
+	if p.Set.Len() == 0 {
+		return nil, errors.New("empty provisioner set")
+	}
+
+	if params.ToRound < params.FromRound {
+		return nil, errors.New("invalid round range")
+	}
+
+	if params.Iterations <= 0 {
+		params.Iterations = 1
+	}
+
+	if params.Iterations > MaxIterations {
+		return nil, fmt.Errorf("too many iterations %d, a round runs up to %d", params.Iterations, MaxIterations)
+	}
+
+	rates := make(map[string]*Rates, p.Set.Len())
+
+	var totalWeight uint64
+
+	for _, pk := range p.Set {
+		m := p.GetMember(pk.Bytes())
+
+		var stake uint64
+
+		for _, s := range m.Stakes {
+			if s.Eligibility <= params.FromRound {
+				stake += s.Value
+			}
+		}
+
+		totalWeight += stake
+		rates[string(m.PublicKeyBLS)] = &Rates{
+			PublicKeyBLS: m.PublicKeyBLS,
+			BLSKey:       hex.EncodeToString(m.PublicKeyBLS),
+			Stake:        stake,
+		}
+	}
+
+	size := config.ConsensusCommitteeSize
+
+	for _, r := range rates {
+		if totalWeight == 0 {
+			break
+		}
+
+		share := float64(r.Stake) / float64(totalWeight)
+		r.ExpectedGenerator = share
+		r.ExpectedMember = 1 - math.Pow(1-share, float64(size))
+		r.ExpectedSeats = math.Min(share*float64(size), float64(r.Stake/config.DUSK))
+	}
+
+	var selections, reductions int
+
+	for round := params.FromRound; round <= params.ToRound; round++ {
+		for step := uint8(1); int(step) <= params.Iterations*stepsPerIteration; step++ {
+			if step%stepsPerIteration == 1 {
+				c := p.CreateVotingCommittee(params.Seed, round, step, config.ConsensusSelectionCommitteeSize)
+				for _, pk := range c.MemberKeys() {
+					rates[string(pk)].SimulatedGenerator++
+				}
+
+				selections++
+
+				continue
+			}
+
+			c := p.CreateVotingCommittee(params.Seed, round, step, size)
+			for _, pk := range c.Set {
+				r := rates[string(pk.Bytes())]
+				r.SimulatedMember++
+				r.SimulatedSeats += float64(c.OccurrencesOf(pk.Bytes()))
+			}
+
+			reductions++
+		}
+
+		// Avoid wrapping around on the last round
+		if round == math.MaxUint64 {
+			break
+		}
+	}
+
+	res := make([]Rates, 0, len(rates))
+
+	for _, r := range rates {
+		r.SimulatedGenerator /= float64(selections)
+		r.SimulatedMember /= float64(reductions)
+		r.SimulatedSeats /= float64(reductions)
+
+		res = append(res, *r)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Stake != res[j].Stake {
+			return res[i].Stake > res[j].Stake
+		}
+
+		return res[i].BLSKey < res[j].BLSKey
+	})
+
+	return res, nil
+}
+
+// Print writes the rates as a table.
+func Print(w io.Writer, rates []Rates) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	_, _ = fmt.Fprintln(tw, "provisioner\tstake (DUSK)\tgenerator exp.\tgenerator sim.\tmember exp.\tmember sim.\tseats exp.\tseats sim.\t")
+
+	for _, r := range rates {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%.2f%%\t%.2f%%\t%.2f%%\t%.2f\t%.2f\t\n",
+			shortKey(r.BLSKey), r.Stake/config.DUSK,
+			100*r.ExpectedGenerator, 100*r.SimulatedGenerator,
+			100*r.ExpectedMember, 100*r.SimulatedMember,
+			r.ExpectedSeats, r.SimulatedSeats)
+	}
+
+	return tw.Flush()
+}
+
+func shortKey(k string) string {
+	if len(k) <= 20 {
+		return k
+	}
+
+	return k[:10] + "..." + k[len(k)-10:]
+}
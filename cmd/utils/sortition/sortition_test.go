@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package sortition
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	p, _ := consensus.MockProvisioners(10)
+	params := Params{Seed: []byte{1, 2, 3}, FromRound: 1, ToRound: 2, Iterations: MaxIterations}
+
+	rates, err := Simulate(*p, params)
+	require.NoError(t, err)
+	require.Len(t, rates, 10)
+
+	var generator, member float64
+
+	for _, r := range rates {
+		generator += r.SimulatedGenerator
+		member += r.SimulatedMember
+		require.InDelta(t, 0.1, r.ExpectedGenerator, 1e-9)
+	}
+
+	// Each selection step extracts a single generator
+	require.InDelta(t, 1, generator, 1e-9)
+	require.Greater(t, member, 1.0)
+}
+
+func TestSimulateInvalidParams(t *testing.T) {
+	p, _ := consensus.MockProvisioners(3)
+	params := Params{FromRound: 1, ToRound: 1}
+
+	// The step of the last iteration would wrap around
+	params.Iterations = MaxIterations + 1
+	_, err := Simulate(*p, params)
+	require.Error(t, err)
+
+	params.Iterations = 86
+	_, err = Simulate(*p, params)
+	require.Error(t, err)
+
+	require.Equal(t, int(config.ConsensusMaxStep)/3, MaxIterations)
+
+	params = Params{FromRound: 2, ToRound: 1}
+	_, err = Simulate(*p, params)
+	require.Error(t, err)
+
+	_, err = Simulate(*user.NewProvisioners(), Params{})
+	require.Error(t, err)
+}
+
+func TestDumpAndLoad(t *testing.T) {
+	p, _ := consensus.MockProvisioners(3)
+
+	var buf bytes.Buffer
+	require.NoError(t, Dump(&buf, *p))
+
+	path := filepath.Join(t.TempDir(), "provisioners.json")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	loaded, err := LoadFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, p.Set.Len(), loaded.Set.Len())
+
+	for _, pk := range p.Set {
+		require.Equal(t, p.GetMember(pk.Bytes()).Stakes, loaded.GetMember(pk.Bytes()).Stakes)
+	}
+
+	var out bytes.Buffer
+	rates, err := Simulate(loaded, Params{FromRound: 1, ToRound: 1, Iterations: 1})
+	require.NoError(t, err)
+	require.NoError(t, Print(&out, rates))
+	require.Contains(t, out.String(), "generator exp.")
+}
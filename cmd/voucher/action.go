@@ -7,6 +7,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"os"
 	"os/signal"
 	"strconv"
@@ -51,6 +52,22 @@ func action(ctx *cli.Context) error {
 	port := ctx.Int(portFlag.Name)
 	c := peer.NewConnector(eb, protocol.NewGossip(), strconv.Itoa(port), processor, protocol.FullNode, challenger.SendChallenge)
 
+	if path := ctx.String(identityFlag.Name); path != "" {
+		id, err := peer.LoadIdentity(path)
+		if err != nil {
+			log.WithError(err).Fatal("could not load identity")
+		}
+
+		c.EnableEncryption(id, ctx.Bool(requireEncryptionFlag.Name), nil)
+
+		log.WithField("identity", hex.EncodeToString(id.PublicKey())).
+			Info("encrypted transport enabled")
+	}
+
+	if ctx.Bool(compressFlag.Name) {
+		c.EnableCompression()
+	}
+
 	log.
 		WithField("port", port).
 		Info("Voucher seeder up & accepting connections")
@@ -25,6 +25,18 @@ var (
 		Usage: "Dusk hostname , eg: --hostname=127.0.0.1",
 		Value: "127.0.0.1",
 	}
+	identityFlag = cli.StringFlag{
+		Name:  "identity",
+		Usage: "identity key file enabling the encrypted transport, created if missing , eg: --identity=voucher.pem",
+	}
+	requireEncryptionFlag = cli.BoolFlag{
+		Name:  "require-encryption",
+		Usage: "refuse peers not supporting the encrypted transport",
+	}
+	compressFlag = cli.BoolFlag{
+		Name:  "compress",
+		Usage: "compress the messages exchanged with the peers supporting it",
+	}
 )
 
 // CLIFlags flags usable in a CLI context.
@@ -32,4 +44,7 @@ var CLIFlags = []cli.Flag{
 	LogLevelFlag,
 	portFlag,
 	hostnameFlag,
+	identityFlag,
+	requireEncryptionFlag,
+	compressFlag,
 }
@@ -129,7 +129,12 @@ func (n *Network) SendWireMsg(ind uint, msg []byte, writeTimeout int) error {
 
 	targetNode := n.nodes[ind]
 	addr := targetNode.Cfg.RPC.Rusk.Address
-	client, _ := kadcast.CreateNetworkClient(context.Background(), "unix", addr, 5000)
+	client, conn, err := kadcast.CreateNetworkClient(context.Background(), "unix", addr, 5000)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
 
 	broadcast := rusk.BroadcastMessage{
 		Message: msg,
@@ -137,7 +142,7 @@ func (n *Network) SendWireMsg(ind uint, msg []byte, writeTimeout int) error {
 
 	ruskCtx := kadcast.InjectRuskVersion(context.Background())
 
-	_, err := client.Broadcast(ruskCtx, &broadcast)
+	_, err = client.Broadcast(ruskCtx, &broadcast)
 
 	return err
 }
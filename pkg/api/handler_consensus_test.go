@@ -7,6 +7,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -18,6 +19,13 @@ import (
 	"github.com/drewolson/testflight"
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/journal"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakes"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/traffic"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,6 +55,31 @@ func TestConsensusAPISmokeTest(t *testing.T) {
 			name:      "Get event queue status",
 			Data:      `{}`,
 		},
+		{
+			targetURL: "/consensus/timeouts",
+			name:      "Get step timeouts",
+			Data:      `{}`,
+		},
+		{
+			targetURL: "/consensus/committeecache",
+			name:      "Get committee cache stats",
+			Data:      `{}`,
+		},
+		{
+			targetURL: "/consensus/queues",
+			name:      "Get message queues",
+			Data:      `{}`,
+		},
+		{
+			targetURL: "/consensus/stakes?height_begin=0&height_end=0",
+			name:      "Get stake events",
+			Data:      `{}`,
+		},
+		{
+			targetURL: "/consensus/stakes/epoch?epoch=0",
+			name:      "Get stake epoch report",
+			Data:      `{}`,
+		},
 	}
 
 	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
@@ -72,51 +105,72 @@ func TestConsensusAPIProvisioners(t *testing.T) {
 	require.Nil(t, err)
 
 	provisioners, _ := consensus.MockProvisioners(5)
-	members := make([]*capi.Member, len(provisioners.Members))
-	i := 0
-
-	for _, v := range provisioners.Members {
-		var stakes []capi.Stake
-
-		for _, s := range v.Stakes {
-			stake := capi.Stake{
-				Value:       s.Value,
-				Reward:      s.Reward,
-				Counter:     s.Counter,
-				Eligibility: s.Eligibility,
-			}
-			stakes = append(stakes, stake)
-		}
-
-		member := capi.Member{
-			PublicKeyBLS: v.PublicKeyBLS,
-			Stakes:       stakes,
-		}
-
-		members[i] = &member
-		i++
-	}
 
-	provisioner := capi.ProvisionerJSON{
-		ID:      1,
-		Set:     provisioners.Set,
-		Members: members,
-	}
+	_, db := lite.CreateDBConnection()
+	require.Nil(t, db.Update(func(t database.Transaction) error {
+		return t.StoreProvisioners(1, provisioners)
+	}))
 
-	err = apiServer.store.Save(&provisioner)
-	require.Nil(t, err)
+	capi.SetDB(db)
 
-	var provisionerJSON capi.ProvisionerJSON
-	err = apiServer.store.Find("ID", uint64(1), &provisionerJSON)
-	require.Nil(t, err)
-	require.NotNil(t, provisioners)
+	defer capi.SetDB(nil)
 
 	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
 		targetURL := "/consensus/provisioners?height=1"
 		response := r.Get(targetURL)
 		require.NotNil(t, response)
+		require.Equal(t, 200, response.StatusCode)
 
-		require.NotEmpty(t, response.RawBody)
+		var provisionerJSON capi.ProvisionerJSON
+		require.Nil(t, json.Unmarshal(response.RawBody, &provisionerJSON))
+		require.Equal(t, uint64(1), provisionerJSON.ID)
+		require.Len(t, provisionerJSON.Members, 5)
+
+		// No snapshot precedes the first one
+		response = r.Get("/consensus/provisioners?height=0")
+		require.Equal(t, 404, response.StatusCode)
+	})
+}
+
+func TestConsensusAPIStakes(t *testing.T) {
+	apiServer, err := NewHTTPServer(nil, nil)
+	require.Nil(t, err)
+
+	pk := make([]byte, user.BlsKeySize)
+	events := []user.StakeEvent{
+		{Height: 1, PublicKeyBLS: pk, Status: user.StakePending, RewardDelta: 1},
+		{Height: 2, PublicKeyBLS: pk, Previous: user.StakePending, Status: user.StakeEligible, RewardDelta: 2},
+	}
+
+	_, db := lite.CreateDBConnection()
+	require.Nil(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreStakeEvents(1, events[:1]); err != nil {
+			return err
+		}
+
+		return t.StoreStakeEvents(2, events[1:])
+	}))
+
+	capi.SetDB(db)
+
+	defer capi.SetDB(nil)
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		response := r.Get(fmt.Sprintf("/consensus/stakes?height_begin=2&height_end=5&bls_key=%x", pk))
+		require.Equal(t, 200, response.StatusCode)
+		require.Contains(t, response.Body, `"previous":"pending","status":"eligible"`)
+
+		var stakeEvents []user.StakeEvent
+		require.Nil(t, json.Unmarshal(response.RawBody, &stakeEvents))
+		require.Len(t, stakeEvents, 1)
+
+		response = r.Get("/consensus/stakes/epoch?epoch=0")
+		require.Equal(t, 200, response.StatusCode)
+
+		var report stakes.EpochReport
+		require.Nil(t, json.Unmarshal(response.RawBody, &report))
+		require.Len(t, report.Events, 2)
+		require.Equal(t, []stakes.EpochReward{{PublicKeyBLS: pk, Reward: 3}}, report.Rewards)
 	})
 }
 
@@ -132,22 +186,19 @@ func TestConsensusAPIRoundInfo(t *testing.T) {
 	apiServer, err := NewHTTPServer(nil, nil)
 	require.Nil(t, err)
 
+	jrnl := journal.New(10)
+	capi.SetJournal(jrnl)
+
+	defer capi.SetJournal(nil)
+
 	for i := 1; i < 6; i++ {
-		// steps array
-		for j := 0; j < 5; j++ {
-			roundInfo := capi.RoundInfoJSON{
-				Round:  uint64(i),
-				Step:   uint8(j),
-				Method: "StopConsensus",
-				Name:   "",
-			}
-			err = apiServer.store.Save(&roundInfo)
-			require.Nil(t, err)
+		jrnl.StartRound(uint64(i))
 
-			var roundInfoArr []capi.RoundInfoJSON
-			err := apiServer.store.DB.Select(q.Gte("ID", uint64(0)), q.Lte("ID", 5)).Find(&roundInfoArr)
-			require.Nil(t, err)
-			require.NotNil(t, roundInfo)
+		// steps array
+		for j := 1; j < 5; j++ {
+			jrnl.StartStep(uint64(i), uint8(j), "selection")
+			jrnl.AddVote(uint64(i), uint8(j), []byte{1, 2, 3}, []byte{4, 5, 6})
+			jrnl.EndStep(uint64(i), uint8(j))
 		}
 	}
 
@@ -161,8 +212,16 @@ func TestConsensusAPIRoundInfo(t *testing.T) {
 
 			require.True(t, len(response.RawBody) > 50)
 
-			body := string(response.RawBody)
-			fmt.Println("roundinfo body", body)
+			var roundInfoArr []capi.RoundInfoJSON
+			require.NoError(t, json.Unmarshal(response.RawBody, &roundInfoArr))
+			// rounds are recorded from 1 to 5
+			expected := 5
+			if i > 1 {
+				expected = 6 - i
+			}
+
+			require.Len(t, roundInfoArr, expected)
+			require.Len(t, roundInfoArr[0].Steps, 4)
 		}
 	})
 }
@@ -294,3 +353,29 @@ func TestP2PLogsWriter(t *testing.T) {
 		require.True(t, len(body) > 100)
 	})
 }
+
+func TestP2PTraffic(t *testing.T) {
+	apiServer, err := NewHTTPServer(nil, nil)
+	require.Nil(t, err)
+
+	traffic.Record(traffic.Gossip, "127.0.0.1:7001", traffic.In, []byte{byte(topics.Tx)}, 100)
+	traffic.Record(traffic.Kadcast, traffic.Broadcast, traffic.Out, []byte{byte(topics.Block)}, 200)
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		response := r.Get("/p2p/traffic?network=gossip&peer=127.0.0.1:7001")
+		require.Equal(t, 200, response.StatusCode)
+
+		var samples []traffic.Sample
+		require.Nil(t, json.Unmarshal(response.RawBody, &samples))
+		require.Equal(t, []traffic.Sample{{
+			Network: traffic.Gossip,
+			Peer:    "127.0.0.1:7001",
+			Topic:   "tx",
+			Counter: traffic.Counter{MessagesIn: 1, BytesIn: 100},
+		}}, samples)
+
+		response = r.Get("/metrics")
+		require.Equal(t, 200, response.StatusCode)
+		require.Contains(t, response.Body, `dusk_p2p_bytes_total{network="kadcast",peer="broadcast",topic="block",direction="out"} 200`)
+	})
+}
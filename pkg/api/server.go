@@ -8,10 +8,13 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
@@ -29,8 +32,42 @@ import (
 var (
 	router *pat.Router
 	log    = logrus.WithField("package", "api")
+
+	// checks of the node subsystems, run by the healthcheck endpoint
+	checksLock sync.RWMutex
+	checks     = make(map[string]func(context.Context) error)
 )
 
+// AddHealthCheck adds the check of a node subsystem to the healthcheck
+// endpoint. Subsystems may be launched after the API server.
+func AddHealthCheck(name string, check func(context.Context) error) {
+	checksLock.Lock()
+	defer checksLock.Unlock()
+
+	checks[name] = check
+}
+
+// runHealthChecks returns the error of the first failing subsystem check.
+func runHealthChecks(ctx context.Context) error {
+	checksLock.RLock()
+	defer checksLock.RUnlock()
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := checks[name](ctx); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
 // Server defines the HTTP server of the API.
 type Server struct {
 	// Node components.
@@ -108,6 +145,10 @@ func (s *Server) InitRouting() *pat.Router {
 				},
 			),
 		),
+
+		healthcheck.WithChecker(
+			"subsystems", healthcheck.CheckerFunc(runHealthChecks),
+		),
 	))
 
 	// init consensus API services
@@ -116,8 +157,16 @@ func (s *Server) InitRouting() *pat.Router {
 	r.HandleFunc("/consensus/provisioners", capi.GetProvisionersHandler).Methods("GET")
 	r.HandleFunc("/consensus/roundinfo", capi.GetRoundInfoHandler).Methods("GET")
 	r.HandleFunc("/consensus/eventqueuestatus", capi.GetEventQueueStatusHandler).Methods("GET")
+	r.HandleFunc("/consensus/evidence", capi.GetEvidenceHandler).Methods("GET")
+	r.HandleFunc("/consensus/stakes", capi.GetStakesHandler).Methods("GET")
+	r.HandleFunc("/consensus/stakes/epoch", capi.GetStakesEpochHandler).Methods("GET")
+	r.HandleFunc("/consensus/timeouts", capi.GetTimeoutsHandler).Methods("GET")
+	r.HandleFunc("/consensus/committeecache", capi.GetCommitteeCacheHandler).Methods("GET")
+	r.HandleFunc("/consensus/queues", capi.GetQueuesHandler).Methods("GET")
 	r.HandleFunc("/p2p/logs", capi.GetP2PLogsHandler).Methods("GET")
 	r.HandleFunc("/p2p/count", capi.GetP2PCountHandler).Methods("GET")
+	r.HandleFunc("/p2p/traffic", capi.GetP2PTrafficHandler).Methods("GET")
+	r.HandleFunc("/metrics", capi.GetMetricsHandler).Methods("GET")
 
 	return r
 }
@@ -26,6 +26,82 @@ type networkConfiguration struct {
 	MaxDupeMapExpire uint32
 
 	ServiceFlag uint8
+
+	Reputation reputationConfiguration
+	Peers      peersConfiguration
+	Shaping    shapingConfiguration
+}
+
+// gossip peers configs.
+type peersConfiguration struct {
+	// Port is the port the gossip listener binds to. If empty, the node does
+	// not take part in the gossip network.
+	Port string
+	// Outbound is the number of outbound connections to maintain.
+	Outbound int
+	// AddrBookFile is the path to the file persisting the address book. If
+	// empty, the book is kept in memory.
+	AddrBookFile string
+	// Seeds are the addresses the address book is bootstrapped with.
+	Seeds []string
+	// IdentityFile is the path to the identity key file of the node, created
+	// if missing. If set, the connections are upgraded to the encrypted
+	// transport with the peers supporting it.
+	IdentityFile string
+	// RequireEncryption refuses the peers not supporting the encrypted
+	// transport.
+	RequireEncryption bool
+	// PinnedPeers are the identities expected from the peers, as
+	// "ip:port=key" entries with a hex encoded key. The connections dialed
+	// to these addresses are refused unless encrypted with the pinned key.
+	PinnedPeers []string
+	// Compression compresses the messages exchanged with the peers supporting
+	// it.
+	Compression bool
+}
+
+// outbound traffic shaping configs.
+type shapingConfiguration struct {
+	// Enabled queues the messages sent to each peer, and to the Kadcast
+	// service, by class.
+	Enabled bool
+
+	Consensus shapingClassConfiguration
+	Blocks    shapingClassConfiguration
+	Txs       shapingClassConfiguration
+	Inventory shapingClassConfiguration
+}
+
+// outbound traffic shaping configs of a class of messages.
+type shapingClassConfiguration struct {
+	// Rate is the number of bytes sent each second. Zero disables the limit.
+	Rate int
+	// Burst is the number of bytes sent at once. It defaults to Rate.
+	Burst int
+	// QueueSize is the number of messages queued. Once full, the oldest
+	// message is dropped.
+	QueueSize int
+	// MaxDelay is the number of milliseconds a message stays queued. Zero
+	// disables the limit.
+	MaxDelay int
+	// Policy of the messages sent over the Rate, either "defer" or "drop".
+	Policy string
+}
+
+// peer reputation configs.
+type reputationConfiguration struct {
+	// Threshold is the score at which a peer is banned.
+	Threshold float64
+	// BanDuration is the number of seconds a peer stays banned.
+	BanDuration int64
+	// DecayPerMinute is the score a peer recovers each minute.
+	DecayPerMinute float64
+	// TxFloodLimit is the number of transactions a peer is allowed to send
+	// each second. Zero disables the limit.
+	TxFloodLimit int
+	// BanFile is the path to the file persisting the bans. If empty, bans
+	// are kept in memory.
+	BanFile string
 }
 
 type clientConfiguration struct {
@@ -40,6 +116,34 @@ type kadcastConfiguration struct {
 	BootstrapAddr []string
 
 	Grpc clientConfiguration
+
+	// Native runs the Kadcast protocol within the node, instead of
+	// connecting to the network service of Rusk.
+	Native kadcastNativeConfiguration
+}
+
+// native Kadcast configs. The node is reachable on Kadcast.Address, and joins
+// the network through Kadcast.BootstrapAddr.
+type kadcastNativeConfiguration struct {
+	Enabled bool
+	// Listen is the address the UDP socket binds to. Defaults to
+	// Kadcast.Address.
+	Listen string
+	// Beta is the number of peers of each bucket a message is delegated to.
+	Beta int
+	// RedundancyFactor is the ratio of FEC encoded blocks to source blocks.
+	RedundancyFactor uint8
+}
+
+// light client configs.
+type lightConfiguration struct {
+	// Enabled runs the node as a light client, syncing and verifying the
+	// block headers only.
+	Enabled bool
+
+	// Trusted is the gRPC endpoint of the full node the light client
+	// fetches the provisioners from.
+	Trusted clientConfiguration
 }
 
 // pkg/core/database package configs.
@@ -59,6 +163,8 @@ type profileConfiguration struct {
 
 // pkg/rpc package configs.
 type rpcConfiguration struct {
+	// Enabled starts the gRPC server exposing the node services.
+	Enabled             bool
 	Network             string
 	Address             string
 	SessionDurationMins uint
@@ -166,6 +272,48 @@ type consensusConfiguration struct {
 
 	// ThrottleIterMilli determines number of Milliseconds to throttle VerifyST.
 	ThrottleIterMilli int64
+
+	// EvidenceFile is the path to the file storing evidence of conflicting
+	// votes signed by provisioners. If empty, evidence is kept in memory.
+	EvidenceFile string
+
+	// MinTimeOutMilli is the lower bound, in milliseconds, of the adaptive
+	// step timeouts. If zero, ConsensusTimeOut is used.
+	MinTimeOutMilli int64
+	// MaxTimeOutMilli is the upper bound, in milliseconds, of the adaptive
+	// step timeouts. If zero, it defaults to 60 seconds.
+	MaxTimeOutMilli int64
+
+	// JournalSize is the amount of consensus rounds recorded by the round
+	// journal. If zero, a default size is used.
+	JournalSize int
+
+	// SignerAddress is the path to the unix socket of a remote signing
+	// daemon holding the consensus keys. If empty, the keys are loaded from
+	// KeysFile.
+	SignerAddress string
+	// SignerTimeoutMilli is the timeout, in milliseconds, of a request to
+	// the signing daemon.
+	SignerTimeoutMilli int64
+
+	// ProtectionFile is the path to the slashing-protection database,
+	// recording the highest vote signed for each consensus message. If
+	// empty, it defaults to KeysFile with a ".protection" suffix when the
+	// keys are loaded from KeysFile, and is disabled otherwise.
+	ProtectionFile string
+
+	// SelectionCutoffMilli ends the collection of the candidate blocks of a
+	// selection step, in milliseconds since the start of the step, once at
+	// least a valid candidate has been received. If zero, candidates are
+	// collected until every generator has sent one, or until the step times
+	// out.
+	SelectionCutoffMilli int64
+
+	// Observer runs the consensus in dry-run mode: the node follows the
+	// consensus, but never signs nor broadcasts a consensus message. The
+	// keys are only used to tell in which committees the node would sit. If
+	// KeysFile is empty, random keys are used.
+	Observer bool
 }
 
 type stateConfiguration struct {
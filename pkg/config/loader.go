@@ -60,6 +60,7 @@ type Registry struct {
 	Mempool   mempoolConfiguration
 	Consensus consensusConfiguration
 	State     stateConfiguration
+	Light     lightConfiguration
 
 	RPC rpcConfiguration
 	Gql gqlConfiguration
@@ -24,57 +24,64 @@ import (
 var log = lg.WithField("process", "consensus")
 
 // Requestor serves to retrieve certain Candidate messages from peers in the
-// network.
+// network. Several candidates can be requested at the same time.
 type Requestor struct {
-	lock           sync.RWMutex
-	requesting     bool
-	publisher      eventbus.Publisher
-	candidateQueue chan block.Block
+	lock      sync.Mutex
+	publisher eventbus.Publisher
+	// waiters are the channels of the pending requests, by candidate hash.
+	waiters map[string][]chan block.Block
 }
 
 // NewRequestor returns an initialized Requestor struct.
 func NewRequestor(publisher eventbus.Publisher) *Requestor {
 	return &Requestor{
-		publisher:      publisher,
-		candidateQueue: make(chan block.Block, 100),
+		publisher: publisher,
+		waiters:   make(map[string][]chan block.Block),
 	}
 }
 
 // ProcessCandidate will process a received Candidate message.
-// Invalid and non-matching Candidate messages are discarded.
+// Invalid and non-requested Candidate messages are discarded.
 func (r *Requestor) ProcessCandidate(srcPeerID string, msg message.Message) ([]bytes.Buffer, error) {
-	if r.isRequesting() {
-		if err := Validate(msg); err != nil {
-			return nil, err
-		}
+	cm, ok := msg.Payload().(block.Block)
+	if !ok || cm.Header == nil || !r.isRequested(cm.Header.Hash) {
+		return nil, nil
+	}
+
+	if err := Validate(msg); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
 
-		cm := msg.Payload().(block.Block)
-		r.candidateQueue <- cm
+	key := string(cm.Header.Hash)
+	for _, ch := range r.waiters[key] {
+		// Channels are buffered, and only written once.
+		ch <- cm
 	}
 
+	delete(r.waiters, key)
+
 	return nil, nil
 }
 
 // RequestCandidate will attempt to fetch a Candidate message for a given hash
 // from the network.
 func (r *Requestor) RequestCandidate(ctx context.Context, hash []byte) (block.Block, error) {
-	r.setRequesting(true)
-	defer r.setRequesting(false)
+	ch := r.register(hash)
+	defer r.unregister(hash, ch)
 
 	if err := r.sendGetCandidate(hash); err != nil {
 		return block.Block{}, nil
 	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			log.WithField("hash", hex.EncodeToString(hash)).Debug("failed to receive candidate from the network")
-			return block.Block{}, errors.New("failed to receive candidate from the network")
-		case cm := <-r.candidateQueue:
-			if bytes.Equal(cm.Header.Hash, hash) {
-				return cm, nil
-			}
-		}
+	select {
+	case <-ctx.Done():
+		log.WithField("hash", hex.EncodeToString(hash)).Debug("failed to receive candidate from the network")
+		return block.Block{}, errors.New("failed to receive candidate from the network")
+	case cm := <-ch:
+		return cm, nil
 	}
 }
 
@@ -107,16 +114,42 @@ func (r *Requestor) sendGetCandidate(hash []byte) error {
 	return nil
 }
 
-func (r *Requestor) setRequesting(status bool) {
+func (r *Requestor) register(hash []byte) chan block.Block {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ch := make(chan block.Block, 1)
+	r.waiters[string(hash)] = append(r.waiters[string(hash)], ch)
+
+	return ch
+}
+
+func (r *Requestor) unregister(hash []byte, ch chan block.Block) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	r.requesting = status
+
+	key := string(hash)
+	waiters := r.waiters[key]
+
+	for i, w := range waiters {
+		if w == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(r.waiters, key)
+		return
+	}
+
+	r.waiters[key] = waiters
 }
 
-func (r *Requestor) isRequesting() bool {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
+func (r *Requestor) isRequested(hash []byte) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 
-	req := r.requesting
-	return req
+	_, ok := r.waiters[string(hash)]
+	return ok
 }
@@ -27,23 +27,33 @@ func TestCandidateQueue(t *testing.T) {
 	req := NewRequestor(bus)
 
 	// Getting a block when no request is made should not result in it being
-	// pushed to the candidateQueue
+	// delivered
 	_, err := req.ProcessCandidate("", message.New(topics.Candidate, *genesis.Decode()))
 	assert.NoError(err)
 
-	assert.Empty(req.candidateQueue)
+	assert.Empty(req.waiters)
 
-	// Getting a block when requesting should make it end up in the queue
+	// Getting a block when requesting should deliver it to all the requests
+	// for its hash
 	c := genesis.Decode()
 
-	req.setRequesting(true)
+	ch1 := req.register(c.Header.Hash)
+	ch2 := req.register(c.Header.Hash)
+	other := req.register([]byte{1, 2, 3})
 
 	_, err = req.ProcessCandidate("", message.New(topics.Candidate, *c))
 	assert.NoError(err)
 
-	assert.True(len(req.candidateQueue) == 1)
-	c2 := <-req.candidateQueue
+	c1 := <-ch1
+	assert.True(c.Equals(&c1))
+
+	c2 := <-ch2
 	assert.True(c.Equals(&c2))
+
+	assert.Empty(other)
+
+	req.unregister([]byte{1, 2, 3}, other)
+	assert.Empty(req.waiters)
 }
 
 func TestRequestor(t *testing.T) {
@@ -16,7 +16,6 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
-	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/base58"
@@ -26,13 +25,13 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
-	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"github.com/sirupsen/logrus"
 	logger "github.com/sirupsen/logrus"
@@ -112,7 +111,15 @@ type Chain struct {
 	ctx context.Context
 
 	blacklisted dupemap.TmpMap
-	verified    sortedset.SafeSet
+
+	// verified caches the outcome of the state transition verification of
+	// the candidates for the next height, so that candidates verified
+	// speculatively are not verified again. Transient errors are not cached.
+	verifiedLock sync.Mutex
+	verified     map[string]error
+
+	// scorer keeps the reputation of the peers. Optional.
+	scorer *reputation.Scorer
 }
 
 // New returns a new chain object. It accepts the EventBus (for messages coming
@@ -131,7 +138,7 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 		loop:              loop,
 		stopConsensusChan: make(chan struct{}),
 		blacklisted:       *dupemap.NewTmpMap(1000, 120),
-		verified:          sortedset.NewSafeSet(),
+		verified:          make(map[string]error),
 	}
 
 	chain.synchronizer = newSynchronizer(db, chain)
@@ -152,6 +159,14 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 		return nil, err
 	}
 
+	// Snapshot the provisioners of the tip, as the blocks stored before
+	// the snapshots were introduced have none.
+	if err := db.Update(func(t database.Transaction) error {
+		return t.StoreProvisioners(chain.tip.Header.Height, chain.p)
+	}); err != nil {
+		return nil, err
+	}
+
 	return chain, nil
 }
 
@@ -250,7 +265,7 @@ func (c *Chain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]
 
 	// Ensure the received block provides a valid hash
 	if err := verifiers.CheckHash(&blk); err != nil {
-		return nil, err
+		return nil, reputation.Blame(reputation.InvalidBlock, err)
 	}
 
 	c.lock.Lock()
@@ -333,7 +348,7 @@ func (c *Chain) TryNextConsecutiveBlockOutSync(blk block.Block, metadata *messag
 // from the network during in-sync state. Returns err if the block is not valid.
 func (c *Chain) TryNextConsecutiveBlockInSync(blk block.Block, metadata *message.Metadata) error {
 	// Make an attempt to accept a new block. If succeeds, we could safely restart the Consensus Loop.
-	// If not, peer reputation score should be decreased.
+	// If not, the peer is penalized for the offenses blamed in the error.
 	if err := c.acceptSuccessiveBlock(blk, metadata); err != nil {
 		return err
 	}
@@ -364,6 +379,12 @@ func (c *Chain) TryNextConsecutiveBlockIsValid(blk block.Block) error {
 	return c.isValidHeader(blk, *c.tip, *c.p, l, true)
 }
 
+// SetScorer sets the Scorer penalizing the peers which send invalid blocks or
+// do not deliver the blocks requested during synchronization.
+func (c *Chain) SetScorer(s *reputation.Scorer) {
+	c.scorer = s
+}
+
 // ProcessSyncTimerExpired called by outsync timer when a peer does not provide GetData response.
 // It implements transition back to inSync state.
 // strPeerAddr is the address of the peer initiated the syncing but failed to deliver.
@@ -371,6 +392,8 @@ func (c *Chain) ProcessSyncTimerExpired(strPeerAddr string) error {
 	log.WithField("curr", c.tip.Header.Height).
 		WithField("src_addr", strPeerAddr).Warn("sync timer expired")
 
+	c.scorer.Penalize(strPeerAddr, reputation.SyncTimeout)
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -583,7 +606,9 @@ func (c *Chain) isValidHeader(newBlock, prevBlock block.Block, provisioners user
 	var err error
 	if err = agreement.CheckBlockCertificate(provisioners, newBlock, prevBlock.Header.Seed); err != nil {
 		l.WithError(err).Error("certificate verification failed")
-		return err
+		// Unlike the sanity checks, which may fail on a fork, an invalid
+		// certificate can not be sent by an honest peer
+		return reputation.Blame(reputation.InvalidBlock, err)
 	}
 
 	return nil
@@ -629,7 +654,10 @@ func (c *Chain) acceptBlock(blk block.Block, withSanityCheck bool) error {
 	}
 
 	c.tip = b
-	c.verified.Reset()
+
+	c.verifiedLock.Lock()
+	c.verified = make(map[string]error)
+	c.verifiedLock.Unlock()
 
 	// 5. Perform all post-events on accepting a block
 	c.postAcceptBlock(*b, l)
@@ -667,6 +695,12 @@ func (c *Chain) persist(b *block.Block) error {
 			return err
 		}
 
+		// Snapshot the provisioners, to verify the certificates at this
+		// height later on
+		if err = t.StoreProvisioners(b.Header.Height, c.p); err != nil {
+			return err
+		}
+
 		// Persist Rusk state
 		if p {
 			if err = c.proxy.Executor().Persist(c.ctx, b.Header.StateHash); err != nil {
@@ -732,12 +766,12 @@ func (c *Chain) VerifyCandidateBlock(ctx context.Context, candidate block.Block)
 
 	// Locking here would enable Chain to perform VST calls in a row, checking
 	// hash against cached hashes firstly.
-	c.verified.Lock()
-	defer c.verified.Unlock()
+	c.verifiedLock.Lock()
+	defer c.verifiedLock.Unlock()
 
-	if c.verified.Contains(candidate.Header.Hash) {
+	if err, ok := c.verified[string(candidate.Header.Hash)]; ok {
 		// already verified
-		return nil
+		return err
 	}
 
 	stateRoot, err = c.proxy.Executor().VerifyStateTransition(ctx, candidate.Txs, candidate.Header.GasLimit,
@@ -746,16 +780,15 @@ func (c *Chain) VerifyCandidateBlock(ctx context.Context, candidate block.Block)
 		return err
 	}
 
-	c.verified.Insert(candidate.Header.Hash)
-
 	if !bytes.Equal(stateRoot, candidate.Header.StateHash) {
 		log.WithField("candidate_state_hash", hex.EncodeToString(candidate.Header.StateHash)).
 			WithField("vst_state_hash", hex.EncodeToString(stateRoot)).Error(errUnexpectedStateHash.Error())
 
-		return errUnexpectedStateHash
+		err = errUnexpectedStateHash
 	}
 
-	return nil
+	c.verified[string(candidate.Header.Hash)] = err
+	return err
 }
 
 // ExecuteStateTransition calls Rusk ExecuteStateTransitiongrpc method.
@@ -793,6 +826,26 @@ func (c *Chain) getRoundUpdate() consensus.RoundUpdate {
 	return r.Copy().(consensus.RoundUpdate)
 }
 
+// provisionersAt returns the provisioners in effect after the block at
+// height, which verify the certificate of the block following it. If no
+// snapshot covers the height, the current provisioners are returned.
+func (c *Chain) provisionersAt(height uint64) user.Provisioners {
+	var p *user.Provisioners
+
+	err := c.db.View(func(t database.Transaction) error {
+		var err error
+		p, err = t.FetchProvisioners(height)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).WithField("height", height).
+			Warn("no provisioners snapshot, using the current provisioners")
+		return *c.p
+	}
+
+	return *p
+}
+
 // GetSyncProgress returns how close the node is to being synced to the tip,
 // as a percentage value.
 func (c *Chain) GetSyncProgress(_ context.Context, e *node.EmptyRequest) (*node.SyncProgressResponse, error) {
@@ -823,44 +876,3 @@ func (c *Chain) CalculateSyncProgress() float64 {
 func (c *Chain) RebuildChain(_ context.Context, e *node.EmptyRequest) (*node.GenericResponse, error) {
 	return &node.GenericResponse{Response: "Unimplemented"}, nil
 }
-
-//nolint
-func (c *Chain) storeStakesInStormDB(blkHeight uint64) {
-	store := capi.GetStormDBInstance()
-	members := make([]*capi.Member, len(c.p.Members))
-	i := 0
-
-	for _, v := range c.p.Members {
-		var stakes []capi.Stake
-
-		for _, s := range v.Stakes {
-			stake := capi.Stake{
-				Value:       s.Value,
-				Reward:      s.Reward,
-				Counter:     s.Counter,
-				Eligibility: s.Eligibility,
-			}
-
-			stakes = append(stakes, stake)
-		}
-
-		member := capi.Member{
-			PublicKeyBLS: v.PublicKeyBLS,
-			Stakes:       stakes,
-		}
-
-		members[i] = &member
-		i++
-	}
-
-	provisioner := capi.ProvisionerJSON{
-		ID:      blkHeight,
-		Set:     c.p.Set,
-		Members: members,
-	}
-
-	err := store.Save(&provisioner)
-	if err != nil {
-		log.Warn("Could not store provisioners on memoryDB")
-	}
-}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 
@@ -247,6 +248,7 @@ func setupChainTest(t *testing.T, startAtHeight uint64) (*eventbus.EventBus, *Ch
 		RPCBus:      rpc,
 		Keys:        BLSKeys,
 		TimerLength: 5 * time.Second,
+		Signer:      signer.NewInMemory(BLSKeys),
 	}
 
 	l := loop.New(e)
@@ -34,8 +34,8 @@ func (c *Chain) allowFallback(b block.Block, l *logrus.Entry) error {
 	}
 
 	// Ensure block fields and certificate are valid against previous block and
-	// current provisioners set.
-	if err = c.isValidHeader(b, prevBlk, *c.p, l, true); err != nil {
+	// the provisioners set in effect after it.
+	if err = c.isValidHeader(b, prevBlk, c.provisionersAt(prevBlk.Header.Height), l, true); err != nil {
 		return err
 	}
 
@@ -165,7 +165,11 @@ func (c *Chain) revertBlockchain(from, to *block.Block, llog *logrus.Entry) erro
 
 	c.p = &provisioners
 
-	return nil
+	// Snapshot the restored provisioners, discarding the snapshots of the
+	// reverted blocks
+	return c.db.Update(func(t database.Transaction) error {
+		return t.StoreProvisioners(to.Header.Height, c.p)
+	})
 }
 
 func (c *Chain) resubmitTxs(txs []transactions.ContractCall) {
@@ -228,10 +232,9 @@ func (c *Chain) isBlockFromFork(b block.Block) (bool, error) {
 		return false, err
 	}
 
-	// A weak assumption is made here that provisioners state has not changed
-	// since recvBlk.Header.Height
-
-	err = c.isValidHeader(b, *pb, *c.p, log, true)
+	// The certificate is verified against the provisioners set in effect
+	// after the predecessor block
+	err = c.isValidHeader(b, *pb, c.provisionersAt(pb.Header.Height), log, true)
 	if err != nil {
 		return false, err
 	}
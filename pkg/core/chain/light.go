@@ -0,0 +1,262 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	logger "github.com/sirupsen/logrus"
+)
+
+// provisionersRetryInterval is the minimum interval between two refreshes of
+// the provisioners, when the refresh fails.
+const provisionersRetryInterval = 5 * time.Second
+
+// ProvisionersFn returns the provisioners in effect after the block at height.
+// The LightChain uses it to track the provisioners, as the stakes are not part
+// of the headers.
+type ProvisionersFn func(ctx context.Context, height uint64) (user.Provisioners, error)
+
+// LightChain is the chain of a light client. It syncs the blocks like the
+// Chain, but does not run the state transitions: it verifies the block
+// headers and their certificates only, trusts the state hash of the headers,
+// and stores the headers without the transactions.
+//
+// As the provisioners can not be derived from the headers, they are fetched
+// through a ProvisionersFn, which usually queries a trusted full node. The set
+// is refreshed when a certificate does not verify against it, at most once
+// per tip, so that blocks with invalid certificates can not flood the trusted
+// node with requests.
+type LightChain struct {
+	db database.DB
+
+	// verifier performs verifications on the block.
+	verifier Verifier
+
+	lock sync.RWMutex
+	tip  *block.Block
+
+	// Current set of provisioners, as tracked through provisionersFn, and
+	// the height of the tip they were fetched at.
+	p              *user.Provisioners
+	pHeight        uint64
+	provisionersFn ProvisionersFn
+	// lastFailure rate-limits the refreshes of the provisioners, once a
+	// refresh failed.
+	lastFailure time.Time
+
+	// Syncing related things.
+	*synchronizer
+	highestSeen uint64
+
+	ctx context.Context
+}
+
+// NewLight returns a LightChain starting from the tip stored by the loader,
+// and the provisioners of the tip returned by provisionersFn.
+func NewLight(ctx context.Context, db database.DB, loader Loader, verifier Verifier, provisionersFn ProvisionersFn) (*LightChain, error) {
+	tip, _, err := loader.LoadTip()
+	if err != nil {
+		return nil, err
+	}
+
+	provisioners, err := provisionersFn(ctx, tip.Header.Height)
+	if err != nil {
+		log.WithError(err).Error("Error in getting provisioners")
+		return nil, err
+	}
+
+	c := &LightChain{
+		db:             db,
+		verifier:       verifier,
+		tip:            tip,
+		p:              &provisioners,
+		pHeight:        tip.Header.Height,
+		provisionersFn: provisionersFn,
+		ctx:            ctx,
+	}
+
+	c.synchronizer = newSynchronizer(db, c)
+	return c, nil
+}
+
+// ProcessBlockFromNetwork will handle blocks incoming from the network, and
+// sync the headers when it detects that the light client is behind.
+// As the LightChain does not follow the consensus, it does not fallback: a
+// block at the height of the tip is discarded.
+// Satisfies the peer.ProcessorFunc interface.
+func (c *LightChain) ProcessBlockFromNetwork(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
+	blk := m.Payload().(block.Block)
+
+	// Ensure the received block provides a valid hash
+	if err := verifiers.CheckHash(&blk); err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	l := log.WithField("recv_blk_h", blk.Header.Height).
+		WithField("curr_h", c.tip.Header.Height).
+		WithField("mode", "light")
+
+	if blk.Header.Height <= c.tip.Header.Height {
+		l.Debug("discard block")
+		return nil, nil
+	}
+
+	if blk.Header.Height > c.highestSeen {
+		c.highestSeen = blk.Header.Height
+	}
+
+	return c.synchronizer.processBlock(srcPeerID, c.tip.Header.Height, blk, m.Metadata())
+}
+
+// TryNextConsecutiveBlockOutSync accepts a block while syncing.
+func (c *LightChain) TryNextConsecutiveBlockOutSync(blk block.Block, metadata *message.Metadata) error {
+	return c.acceptBlock(blk)
+}
+
+// TryNextConsecutiveBlockInSync accepts the block following the tip.
+func (c *LightChain) TryNextConsecutiveBlockInSync(blk block.Block, metadata *message.Metadata) error {
+	return c.acceptBlock(blk)
+}
+
+// TryNextConsecutiveBlockIsValid verifies the header and the certificate of
+// a block against the tip, without changing any state.
+func (c *LightChain) TryNextConsecutiveBlockIsValid(blk block.Block) error {
+	return c.isValidHeader(blk, log.WithField("event", "check_block"))
+}
+
+// RestartConsensus is a no-op, as a light client does not run the consensus.
+func (c *LightChain) RestartConsensus() error {
+	return nil
+}
+
+// StopConsensus is a no-op, as a light client does not run the consensus.
+func (c *LightChain) StopConsensus() {}
+
+// ProcessSyncTimerExpired switches back to in-sync state when the syncing
+// peer fails to deliver.
+func (c *LightChain) ProcessSyncTimerExpired(strPeerAddr string) error {
+	log.WithField("curr", c.tip.Header.Height).
+		WithField("src_addr", strPeerAddr).
+		WithField("mode", "light").Warn("sync timer expired")
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state = c.inSync
+	return nil
+}
+
+// Tip returns the header of the tip.
+func (c *LightChain) Tip() *block.Header {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.tip.Header.Copy()
+}
+
+// CalculateSyncProgress of the light client.
+func (c *LightChain) CalculateSyncProgress() float64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.highestSeen == 0 {
+		return 0.0
+	}
+
+	progressPercentage := (float64(c.tip.Header.Height) / float64(c.highestSeen)) * 100
+	if progressPercentage > 100 {
+		progressPercentage = 100
+	}
+
+	return progressPercentage
+}
+
+// isValidHeader verifies the header and the certificate of a block against
+// the tip. If the certificate does not verify, the provisioners may have
+// changed since they were fetched: unless already fetched at the tip, the
+// provisioners of the tip are fetched, and the certificate verified once more.
+func (c *LightChain) isValidHeader(blk block.Block, l *logger.Entry) error {
+	if err := c.verifier.SanityCheckBlock(*c.tip, blk); err != nil {
+		l.WithError(err).Error("block header verification failed")
+		return err
+	}
+
+	err := agreement.CheckBlockCertificate(*c.p, blk, c.tip.Header.Seed)
+	if err == nil {
+		return nil
+	}
+
+	// The provisioners are up to date, or were refreshed too recently
+	if c.pHeight == c.tip.Header.Height || time.Since(c.lastFailure) < provisionersRetryInterval {
+		l.WithError(err).Error("certificate verification failed")
+		return err
+	}
+
+	provisioners, perr := c.provisionersFn(c.ctx, c.tip.Header.Height)
+	if perr != nil {
+		c.lastFailure = time.Now()
+		l.WithError(perr).Warn("could not refresh provisioners")
+		return err
+	}
+
+	c.p = &provisioners
+	c.pHeight = c.tip.Header.Height
+
+	if err = agreement.CheckBlockCertificate(*c.p, blk, c.tip.Header.Seed); err != nil {
+		l.WithError(err).Error("certificate verification failed")
+		return err
+	}
+
+	return nil
+}
+
+// acceptBlock verifies a block, and stores its header.
+func (c *LightChain) acceptBlock(blk block.Block) error {
+	l := log.WithFields(logger.Fields{
+		"event":     "accept_block",
+		"mode":      "light",
+		"height":    blk.Header.Height,
+		"iteration": blk.Header.Iteration,
+		"hash":      util.StringifyBytes(blk.Header.Hash),
+		"curr_h":    c.tip.Header.Height,
+		"prov_num":  c.p.Set.Len(),
+	})
+
+	if err := c.isValidHeader(blk, l); err != nil {
+		return err
+	}
+
+	// Only the header is stored. There is no state to persist, so that the
+	// block is always marked as persisted.
+	b := &block.Block{Header: blk.Header, Txs: nil}
+
+	if err := c.db.Update(func(t database.Transaction) error {
+		return t.StoreBlock(b, true)
+	}); err != nil {
+		l.WithError(err).Error("storing header failed")
+		return err
+	}
+
+	c.tip = b
+
+	l.Debug("header accepted")
+	return nil
+}
@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	assert "github.com/stretchr/testify/require"
+)
+
+// mockFinalizedBlock mocks the block following prevBlock, finalized in the
+// first iteration by a quorum of the provisioners.
+func mockFinalizedBlock(prevBlock block.Block, p *user.Provisioners, keys []key.Keys) *block.Block {
+	blk := helper.RandomBlock(prevBlock.Header.Height+1, 1)
+	blk.Header.PrevBlockHash = prevBlock.Header.Hash
+	blk.Header.Timestamp = prevBlock.Header.Timestamp + 1
+	blk.Header.StateHash = make([]byte, 32)
+	blk.Header.Iteration = 1
+
+	hash, err := blk.CalculateHash()
+	if err != nil {
+		panic(err)
+	}
+
+	blk.Header.Hash = hash
+
+	votes := message.GenVotes(hash, prevBlock.Header.Seed, blk.Header.Height, 3, keys, p)
+	blk.Header.Certificate = &block.Certificate{
+		StepOneBatchedSig: votes[0].Signature,
+		StepTwoBatchedSig: votes[1].Signature,
+		StepOneCommittee:  votes[0].BitSet,
+		StepTwoCommittee:  votes[1].BitSet,
+	}
+
+	return blk
+}
+
+// TestLightChain ensures that the light client accepts the blocks certified
+// by the provisioners, stores their headers only, and refreshes the
+// provisioners once per tip when a certificate does not verify.
+func TestLightChain(t *testing.T) {
+	assert := assert.New(t)
+
+	p, keys := consensus.MockProvisioners(10)
+	stale, _ := consensus.MockProvisioners(10)
+
+	// The light client starts with a stale set of provisioners
+	calls := 0
+	provisionersFn := func(context.Context, uint64) (user.Provisioners, error) {
+		calls++
+		if calls == 1 {
+			return *stale, nil
+		}
+
+		return *p, nil
+	}
+
+	_, db := heavy.CreateDBConnection()
+	loader := createLoader(db)
+
+	c, err := NewLight(context.Background(), db, loader, loader, provisionersFn)
+	assert.NoError(err)
+
+	// The certificate is not checked at height 1
+	blk1 := mockFinalizedBlock(*c.tip, p, keys)
+	assert.NoError(c.TryNextConsecutiveBlockInSync(*blk1, nil))
+	assert.True(blk1.Header.Equals(c.Tip()))
+
+	blk2 := mockFinalizedBlock(*blk1, p, keys)
+
+	// A tampered certificate does not verify, even with fresh provisioners
+	tampered := blk2.Copy().(block.Block)
+	tampered.Header.Certificate.StepOneCommittee = 0
+	assert.Error(c.TryNextConsecutiveBlockIsValid(tampered))
+	assert.Equal(2, calls)
+
+	// The provisioners are refreshed once per tip
+	assert.Error(c.TryNextConsecutiveBlockIsValid(tampered))
+	assert.Equal(2, calls)
+
+	assert.NoError(c.TryNextConsecutiveBlockInSync(*blk2, nil))
+	assert.True(blk2.Header.Equals(c.Tip()))
+	assert.Equal(2, calls)
+
+	// Only the header is stored
+	assert.NoError(db.View(func(t database.Transaction) error {
+		txs, err := t.FetchBlockTxs(blk2.Header.Hash)
+		assert.Empty(txs)
+		return err
+	}))
+}
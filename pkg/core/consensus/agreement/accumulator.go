@@ -10,7 +10,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/evidence"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -23,11 +25,15 @@ type Accumulator struct {
 	CollectedVotesChan chan []message.Agreement
 	storeMap           *storeMap
 
+	// detector is optional and collects evidence of conflicting agreements.
+	detector *evidence.Detector
+
 	workersQuitChan chan struct{}
 }
 
 // NewAccumulator initializes a worker pool, starts up an Accumulator and returns it.
-func newAccumulator(handler Handler, workerAmount int) *Accumulator {
+// The evidence.Detector is optional.
+func newAccumulator(handler Handler, workerAmount int, detector *evidence.Detector) *Accumulator {
 	// create accumulator
 	a := &Accumulator{
 		handler:            handler,
@@ -35,6 +41,7 @@ func newAccumulator(handler Handler, workerAmount int) *Accumulator {
 		eventChan:          make(chan message.Agreement, 100),
 		CollectedVotesChan: make(chan []message.Agreement, 1),
 		storeMap:           newStoreMap(),
+		detector:           detector,
 		workersQuitChan:    make(chan struct{}),
 	}
 
@@ -63,6 +70,15 @@ func (a *Accumulator) Accumulate() {
 	for ev := range a.eventChan {
 		hdr := ev.State()
 
+		// An Agreement for a different block hash, sent by a Provisioner
+		// in the same round and step, is not accumulated.
+		if e := a.detector.Check(topics.Agreement, hdr, ev.Signature()); e != nil {
+			lg.WithField("round", hdr.Round).
+				WithField("step", hdr.Step).
+				Warnln("discarding conflicting agreement")
+			continue
+		}
+
 		// Obtain corresponding block agreement cache given its hash
 		var s *store
 		if s = a.storeMap.getStoreByHash(hdr.BlockHash); s == nil {
@@ -60,7 +60,7 @@ func (m *MockHandler) Verify(ev message.Agreement) error {
 func TestAccumulatorStop(t *testing.T) {
 	hdlr := &MockHandler{true, true, user.VotingCommittee{}, 2, true}
 
-	accumulator := newAccumulator(hdlr, 100)
+	accumulator := newAccumulator(hdlr, 100, nil)
 	go accumulator.Accumulate()
 
 	time.Sleep(3 * time.Second)
@@ -76,7 +76,7 @@ func TestAccumulation(t *testing.T) {
 	// Make an accumulator that has a quorum of 2
 	hdlr := &MockHandler{true, true, user.VotingCommittee{}, 2, true}
 
-	accumulator := newAccumulator(hdlr, 4)
+	accumulator := newAccumulator(hdlr, 4, nil)
 	go accumulator.Accumulate()
 
 	createAgreement := newAggroFactory(10)
@@ -94,7 +94,7 @@ func TestStop(t *testing.T) {
 	// Make an accumulator that has a quorum of 3
 	hdlr := &MockHandler{true, true, user.VotingCommittee{}, 3, true}
 
-	accumulator := newAccumulator(hdlr, 4)
+	accumulator := newAccumulator(hdlr, 4, nil)
 	go accumulator.Accumulate()
 
 	createAgreement := newAggroFactory(10)
@@ -120,7 +120,7 @@ func TestFailedVerification(t *testing.T) {
 	// Make an accumulator that has a quorum of 2 and fails verification
 	hdlr := &MockHandler{true, true, user.VotingCommittee{}, 3, false}
 
-	accumulator := newAccumulator(hdlr, 4)
+	accumulator := newAccumulator(hdlr, 4, nil)
 	go accumulator.Accumulate()
 
 	createAgreement := newAggroFactory(10)
@@ -143,7 +143,7 @@ func TestNotInCommittee(t *testing.T) {
 	// Make an accumulator that has a quorum of 2 and is not in the committee
 	hdlr := &MockHandler{true, false, user.VotingCommittee{}, 1, false}
 
-	accumulator := newAccumulator(hdlr, 4)
+	accumulator := newAccumulator(hdlr, 4, nil)
 	go accumulator.Accumulate()
 
 	createAgreement := newAggroFactory(10)
@@ -164,7 +164,7 @@ func TestSafeStop(t *testing.T) {
 	// Make an accumulator that has a quorum of 2 and fails verification
 	hdlr := &MockHandler{true, false, user.VotingCommittee{}, 3, false}
 
-	accumulator := newAccumulator(hdlr, 4)
+	accumulator := newAccumulator(hdlr, 4, nil)
 	go accumulator.Accumulate()
 
 	createAgreement := newAggroFactory(20)
@@ -7,6 +7,7 @@
 package agreement
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
@@ -26,20 +27,27 @@ func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block, seed
 		return nil
 	}
 
+	return CheckCertificate(provisioners, blk.Header.Height, blk.Header.Iteration, blk.Header.Hash, blk.Header.Certificate, seed)
+}
+
+// CheckCertificate ensures that the certificate holds a quorum of valid votes
+// for the block hash, in both reduction steps of the iteration.
+func CheckCertificate(provisioners user.Provisioners, round uint64, iteration uint8, blockHash []byte, cert *block.Certificate, seed []byte) error {
+	if cert == nil {
+		return errors.New("missing certificate")
+	}
+
 	// First, lets get the actual reduction steps
 	// These would be the two steps preceding the one on the certificate
-	stepOne := (blk.Header.Iteration-1)*3 + 2
-	stepTwo := (blk.Header.Iteration-1)*3 + 3
-
-	stepOneBatchedSig := blk.Header.Certificate.StepOneBatchedSig
-	stepTwoBatchedSig := blk.Header.Certificate.StepTwoBatchedSig
+	stepOne := (iteration-1)*3 + 2
+	stepTwo := (iteration-1)*3 + 3
 
 	// Now, check the certificate's correctness for both reduction steps
-	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, blk.Header.Hash, seed); err != nil {
+	if err := checkBlockCertificateForStep(cert.StepOneBatchedSig, cert.StepOneCommittee, round, stepOne, provisioners, blockHash, seed); err != nil {
 		return err
 	}
 
-	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash, seed)
+	return checkBlockCertificateForStep(cert.StepTwoBatchedSig, cert.StepTwoCommittee, round, stepTwo, provisioners, blockHash, seed)
 }
 
 func checkBlockCertificateForStep(batchedSig []byte, bitSet uint64, round uint64, step uint8, provisioners user.Provisioners, blockHash, seed []byte) error {
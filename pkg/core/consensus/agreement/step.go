@@ -18,6 +18,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
@@ -58,7 +59,7 @@ func (s *Loop) GetControlFn() consensus.ControlFn {
 func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementChan <-chan message.Message, aggrAgreementChan <-chan message.Message, r consensus.RoundUpdate) consensus.Results {
 	// creating accumulator and handler
 	handler := NewHandler(s.Keys, r.P, r.Seed)
-	acc := newAccumulator(handler, WorkerAmount)
+	acc := newAccumulator(handler, WorkerAmount, s.Detector)
 
 	// deferring queue cleanup at the end of the execution of this round
 	defer func() {
@@ -375,6 +376,8 @@ func (s *Loop) processAggrAgreement(ctx context.Context, h *handler, msg message
 			WithField("hash", util.StringifyBytes(aggro.State().BlockHash)).
 			WithField("bitset", aggro.Bitset).
 			WithError(err).Errorln("failed to verify aggragreement signature")
+
+		e.Penalize(msg.Metadata(), reputation.InvalidSignature)
 		return nil, err
 	}
 
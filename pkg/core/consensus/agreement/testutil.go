@@ -11,6 +11,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
@@ -29,6 +30,7 @@ func NewHelper(provisioners int) *Helper {
 	p, provisionersKeys := consensus.MockProvisioners(provisioners)
 	emitter := consensus.MockEmitter(time.Second)
 	emitter.Keys = provisionersKeys[0]
+	emitter.Signer = signer.NewInMemory(emitter.Keys)
 
 	return &Helper{
 		Emitter:          emitter,
@@ -12,7 +12,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
@@ -66,7 +65,7 @@ func (bg *generator) GenerateCandidateMessage(ctx context.Context, r consensus.R
 		WithField("iteration", iteration).
 		WithField("step", step)
 
-	seed, err := bg.sign(r.Seed)
+	seed, err := bg.SignSeed(r.Round, r.Seed)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +90,7 @@ func (bg *generator) GenerateCandidateMessage(ctx context.Context, r consensus.R
 	// Thus, we will instead gossip it directly.
 	scr := message.NewNewBlock(hdr, r.Hash, *blk)
 
-	sig, err := bg.Sign(hdr)
+	sig, err := bg.Sign(topics.NewBlock, hdr)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +233,3 @@ func (bg *generator) FetchMempoolTxs() ([]transactions.ContractCall, error) {
 
 	return resp.([]transactions.ContractCall), nil
 }
-
-func (bg *generator) sign(seed []byte) ([]byte, error) {
-	return bls.Sign(bg.Keys.BLSSecretKey, bg.Keys.BLSPubKey, seed)
-}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -33,6 +34,7 @@ func NewHelper(provisioners int, timeOut time.Duration) *Helper {
 
 	emitter := consensus.MockEmitter(timeOut)
 	emitter.Keys = provisionersKeys[0]
+	emitter.Signer = signer.NewInMemory(emitter.Keys)
 
 	hlp := &Helper{
 		ThisSender:       emitter.Keys.BLSPubKey,
@@ -7,12 +7,22 @@
 package capi
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/asdine/storm/v3/q"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/evidence"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/journal"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakes"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/traffic"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/sirupsen/logrus"
@@ -21,6 +31,11 @@ import (
 var (
 	eventBus *eventbus.EventBus
 	rpcBus   *rpcbus.RPCBus
+	evStore  evidence.Store
+	jrnl     *journal.Journal
+	timeouts *consensus.Timeouts
+	queues   map[string]*consensus.Queue
+	db       database.DB
 	log      = logrus.WithField("package", "capi")
 )
 
@@ -35,6 +50,32 @@ func StartAPI(eb *eventbus.EventBus, rb *rpcbus.RPCBus) {
 		Debug("StartAPI")
 }
 
+// SetEvidenceStore sets the store queried by GetEvidenceHandler.
+func SetEvidenceStore(s evidence.Store) {
+	evStore = s
+}
+
+// SetDB sets the blockchain database queried by GetProvisionersHandler and
+// the stakes handlers.
+func SetDB(d database.DB) {
+	db = d
+}
+
+// SetJournal sets the consensus journal queried by GetRoundInfoHandler.
+func SetJournal(j *journal.Journal) {
+	jrnl = j
+}
+
+// SetTimeouts sets the step timeouts reported by GetTimeoutsHandler.
+func SetTimeouts(t *consensus.Timeouts) {
+	timeouts = t
+}
+
+// SetQueues sets the consensus message queues reported by GetQueuesHandler.
+func SetQueues(q map[string]*consensus.Queue) {
+	queues = q
+}
+
 // GetBiddersHandler will return a json response.
 // FIXME this is not yet implemented since we dont have the info yet.
 func GetBiddersHandler(res http.ResponseWriter, req *http.Request) {
@@ -55,7 +96,9 @@ func GetBiddersHandler(res http.ResponseWriter, req *http.Request) {
 	_, _ = res.Write([]byte(`{"error":"not yet implemented"}`))
 }
 
-// GetProvisionersHandler will return Provisioners json.
+// GetProvisionersHandler will return the Provisioners json of the snapshot in
+// effect after the block at the requested height. Heights above the tip get
+// the current provisioners.
 func GetProvisionersHandler(res http.ResponseWriter, req *http.Request) {
 	heightStr := req.URL.Query().Get("height")
 	if heightStr == "" {
@@ -63,7 +106,7 @@ func GetProvisionersHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	height, err := strconv.Atoi(heightStr)
+	height, err := strconv.ParseUint(heightStr, 10, 64)
 	if err != nil {
 		res.WriteHeader(http.StatusBadRequest)
 		return
@@ -71,26 +114,34 @@ func GetProvisionersHandler(res http.ResponseWriter, req *http.Request) {
 
 	log.WithField("height", height).Debug("GetProvisionersHandler")
 
-	var provisioner ProvisionerJSON
-
-	err = GetStormDBInstance().Find("ID", uint64(height), &provisioner)
-	if err != nil {
+	if db == nil {
 		res.WriteHeader(http.StatusNotFound)
 		return
 	}
 
-	var b []byte
+	var p *user.Provisioners
 
-	b, err = json.Marshal(provisioner)
+	err = db.View(func(t database.Transaction) error {
+		var err error
+		p, err = t.FetchProvisioners(height)
+		return err
+	})
 	if err != nil {
 		res.WriteHeader(http.StatusNotFound)
 		return
 	}
 
+	b, err := json.Marshal(NewProvisionerJSON(height, p))
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	_, _ = res.Write(b)
 }
 
-// GetRoundInfoHandler will return RoundInfoJSON json array.
+// GetRoundInfoHandler will return RoundInfoJSON json array, as recorded by
+// the consensus journal.
 func GetRoundInfoHandler(res http.ResponseWriter, req *http.Request) {
 	heightBeginStr := req.URL.Query().Get("height_begin")
 	if heightBeginStr == "" {
@@ -121,15 +172,18 @@ func GetRoundInfoHandler(res http.ResponseWriter, req *http.Request) {
 		WithField("heightEnd", heightEnd).
 		Debug("GetRoundInfoHandler")
 
-	var roundInfos []RoundInfoJSON
-
-	// TODO: step should be a argument for query ?
-	err = GetStormDBInstance().DB.Range("Round", heightBegin, heightEnd, &roundInfos)
-	if err != nil {
-		res.WriteHeader(http.StatusInternalServerError)
+	if heightBegin < 0 || heightEnd < heightBegin {
+		res.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	rounds := jrnl.Rounds(uint64(heightBegin), uint64(heightEnd))
+
+	roundInfos := make([]RoundInfoJSON, 0, len(rounds))
+	for _, r := range rounds {
+		roundInfos = append(roundInfos, NewRoundInfoJSON(r))
+	}
+
 	if len(roundInfos) == 0 {
 		res.WriteHeader(http.StatusNotFound)
 		return
@@ -144,6 +198,203 @@ func GetRoundInfoHandler(res http.ResponseWriter, req *http.Request) {
 	_, _ = res.Write(outputBytes)
 }
 
+// GetEvidenceHandler will return EvidenceJSON json array.
+func GetEvidenceHandler(res http.ResponseWriter, req *http.Request) {
+	heightBegin, err := strconv.ParseUint(req.URL.Query().Get("height_begin"), 10, 64)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	heightEnd, err := strconv.ParseUint(req.URL.Query().Get("height_end"), 10, 64)
+	if err != nil || heightEnd < heightBegin {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	log.
+		WithField("heightBegin", heightBegin).
+		WithField("heightEnd", heightEnd).
+		Debug("GetEvidenceHandler")
+
+	if evStore == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	evs, err := evStore.FetchRange(heightBegin, heightEnd)
+	if err != nil {
+		log.WithError(err).Error("could not fetch evidence")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	evidenceList := make([]EvidenceJSON, 0, len(evs))
+	for _, e := range evs {
+		evidenceList = append(evidenceList, NewEvidenceJSON(e))
+	}
+
+	b, err := json.Marshal(evidenceList)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetStakesHandler will return the stake events json of the blocks between
+// two heights (inclusive). The events can be filtered by the hex encoded BLS
+// key of a provisioner.
+func GetStakesHandler(res http.ResponseWriter, req *http.Request) {
+	heightBegin, err := strconv.ParseUint(req.URL.Query().Get("height_begin"), 10, 64)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	heightEnd, err := strconv.ParseUint(req.URL.Query().Get("height_end"), 10, 64)
+	if err != nil || heightEnd < heightBegin {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pubKeyBLS, err := hex.DecodeString(req.URL.Query().Get("bls_key"))
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	log.
+		WithField("heightBegin", heightBegin).
+		WithField("heightEnd", heightEnd).
+		Debug("GetStakesHandler")
+
+	events, ok := fetchStakeEvents(res, heightBegin, heightEnd)
+	if !ok {
+		return
+	}
+
+	filtered := make([]user.StakeEvent, 0, len(events))
+
+	for _, e := range events {
+		if len(pubKeyBLS) == 0 || bytes.Equal(e.PublicKeyBLS, pubKeyBLS) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	b, err := json.Marshal(filtered)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetStakesEpochHandler will return the EpochReport json of the requested
+// epoch.
+func GetStakesEpochHandler(res http.ResponseWriter, req *http.Request) {
+	epoch, err := strconv.ParseUint(req.URL.Query().Get("epoch"), 10, 64)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	log.WithField("epoch", epoch).Debug("GetStakesEpochHandler")
+
+	from, to := stakes.EpochBounds(epoch)
+
+	events, ok := fetchStakeEvents(res, from, to)
+	if !ok {
+		return
+	}
+
+	b, err := json.Marshal(stakes.NewEpochReport(epoch, events))
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+func fetchStakeEvents(res http.ResponseWriter, from, to uint64) ([]user.StakeEvent, bool) {
+	if db == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	var events []user.StakeEvent
+
+	if err := db.View(func(t database.Transaction) error {
+		var err error
+		events, err = t.FetchStakeEvents(from, to)
+		return err
+	}); err != nil {
+		log.WithError(err).Error("could not fetch stake events")
+		res.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return events, true
+}
+
+// GetTimeoutsHandler will return TimeoutsJSON json.
+func GetTimeoutsHandler(res http.ResponseWriter, req *http.Request) {
+	if timeouts == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(NewTimeoutsJSON(timeouts.Status()))
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetQueuesHandler will return a list of QueueJSON json, sorted by name.
+func GetQueuesHandler(res http.ResponseWriter, req *http.Request) {
+	if queues == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	names := make([]string, 0, len(queues))
+	for name := range queues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	list := make([]QueueJSON, 0, len(names))
+	for _, name := range names {
+		list = append(list, NewQueueJSON(name, queues[name].Status()))
+	}
+
+	b, err := json.Marshal(list)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetCommitteeCacheHandler will return CommitteeCacheJSON json.
+func GetCommitteeCacheHandler(res http.ResponseWriter, req *http.Request) {
+	b, err := json.Marshal(NewCommitteeCacheJSON(user.CommitteeCacheStatus()))
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
 // GetEventQueueStatusHandler will return EventQueueJSON json.
 func GetEventQueueStatusHandler(res http.ResponseWriter, req *http.Request) {
 	heightStr := req.URL.Query().Get("height")
@@ -233,3 +484,37 @@ func GetP2PCountHandler(res http.ResponseWriter, req *http.Request) {
 
 	_, _ = res.Write(b)
 }
+
+// GetP2PTrafficHandler will return the messages and bytes exchanged with each
+// peer, by network and topic. The samples can be filtered with the network
+// and peer query parameters.
+func GetP2PTrafficHandler(res http.ResponseWriter, req *http.Request) {
+	network := req.URL.Query().Get("network")
+	peer := req.URL.Query().Get("peer")
+
+	samples := make([]traffic.Sample, 0)
+
+	for _, s := range traffic.Snapshot() {
+		if (network == "" || s.Network == network) && (peer == "" || s.Peer == peer) {
+			samples = append(samples, s)
+		}
+	}
+
+	b, err := json.Marshal(samples)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetMetricsHandler will return the traffic counters in the Prometheus text
+// exposition format.
+func GetMetricsHandler(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	if err := traffic.WritePrometheus(res, traffic.Snapshot()); err != nil {
+		log.WithError(err).Debug("failed to write metrics")
+	}
+}
@@ -7,8 +7,16 @@
 package capi
 
 import (
+	"bytes"
+	"encoding/hex"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/evidence"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/journal"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -23,14 +31,95 @@ type EventQueueJSON struct {
 	UpdatedAt time.Time        `json:"updated_at"`
 }
 
-// RoundInfoJSON is used as JSON wrapper for round info fields.
+// RoundInfoJSON is used as JSON wrapper for the journal of a consensus round.
 type RoundInfoJSON struct {
-	ID        int       `storm:"id,increment" json:"id"`
-	Round     uint64    `storm:"index" json:"round"`
-	Step      uint8     `json:"step"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Method    string    `json:"method"`
-	Name      string    `json:"name"`
+	Round       uint64             `json:"round"`
+	StartedAt   time.Time          `json:"started_at"`
+	EndedAt     *time.Time         `json:"ended_at,omitempty"`
+	BlockHash   string             `json:"block_hash,omitempty"`
+	Certificate *block.Certificate `json:"certificate,omitempty"`
+	Err         string             `json:"error,omitempty"`
+	Steps       []StepInfoJSON     `json:"steps"`
+}
+
+// StepInfoJSON is used as JSON wrapper for the journal of a consensus step.
+type StepInfoJSON struct {
+	Step         uint8      `json:"step"`
+	Phase        string     `json:"phase"`
+	StartedAt    time.Time  `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+	Committee    []string   `json:"committee"`
+	Member       bool       `json:"member"`
+	Candidate    string     `json:"candidate,omitempty"`
+	Alternatives []string   `json:"alternatives,omitempty"`
+	Votes        []VoteJSON `json:"votes"`
+	QuorumHash   string     `json:"quorum_hash,omitempty"`
+	QuorumAt     *time.Time `json:"quorum_at,omitempty"`
+	TimedOut     bool       `json:"timed_out"`
+}
+
+// VoteJSON is used as JSON wrapper for a vote received in a consensus step.
+type VoteJSON struct {
+	Sender     string    `json:"sender"`
+	Hash       string    `json:"hash"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// NewRoundInfoJSON creates a RoundInfoJSON out of a journal.Round.
+func NewRoundInfoJSON(r journal.Round) RoundInfoJSON {
+	info := RoundInfoJSON{
+		Round:       r.Round,
+		StartedAt:   r.StartedAt,
+		EndedAt:     timeOrNil(r.EndedAt),
+		BlockHash:   hex.EncodeToString(r.BlockHash),
+		Certificate: r.Certificate,
+		Err:         r.Err,
+		Steps:       make([]StepInfoJSON, 0, len(r.Steps)),
+	}
+
+	for _, s := range r.Steps {
+		step := StepInfoJSON{
+			Step:       s.Step,
+			Phase:      s.Phase,
+			StartedAt:  s.StartedAt,
+			EndedAt:    timeOrNil(s.EndedAt),
+			Committee:  make([]string, 0, len(s.Committee)),
+			Member:     s.Member,
+			Candidate:  hex.EncodeToString(s.Candidate),
+			Votes:      make([]VoteJSON, 0, len(s.Votes)),
+			QuorumHash: hex.EncodeToString(s.QuorumHash),
+			QuorumAt:   timeOrNil(s.QuorumAt),
+			TimedOut:   s.TimedOut,
+		}
+
+		for _, m := range s.Committee {
+			step.Committee = append(step.Committee, hex.EncodeToString(m))
+		}
+
+		for _, h := range s.Alternatives {
+			step.Alternatives = append(step.Alternatives, hex.EncodeToString(h))
+		}
+
+		for _, v := range s.Votes {
+			step.Votes = append(step.Votes, VoteJSON{
+				Sender:     hex.EncodeToString(v.Sender),
+				Hash:       hex.EncodeToString(v.Hash),
+				ReceivedAt: v.ReceivedAt,
+			})
+		}
+
+		info.Steps = append(info.Steps, step)
+	}
+
+	return info
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
 }
 
 // PeerJSON is used as JSON wrapper for peer info fields.
@@ -73,3 +162,148 @@ type ProvisionerJSON struct {
 	Set     sortedset.Set `json:"set"`
 	Members []*Member     `json:"members"`
 }
+
+// NewProvisionerJSON creates the JSON view of the provisioners in effect at a
+// height. Members are ordered as in the set.
+func NewProvisionerJSON(height uint64, p *user.Provisioners) ProvisionerJSON {
+	members := make([]*Member, 0, len(p.Members))
+
+	for i := range p.Set {
+		m, err := p.MemberAt(i)
+		if err != nil || m == nil {
+			continue
+		}
+
+		stakes := make([]Stake, len(m.Stakes))
+		for i, s := range m.Stakes {
+			stakes[i] = Stake{
+				Value:       s.Value,
+				Reward:      s.Reward,
+				Counter:     s.Counter,
+				Eligibility: s.Eligibility,
+			}
+		}
+
+		members = append(members, &Member{
+			PublicKeyBLS: m.PublicKeyBLS,
+			Stakes:       stakes,
+		})
+	}
+
+	return ProvisionerJSON{
+		ID:      height,
+		Set:     p.Set,
+		Members: members,
+	}
+}
+
+// EvidenceJSON is used as JSON wrapper for the evidence of conflicting votes.
+type EvidenceJSON struct {
+	ID         string    `json:"id"`
+	Topic      string    `json:"topic"`
+	Round      uint64    `json:"round"`
+	Step       uint8     `json:"step"`
+	Offender   string    `json:"offender"`
+	FirstHash  string    `json:"first_hash"`
+	SecondHash string    `json:"second_hash"`
+	DetectedAt time.Time `json:"detected_at"`
+	// Raw is the hex encoded serialized evidence, verifiable by third parties.
+	Raw string `json:"raw"`
+}
+
+// NewEvidenceJSON creates an EvidenceJSON out of an evidence.Evidence.
+func NewEvidenceJSON(e evidence.Evidence) EvidenceJSON {
+	buf := new(bytes.Buffer)
+	if err := evidence.Marshal(buf, e); err != nil {
+		log.WithError(err).Warn("could not marshal evidence")
+	}
+
+	return EvidenceJSON{
+		ID:         e.ID(),
+		Topic:      e.Topic.String(),
+		Round:      e.Round(),
+		Step:       e.Step(),
+		Offender:   hex.EncodeToString(e.Offender()),
+		FirstHash:  hex.EncodeToString(e.First.Header.BlockHash),
+		SecondHash: hex.EncodeToString(e.Second.Header.BlockHash),
+		DetectedAt: time.Unix(e.DetectedAt, 0),
+		Raw:        hex.EncodeToString(buf.Bytes()),
+	}
+}
+
+// TimeoutsJSON is used as JSON wrapper for the effective step timeouts, in
+// milliseconds.
+type TimeoutsJSON struct {
+	BaseMilli int64            `json:"base_ms"`
+	MinMilli  int64            `json:"min_ms"`
+	MaxMilli  int64            `json:"max_ms"`
+	Phases    map[string]int64 `json:"phases_ms"`
+}
+
+// NewTimeoutsJSON creates a TimeoutsJSON out of a consensus.TimeoutsStatus.
+func NewTimeoutsJSON(s consensus.TimeoutsStatus) TimeoutsJSON {
+	t := TimeoutsJSON{
+		BaseMilli: s.Base.Milliseconds(),
+		MinMilli:  s.Min.Milliseconds(),
+		MaxMilli:  s.Max.Milliseconds(),
+		Phases:    make(map[string]int64, len(s.Timeouts)),
+	}
+
+	for phase, d := range s.Timeouts {
+		t.Phases[phase] = d.Milliseconds()
+	}
+
+	return t
+}
+
+// CommitteeCacheJSON is used as JSON wrapper for the committee cache counters.
+type CommitteeCacheJSON struct {
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	HitRate   float64 `json:"hit_rate"`
+	Len       int     `json:"len"`
+	Capacity  int     `json:"capacity"`
+}
+
+// NewCommitteeCacheJSON creates a CommitteeCacheJSON out of a
+// user.CommitteeCacheStats.
+func NewCommitteeCacheJSON(s user.CommitteeCacheStats) CommitteeCacheJSON {
+	return CommitteeCacheJSON{
+		Hits:      s.Hits,
+		Misses:    s.Misses,
+		Evictions: s.Evictions,
+		HitRate:   s.HitRate(),
+		Len:       s.Len,
+		Capacity:  s.Capacity,
+	}
+}
+
+// QueueJSON is used as JSON wrapper for the state of a consensus message
+// queue.
+type QueueJSON struct {
+	Name     string            `json:"name"`
+	Round    uint64            `json:"round"`
+	Items    int               `json:"items"`
+	Capacity int               `json:"capacity"`
+	Rounds   map[uint64]int    `json:"rounds"`
+	Dropped  map[string]uint64 `json:"dropped"`
+}
+
+// NewQueueJSON creates a QueueJSON out of a consensus.QueueStatus.
+func NewQueueJSON(name string, s consensus.QueueStatus) QueueJSON {
+	q := QueueJSON{
+		Name:     name,
+		Round:    s.Round,
+		Items:    s.Items,
+		Capacity: s.Capacity,
+		Rounds:   s.Rounds,
+		Dropped:  make(map[string]uint64, len(s.Dropped)),
+	}
+
+	for reason, n := range s.Dropped {
+		q.Dropped[string(reason)] = n
+	}
+
+	return q
+}
@@ -7,18 +7,21 @@
 package consensus
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"time"
 
-	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/evidence"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/journal"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -30,6 +33,12 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// ErrObserver is returned when an Emitter in observer mode is asked to sign.
+var ErrObserver = errors.New("observer mode: signing disabled")
+
+// ErrNoSigner is returned when an Emitter without a Signer is asked to sign.
+var ErrNoSigner = errors.New("no consensus signer")
+
 type (
 	// Results carries the eventual consensus results.
 	Results struct {
@@ -51,6 +60,25 @@ type (
 		RPCBus      *rpcbus.RPCBus
 		Keys        key.Keys
 		TimerLength time.Duration
+
+		// Detector collects evidence of provisioners signing conflicting
+		// votes. It can be nil.
+		Detector *evidence.Detector
+		// Journal records the course of the last consensus rounds. It can
+		// be nil.
+		Journal *journal.Journal
+		// Timeouts adapts the step timeouts across rounds. It can be nil.
+		Timeouts *Timeouts
+		// Signer signs the consensus messages. It is required, unless in
+		// observer mode.
+		Signer signer.Signer
+		// Observer makes the node follow the consensus (committees, message
+		// validation, quorums) without ever signing or broadcasting a
+		// message.
+		Observer bool
+		// Reputation penalizes the peers relaying consensus messages with
+		// an invalid signature. It can be nil.
+		Reputation *reputation.Scorer
 	}
 
 	// RoundUpdate carries the data about the new Round, such as the active
@@ -113,24 +141,39 @@ func (c *acceptedBlockCollector) Collect(m message.Message) {
 	c.blockChan <- m.Payload().(block.Block)
 }
 
-// Sign a header.
-func (e *Emitter) Sign(h header.Header) ([]byte, error) {
-	preimage := new(bytes.Buffer)
-	if err := header.MarshalSignableVote(preimage, h); err != nil {
-		return nil, err
+// Sign the vote carried by the header of a consensus message of topic t.
+func (e *Emitter) Sign(t topics.Topic, h header.Header) ([]byte, error) {
+	if e.Observer {
+		return nil, ErrObserver
 	}
 
-	signedHash, err := bls.Sign(e.Keys.BLSSecretKey, e.Keys.BLSPubKey, preimage.Bytes())
-	if err != nil {
-		return nil, err
+	if e.Signer == nil {
+		return nil, ErrNoSigner
 	}
 
-	return signedHash, nil
+	return e.Signer.Sign(t, h)
+}
+
+// SignSeed signs the seed of the candidate block of a round.
+func (e *Emitter) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	if e.Observer {
+		return nil, ErrObserver
+	}
+
+	if e.Signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	return e.Signer.SignSeed(round, seed)
 }
 
 // Gossip concatenates the topic, the header and the payload,
-// and gossips it to the rest of the network.
+// and gossips it to the rest of the network. It is a no-op in observer mode.
 func (e *Emitter) Gossip(msg message.Message) error {
+	if e.Observer {
+		return nil
+	}
+
 	// message.Marshal takes care of prepending the topic, marshaling the
 	// header, etc
 	buf, err := message.Marshal(msg)
@@ -145,8 +188,13 @@ func (e *Emitter) Gossip(msg message.Message) error {
 	return nil
 }
 
-// Kadcast propagates a message in Kadcast network.
+// Kadcast propagates a message in Kadcast network. It is a no-op in observer
+// mode.
 func (e *Emitter) Kadcast(msg message.Message) error {
+	if e.Observer {
+		return nil
+	}
+
 	buf, err := message.Marshal(msg)
 	if err != nil {
 		return err
@@ -166,6 +214,14 @@ func (e *Emitter) Republish(msg message.Message) error {
 	return e.Gossip(msg)
 }
 
+// Penalize the peer which relayed a message for an Offense. Only the Kadcast
+// metadata carries the source of a message, otherwise it is a no-op.
+func (e *Emitter) Penalize(metadata *message.Metadata, o reputation.Offense) {
+	if metadata != nil {
+		e.Reputation.Penalize(metadata.Source, o)
+	}
+}
+
 // WithFields builds a list of common Consensus logging fields.
 // It also adds extra fields for TraceLevel only.
 func WithFields(round uint64, step uint8, eventName string, hash,
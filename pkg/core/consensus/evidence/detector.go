@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package evidence
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "consensus").WithField("actor", "evidence")
+
+// roundsToKeep is the amount of rounds, before the most recent one, for which
+// the Detector retains the votes.
+const roundsToKeep = 2
+
+type voteKey struct {
+	topic  topics.Topic
+	round  uint64
+	step   uint8
+	sender string
+}
+
+// Detector keeps track of the first vote cast by each provisioner at a given
+// topic, round and step. It reports an Evidence whenever the same provisioner
+// signs a different block hash for the same topic, round and step.
+//
+// The Detector assumes that the votes it is fed have already been verified.
+// It is safe for concurrent use. A nil Detector is a valid no-op Detector.
+type Detector struct {
+	lock     sync.Mutex
+	votes    map[voteKey]Vote
+	reported map[voteKey]struct{}
+	maxRound uint64
+
+	store Store
+}
+
+// NewDetector returns a Detector persisting the Evidence into the Store.
+func NewDetector(store Store) *Detector {
+	return &Detector{
+		votes:    make(map[voteKey]Vote),
+		reported: make(map[voteKey]struct{}),
+		store:    store,
+	}
+}
+
+// Check records a verified vote and returns an Evidence if it conflicts with a
+// vote previously cast by the same provisioner. It returns nil otherwise.
+// The Evidence is logged and persisted only the first time it is detected,
+// but it is returned on each conflicting vote so that callers can discard it.
+func (d *Detector) Check(topic topics.Topic, hdr header.Header, signature []byte) *Evidence {
+	if d == nil {
+		return nil
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if hdr.Round+roundsToKeep < d.maxRound {
+		// Too old to be tracked
+		return nil
+	}
+
+	if hdr.Round > d.maxRound {
+		d.maxRound = hdr.Round
+		d.prune()
+	}
+
+	k := voteKey{topic, hdr.Round, hdr.Step, string(hdr.PubKeyBLS)}
+
+	first, found := d.votes[k]
+	if !found {
+		d.votes[k] = Vote{Header: hdr, Signature: signature}.Copy()
+		return nil
+	}
+
+	if bytes.Equal(first.Header.BlockHash, hdr.BlockHash) {
+		return nil
+	}
+
+	e := Evidence{
+		Topic:      topic,
+		First:      first,
+		Second:     Vote{Header: hdr, Signature: signature}.Copy(),
+		DetectedAt: time.Now().Unix(),
+	}
+
+	if _, ok := d.reported[k]; ok {
+		// already reported
+		return &e
+	}
+
+	d.reported[k] = struct{}{}
+
+	lg.WithField("event", "equivocation").
+		WithField("topic", topic.String()).
+		WithField("round", hdr.Round).
+		WithField("step", hdr.Step).
+		WithField("offender", util.StringifyBytes(hdr.PubKeyBLS)).
+		WithField("first_hash", util.StringifyBytes(first.Header.BlockHash)).
+		WithField("second_hash", util.StringifyBytes(hdr.BlockHash)).
+		Error("conflicting votes detected")
+
+	if d.store != nil {
+		if err := d.store.Put(e); err != nil {
+			lg.WithError(err).Error("could not persist evidence")
+		}
+	}
+
+	return &e
+}
+
+// Store returns the Store used to persist the Evidence.
+func (d *Detector) Store() Store {
+	if d == nil {
+		return nil
+	}
+
+	return d.store
+}
+
+// prune removes the votes (and the reports) of the rounds no longer tracked.
+func (d *Detector) prune() {
+	for k := range d.votes {
+		if k.round+roundsToKeep < d.maxRound {
+			delete(d.votes, k)
+		}
+	}
+
+	for k := range d.reported {
+		if k.round+roundsToKeep < d.maxRound {
+			delete(d.reported, k)
+		}
+	}
+}
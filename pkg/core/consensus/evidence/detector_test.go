@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package evidence
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func mockVote(t *testing.T, k key.Keys, round uint64, step uint8) Vote {
+	hash, err := crypto.RandEntropy(32)
+	require.NoError(t, err)
+
+	red := message.MockReduction(hash, round, step, []key.Keys{k})
+	return Vote{Header: red.State(), Signature: red.SignedHash}
+}
+
+func TestDetectConflictingVotes(t *testing.T) {
+	require := require.New(t)
+
+	store, err := NewStore("")
+	require.NoError(err)
+
+	defer store.Close()
+
+	d := NewDetector(store)
+	k := key.NewRandKeys()
+
+	first := mockVote(t, k, 1, 2)
+	second := mockVote(t, k, 1, 2)
+
+	require.Nil(d.Check(topics.Reduction, first.Header, first.Signature))
+	// Repeating the same vote is not an equivocation
+	require.Nil(d.Check(topics.Reduction, first.Header, first.Signature))
+	// Same vote on a different topic is tracked separately
+	require.Nil(d.Check(topics.Agreement, second.Header, second.Signature))
+
+	e := d.Check(topics.Reduction, second.Header, second.Signature)
+	require.NotNil(e)
+	require.NoError(e.Verify())
+	require.Equal(k.BLSPubKey, e.Offender())
+
+	// Conflicting votes are always reported, evidence is stored once
+	require.NotNil(d.Check(topics.Reduction, second.Header, second.Signature))
+
+	evs, err := store.FetchRange(0, 10)
+	require.NoError(err)
+	require.Len(evs, 1)
+	require.Equal(e.ID(), evs[0].ID())
+	require.NoError(evs[0].Verify())
+
+	evs, err = store.FetchRange(2, 10)
+	require.NoError(err)
+	require.Empty(evs)
+}
+
+func TestPruneOldRounds(t *testing.T) {
+	require := require.New(t)
+
+	d := NewDetector(nil)
+	k := key.NewRandKeys()
+
+	first := mockVote(t, k, 1, 1)
+	require.Nil(d.Check(topics.Reduction, first.Header, first.Signature))
+
+	later := mockVote(t, k, 1+roundsToKeep+1, 1)
+	require.Nil(d.Check(topics.Reduction, later.Header, later.Signature))
+
+	// Votes from pruned rounds are ignored
+	second := mockVote(t, k, 1, 1)
+	require.Nil(d.Check(topics.Reduction, second.Header, second.Signature))
+}
+
+func TestVerifyInvalidEvidence(t *testing.T) {
+	require := require.New(t)
+
+	k := key.NewRandKeys()
+	first := mockVote(t, k, 1, 1)
+	other := mockVote(t, key.NewRandKeys(), 1, 1)
+
+	e := Evidence{Topic: topics.Reduction, First: first, Second: first}
+	require.Equal(errSameHash, e.Verify())
+
+	e.Second = other
+	require.Equal(errDifferentSigner, e.Verify())
+
+	e.Second = mockVote(t, k, 2, 1)
+	require.Equal(errDifferentPhase, e.Verify())
+
+	// Tampered hash invalidates the signature
+	e.Second = mockVote(t, k, 1, 1)
+	e.Second.Header.BlockHash = bytes.Repeat([]byte{1}, 32)
+	require.Error(e.Verify())
+
+	var nilDetector *Detector
+	require.Nil(nilDetector.Check(topics.Reduction, first.Header, first.Signature))
+}
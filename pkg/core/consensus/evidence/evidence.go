@@ -0,0 +1,181 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package evidence
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/msg"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+)
+
+var (
+	errDifferentSigner = errors.New("votes are signed by different provisioners")
+	errDifferentPhase  = errors.New("votes belong to different rounds or steps")
+	errSameHash        = errors.New("votes are cast for the same block hash")
+)
+
+type (
+	// Vote is a signed consensus header, as carried by both Reduction and
+	// Agreement messages.
+	Vote struct {
+		Header    header.Header
+		Signature []byte
+	}
+
+	// Evidence is the proof that a provisioner signed two different block
+	// hashes for the same round and step of a consensus phase. Both votes
+	// carry a valid signature of the same BLS key, which makes the evidence
+	// verifiable by any third party.
+	Evidence struct {
+		Topic  topics.Topic
+		First  Vote
+		Second Vote
+
+		// DetectedAt is the unix timestamp of the detection.
+		DetectedAt int64
+	}
+)
+
+// Round returns the round in which the equivocation happened.
+func (e Evidence) Round() uint64 {
+	return e.First.Header.Round
+}
+
+// Step returns the step in which the equivocation happened.
+func (e Evidence) Step() uint8 {
+	return e.First.Header.Step
+}
+
+// Offender returns the BLS public key of the misbehaving provisioner.
+func (e Evidence) Offender() []byte {
+	return e.First.Header.PubKeyBLS
+}
+
+// ID returns a unique identifier of the evidence. A provisioner can be
+// reported only once per round, step and topic. The identifier sorts by round.
+func (e Evidence) ID() string {
+	return fmt.Sprintf("%020d:%03d:%d:%x", e.Round(), e.Step(), e.Topic, e.Offender())
+}
+
+func (e Evidence) String() string {
+	return fmt.Sprintf("topic='%s' round='%d' step='%d' offender='%s' first_hash='%s' second_hash='%s'",
+		e.Topic, e.Round(), e.Step(), util.StringifyBytes(e.Offender()),
+		util.StringifyBytes(e.First.Header.BlockHash), util.StringifyBytes(e.Second.Header.BlockHash))
+}
+
+// Verify checks that the evidence is self-consistent and that both votes
+// have been signed by the offender.
+func (e Evidence) Verify() error {
+	f, s := e.First.Header, e.Second.Header
+
+	if !bytes.Equal(f.PubKeyBLS, s.PubKeyBLS) {
+		return errDifferentSigner
+	}
+
+	if f.Round != s.Round || f.Step != s.Step {
+		return errDifferentPhase
+	}
+
+	if bytes.Equal(f.BlockHash, s.BlockHash) {
+		return errSameHash
+	}
+
+	for _, v := range []Vote{e.First, e.Second} {
+		if err := v.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Verify the BLS signature of the vote.
+func (v Vote) Verify() error {
+	buf := new(bytes.Buffer)
+	if err := header.MarshalSignableVote(buf, v.Header); err != nil {
+		return err
+	}
+
+	return msg.VerifyBLSSignature(v.Header.PubKeyBLS, v.Signature, buf.Bytes())
+}
+
+// Copy returns a deep copy of the Vote.
+func (v Vote) Copy() Vote {
+	sig := make([]byte, len(v.Signature))
+	copy(sig, v.Signature)
+
+	return Vote{
+		Header:    v.Header.Copy().(header.Header),
+		Signature: sig,
+	}
+}
+
+// Marshal an Evidence into a buffer.
+func Marshal(r *bytes.Buffer, e Evidence) error {
+	if err := encoding.WriteUint8(r, uint8(e.Topic)); err != nil {
+		return err
+	}
+
+	if err := marshalVote(r, e.First); err != nil {
+		return err
+	}
+
+	if err := marshalVote(r, e.Second); err != nil {
+		return err
+	}
+
+	return encoding.WriteUint64LE(r, uint64(e.DetectedAt))
+}
+
+// Unmarshal an Evidence from a buffer.
+func Unmarshal(r *bytes.Buffer, e *Evidence) error {
+	var topic uint8
+	if err := encoding.ReadUint8(r, &topic); err != nil {
+		return err
+	}
+
+	e.Topic = topics.Topic(topic)
+
+	if err := unmarshalVote(r, &e.First); err != nil {
+		return err
+	}
+
+	if err := unmarshalVote(r, &e.Second); err != nil {
+		return err
+	}
+
+	var detectedAt uint64
+	if err := encoding.ReadUint64LE(r, &detectedAt); err != nil {
+		return err
+	}
+
+	e.DetectedAt = int64(detectedAt)
+	return nil
+}
+
+func marshalVote(r *bytes.Buffer, v Vote) error {
+	if err := header.Marshal(r, v.Header); err != nil {
+		return err
+	}
+
+	return encoding.WriteVarBytes(r, v.Signature)
+}
+
+func unmarshalVote(r *bytes.Buffer, v *Vote) error {
+	if err := header.Unmarshal(r, &v.Header); err != nil {
+		return err
+	}
+
+	v.Signature = make([]byte, 0)
+	return encoding.ReadVarBytes(r, &v.Signature)
+}
@@ -0,0 +1,109 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package evidence
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tidwall/buntdb"
+)
+
+const evidencePrefix = "ev:"
+
+// Store persists the collected Evidence.
+type Store interface {
+	// Put stores an Evidence. Storing the same Evidence twice is a no-op.
+	Put(Evidence) error
+	// FetchRange returns all Evidence collected between two rounds (inclusive).
+	FetchRange(from, to uint64) ([]Evidence, error)
+	// Close the Store.
+	Close() error
+}
+
+type buntStore struct {
+	db *buntdb.DB
+}
+
+// NewStore opens (or creates) a buntdb backed Store at the specified path.
+// If path is empty, the Store is kept in memory.
+func NewStore(path string) (Store, error) {
+	if path == "" {
+		path = ":memory:"
+	}
+
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config buntdb.Config
+	if err := db.ReadConfig(&config); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	// Evidence is rare and valuable. Sync on each write.
+	config.SyncPolicy = buntdb.Always
+
+	if err := db.SetConfig(config); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &buntStore{db: db}, nil
+}
+
+// Put implements Store.
+func (s *buntStore) Put(e Evidence) error {
+	buf := new(bytes.Buffer)
+	if err := Marshal(buf, e); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		key := evidencePrefix + e.ID()
+		if _, err := tx.Get(key); err == nil {
+			return nil
+		}
+
+		_, _, err := tx.Set(key, buf.String(), nil)
+		return err
+	})
+}
+
+// FetchRange implements Store.
+func (s *buntStore) FetchRange(from, to uint64) ([]Evidence, error) {
+	res := make([]Evidence, 0)
+
+	var unmarshalErr error
+
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		lower := fmt.Sprintf("%s%020d", evidencePrefix, from)
+		upper := fmt.Sprintf("%s%020d", evidencePrefix, to+1)
+
+		return tx.AscendRange("", lower, upper, func(key, value string) bool {
+			var e Evidence
+			if unmarshalErr = Unmarshal(bytes.NewBufferString(value), &e); unmarshalErr != nil {
+				return false
+			}
+
+			res = append(res, e)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, unmarshalErr
+}
+
+// Close implements Store.
+func (s *buntStore) Close() error {
+	return s.db.Close()
+}
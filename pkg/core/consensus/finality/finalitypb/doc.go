@@ -0,0 +1,11 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package finalitypb holds the protobuf messages and the gRPC service of the
+// finality proofs, generated from finality.proto.
+package finalitypb
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. finality.proto
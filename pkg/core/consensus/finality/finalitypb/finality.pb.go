@@ -0,0 +1,644 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        (unknown)
+// source: finality.proto
+
+package finalitypb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type HeightRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (x *HeightRequest) Reset() {
+	*x = HeightRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeightRequest) ProtoMessage() {}
+
+func (x *HeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeightRequest.ProtoReflect.Descriptor instead.
+func (*HeightRequest) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HeightRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// StepVotes are the votes of a reduction step for the finalized block.
+type StepVotes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Aggregated BLS signature of the committee members who voted.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Bitset of the committee members who voted.
+	Bitset uint64 `protobuf:"varint,2,opt,name=bitset,proto3" json:"bitset,omitempty"`
+}
+
+func (x *StepVotes) Reset() {
+	*x = StepVotes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepVotes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepVotes) ProtoMessage() {}
+
+func (x *StepVotes) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepVotes.ProtoReflect.Descriptor instead.
+func (*StepVotes) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StepVotes) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *StepVotes) GetBitset() uint64 {
+	if x != nil {
+		return x.Bitset
+	}
+	return 0
+}
+
+// FinalityProof proves that a block was finalized in an iteration of a
+// round.
+type FinalityProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash []byte     `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Round     uint64     `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Iteration uint32     `protobuf:"varint,3,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	StepOne   *StepVotes `protobuf:"bytes,4,opt,name=step_one,json=stepOne,proto3" json:"step_one,omitempty"`
+	StepTwo   *StepVotes `protobuf:"bytes,5,opt,name=step_two,json=stepTwo,proto3" json:"step_two,omitempty"`
+}
+
+func (x *FinalityProof) Reset() {
+	*x = FinalityProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalityProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalityProof) ProtoMessage() {}
+
+func (x *FinalityProof) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalityProof.ProtoReflect.Descriptor instead.
+func (*FinalityProof) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FinalityProof) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *FinalityProof) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *FinalityProof) GetIteration() uint32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *FinalityProof) GetStepOne() *StepVotes {
+	if x != nil {
+		return x.StepOne
+	}
+	return nil
+}
+
+func (x *FinalityProof) GetStepTwo() *StepVotes {
+	if x != nil {
+		return x.StepTwo
+	}
+	return nil
+}
+
+// BlockHeader is a block header, marshaled as in the wire protocol.
+type BlockHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header []byte `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+}
+
+func (x *BlockHeader) Reset() {
+	*x = BlockHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeader) ProtoMessage() {}
+
+func (x *BlockHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeader.ProtoReflect.Descriptor instead.
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlockHeader) GetHeader() []byte {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+// Provisioners is a provisioners set, marshaled as in the wire protocol.
+type Provisioners struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provisioners []byte `protobuf:"bytes,1,opt,name=provisioners,proto3" json:"provisioners,omitempty"`
+}
+
+func (x *Provisioners) Reset() {
+	*x = Provisioners{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Provisioners) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provisioners) ProtoMessage() {}
+
+func (x *Provisioners) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provisioners.ProtoReflect.Descriptor instead.
+func (*Provisioners) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Provisioners) GetProvisioners() []byte {
+	if x != nil {
+		return x.Provisioners
+	}
+	return nil
+}
+
+var File_finality_proto protoreflect.FileDescriptor
+
+var file_finality_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x04, 0x64, 0x75, 0x73, 0x6b, 0x22, 0x27, 0x0a, 0x0d, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0x41, 0x0a, 0x09, 0x53, 0x74, 0x65, 0x70, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x69,
+	0x74, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x69, 0x74, 0x73,
+	0x65, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x0d, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x08, 0x73, 0x74, 0x65, 0x70, 0x5f,
+	0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x75, 0x73, 0x6b,
+	0x2e, 0x53, 0x74, 0x65, 0x70, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x07, 0x73, 0x74, 0x65, 0x70,
+	0x4f, 0x6e, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x74, 0x77, 0x6f, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x75, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x65,
+	0x70, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x07, 0x73, 0x74, 0x65, 0x70, 0x54, 0x77, 0x6f, 0x22,
+	0x25, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x32, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x72, 0x73, 0x32, 0xc4, 0x01, 0x0a, 0x08, 0x46,
+	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x13, 0x2e, 0x64, 0x75,
+	0x73, 0x6b, 0x2e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x64, 0x75, 0x73, 0x6b, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x13, 0x2e, 0x64, 0x75, 0x73, 0x6b,
+	0x2e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
+	0x2e, 0x64, 0x75, 0x73, 0x6b, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x64, 0x75, 0x73, 0x6b, 0x2e, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x64, 0x75,
+	0x73, 0x6b, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x72, 0x73, 0x22,
+	0x00, 0x42, 0x50, 0x5a, 0x4e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x64, 0x75, 0x73, 0x6b, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x64, 0x75, 0x73,
+	0x6b, 0x2d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f,
+	0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_finality_proto_rawDescOnce sync.Once
+	file_finality_proto_rawDescData = file_finality_proto_rawDesc
+)
+
+func file_finality_proto_rawDescGZIP() []byte {
+	file_finality_proto_rawDescOnce.Do(func() {
+		file_finality_proto_rawDescData = protoimpl.X.CompressGZIP(file_finality_proto_rawDescData)
+	})
+	return file_finality_proto_rawDescData
+}
+
+var file_finality_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_finality_proto_goTypes = []interface{}{
+	(*HeightRequest)(nil), // 0: dusk.HeightRequest
+	(*StepVotes)(nil),     // 1: dusk.StepVotes
+	(*FinalityProof)(nil), // 2: dusk.FinalityProof
+	(*BlockHeader)(nil),   // 3: dusk.BlockHeader
+	(*Provisioners)(nil),  // 4: dusk.Provisioners
+}
+var file_finality_proto_depIdxs = []int32{
+	1, // 0: dusk.FinalityProof.step_one:type_name -> dusk.StepVotes
+	1, // 1: dusk.FinalityProof.step_two:type_name -> dusk.StepVotes
+	0, // 2: dusk.Finality.GetFinalityProof:input_type -> dusk.HeightRequest
+	0, // 3: dusk.Finality.GetBlockHeader:input_type -> dusk.HeightRequest
+	0, // 4: dusk.Finality.GetProvisioners:input_type -> dusk.HeightRequest
+	2, // 5: dusk.Finality.GetFinalityProof:output_type -> dusk.FinalityProof
+	3, // 6: dusk.Finality.GetBlockHeader:output_type -> dusk.BlockHeader
+	4, // 7: dusk.Finality.GetProvisioners:output_type -> dusk.Provisioners
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_finality_proto_init() }
+func file_finality_proto_init() {
+	if File_finality_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_finality_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeightRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepVotes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalityProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Provisioners); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_finality_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_finality_proto_goTypes,
+		DependencyIndexes: file_finality_proto_depIdxs,
+		MessageInfos:      file_finality_proto_msgTypes,
+	}.Build()
+	File_finality_proto = out.File
+	file_finality_proto_rawDesc = nil
+	file_finality_proto_goTypes = nil
+	file_finality_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// FinalityClient is the client API for Finality service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FinalityClient interface {
+	// GetFinalityProof returns the finality proof of the block at a height.
+	GetFinalityProof(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*FinalityProof, error)
+	// GetBlockHeader returns the header of the block at a height.
+	GetBlockHeader(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*BlockHeader, error)
+	// GetProvisioners returns the provisioners in effect after the block at a
+	// height.
+	GetProvisioners(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*Provisioners, error)
+}
+
+type finalityClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFinalityClient(cc grpc.ClientConnInterface) FinalityClient {
+	return &finalityClient{cc}
+}
+
+func (c *finalityClient) GetFinalityProof(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*FinalityProof, error) {
+	out := new(FinalityProof)
+	err := c.cc.Invoke(ctx, "/dusk.Finality/GetFinalityProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityClient) GetBlockHeader(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*BlockHeader, error) {
+	out := new(BlockHeader)
+	err := c.cc.Invoke(ctx, "/dusk.Finality/GetBlockHeader", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityClient) GetProvisioners(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*Provisioners, error) {
+	out := new(Provisioners)
+	err := c.cc.Invoke(ctx, "/dusk.Finality/GetProvisioners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FinalityServer is the server API for Finality service.
+type FinalityServer interface {
+	// GetFinalityProof returns the finality proof of the block at a height.
+	GetFinalityProof(context.Context, *HeightRequest) (*FinalityProof, error)
+	// GetBlockHeader returns the header of the block at a height.
+	GetBlockHeader(context.Context, *HeightRequest) (*BlockHeader, error)
+	// GetProvisioners returns the provisioners in effect after the block at a
+	// height.
+	GetProvisioners(context.Context, *HeightRequest) (*Provisioners, error)
+}
+
+// UnimplementedFinalityServer can be embedded to have forward compatible implementations.
+type UnimplementedFinalityServer struct {
+}
+
+func (*UnimplementedFinalityServer) GetFinalityProof(context.Context, *HeightRequest) (*FinalityProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFinalityProof not implemented")
+}
+func (*UnimplementedFinalityServer) GetBlockHeader(context.Context, *HeightRequest) (*BlockHeader, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHeader not implemented")
+}
+func (*UnimplementedFinalityServer) GetProvisioners(context.Context, *HeightRequest) (*Provisioners, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvisioners not implemented")
+}
+
+func RegisterFinalityServer(s *grpc.Server, srv FinalityServer) {
+	s.RegisterService(&_Finality_serviceDesc, srv)
+}
+
+func _Finality_GetFinalityProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityServer).GetFinalityProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dusk.Finality/GetFinalityProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityServer).GetFinalityProof(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Finality_GetBlockHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityServer).GetBlockHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dusk.Finality/GetBlockHeader",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityServer).GetBlockHeader(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Finality_GetProvisioners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityServer).GetProvisioners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dusk.Finality/GetProvisioners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityServer).GetProvisioners(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Finality_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dusk.Finality",
+	HandlerType: (*FinalityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFinalityProof",
+			Handler:    _Finality_GetFinalityProof_Handler,
+		},
+		{
+			MethodName: "GetBlockHeader",
+			Handler:    _Finality_GetBlockHeader_Handler,
+		},
+		{
+			MethodName: "GetProvisioners",
+			Handler:    _Finality_GetProvisioners_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "finality.proto",
+}
@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package finality produces and verifies compact proofs that a block was
+// finalized by the consensus, for clients which do not follow the consensus
+// themselves.
+package finality
+
+import (
+	"errors"
+	"math"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/finality/finalitypb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"google.golang.org/protobuf/proto"
+)
+
+// StepVotes are the votes of a reduction step for the finalized block: the
+// aggregated BLS signature of the committee members who voted, and their
+// bitset.
+type StepVotes struct {
+	Signature []byte
+	BitSet    uint64
+}
+
+// Proof is a compact proof that a block was finalized. It carries the
+// aggregated votes of both reduction steps of the iteration which produced
+// the block, which is all a client needs to verify the block against the
+// provisioners of the round.
+//
+// The signatures of the two steps are not merged into one, as they sign
+// different votes (the step being part of the vote), and a BLS aggregated
+// signature can only be verified against a single message.
+type Proof struct {
+	BlockHash []byte
+	Round     uint64
+	Iteration uint8
+	StepOne   StepVotes
+	StepTwo   StepVotes
+}
+
+// New creates the finality proof of an accepted block out of its header.
+func New(hdr *block.Header) (*Proof, error) {
+	if hdr.Certificate == nil {
+		return nil, errors.New("block has no certificate")
+	}
+
+	return &Proof{
+		BlockHash: append([]byte{}, hdr.Hash...),
+		Round:     hdr.Height,
+		Iteration: hdr.Iteration,
+		StepOne: StepVotes{
+			Signature: append([]byte{}, hdr.Certificate.StepOneBatchedSig...),
+			BitSet:    hdr.Certificate.StepOneCommittee,
+		},
+		StepTwo: StepVotes{
+			Signature: append([]byte{}, hdr.Certificate.StepTwoBatchedSig...),
+			BitSet:    hdr.Certificate.StepTwoCommittee,
+		},
+	}, nil
+}
+
+// Verify checks the proof against the provisioners, and the seed, of the
+// round. These are the provisioners and the seed of the block preceding the
+// finalized one.
+func (p *Proof) Verify(provisioners user.Provisioners, seed []byte) error {
+	if p.Iteration == 0 {
+		return errors.New("invalid iteration")
+	}
+
+	cert := &block.Certificate{
+		StepOneBatchedSig: p.StepOne.Signature,
+		StepTwoBatchedSig: p.StepTwo.Signature,
+		StepOneCommittee:  p.StepOne.BitSet,
+		StepTwoCommittee:  p.StepTwo.BitSet,
+	}
+
+	return agreement.CheckCertificate(provisioners, p.Round, p.Iteration, p.BlockHash, cert, seed)
+}
+
+// ToProto converts a Proof into its protobuf message.
+func (p *Proof) ToProto() *finalitypb.FinalityProof {
+	return &finalitypb.FinalityProof{
+		BlockHash: p.BlockHash,
+		Round:     p.Round,
+		Iteration: uint32(p.Iteration),
+		StepOne: &finalitypb.StepVotes{
+			Signature: p.StepOne.Signature,
+			Bitset:    p.StepOne.BitSet,
+		},
+		StepTwo: &finalitypb.StepVotes{
+			Signature: p.StepTwo.Signature,
+			Bitset:    p.StepTwo.BitSet,
+		},
+	}
+}
+
+// FromProto converts a protobuf message into a Proof.
+func FromProto(m *finalitypb.FinalityProof) (*Proof, error) {
+	if len(m.GetBlockHash()) != 32 {
+		return nil, errors.New("invalid block hash")
+	}
+
+	if m.GetIteration() > math.MaxUint8 {
+		return nil, errors.New("invalid iteration")
+	}
+
+	return &Proof{
+		BlockHash: m.GetBlockHash(),
+		Round:     m.GetRound(),
+		Iteration: uint8(m.GetIteration()),
+		StepOne: StepVotes{
+			Signature: m.GetStepOne().GetSignature(),
+			BitSet:    m.GetStepOne().GetBitset(),
+		},
+		StepTwo: StepVotes{
+			Signature: m.GetStepTwo().GetSignature(),
+			BitSet:    m.GetStepTwo().GetBitset(),
+		},
+	}, nil
+}
+
+// Marshal a Proof into its protobuf encoding.
+func Marshal(p *Proof) ([]byte, error) {
+	return proto.Marshal(p.ToProto())
+}
+
+// Unmarshal a Proof from its protobuf encoding.
+func Unmarshal(b []byte) (*Proof, error) {
+	m := new(finalitypb.FinalityProof)
+	if err := proto.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+
+	return FromProto(m)
+}
@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package finality_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/finality"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// mockFinalizedBlock creates a block of the given round, finalized in the
+// first iteration by a quorum of the provisioners.
+func mockFinalizedBlock(round uint64, seed []byte) (*block.Block, *consensus.RoundUpdate) {
+	p, keys := consensus.MockProvisioners(10)
+
+	blk := helper.RandomBlock(round, 1)
+	blk.Header.Iteration = 1
+
+	votes := message.GenVotes(blk.Header.Hash, seed, round, 3, keys, p)
+	blk.Header.Certificate = &block.Certificate{
+		StepOneBatchedSig: votes[0].Signature,
+		StepTwoBatchedSig: votes[1].Signature,
+		StepOneCommittee:  votes[0].BitSet,
+		StepTwoCommittee:  votes[1].BitSet,
+	}
+
+	return blk, &consensus.RoundUpdate{Round: round, P: *p, Seed: seed}
+}
+
+func TestProof(t *testing.T) {
+	seed := []byte{1, 2, 3, 4}
+	blk, ru := mockFinalizedBlock(5, seed)
+
+	p, err := finality.New(blk.Header)
+	require.NoError(t, err)
+	require.NoError(t, p.Verify(ru.P, seed))
+
+	b, err := finality.Marshal(p)
+	require.NoError(t, err)
+
+	decoded, err := finality.Unmarshal(b)
+	require.NoError(t, err)
+	require.Equal(t, p, decoded)
+	require.NoError(t, decoded.Verify(ru.P, seed))
+
+	// A proof does not hold for another block, round or iteration
+	decoded.BlockHash[0] ^= 0xff
+	require.Error(t, decoded.Verify(ru.P, seed))
+
+	p.Round++
+	require.Error(t, p.Verify(ru.P, seed))
+	p.Round--
+
+	p.Iteration++
+	require.Error(t, p.Verify(ru.P, seed))
+}
+
+func TestService(t *testing.T) {
+	seed := []byte{1, 2, 3, 4}
+	blk, ru := mockFinalizedBlock(5, seed)
+
+	_, db := lite.CreateDBConnection()
+	require.NoError(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreProvisioners(4, &ru.P); err != nil {
+			return err
+		}
+
+		return t.StoreBlock(blk, true)
+	}))
+
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "grpc.sock"))
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	finality.Register(srv, finality.NewService(db))
+
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("unix://"+l.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	p, err := finality.GetFinalityProof(context.Background(), conn, 5)
+	require.NoError(t, err)
+	require.Equal(t, blk.Header.Hash, p.BlockHash)
+	require.NoError(t, p.Verify(ru.P, seed))
+
+	_, err = finality.GetFinalityProof(context.Background(), conn, 6)
+	require.Error(t, err)
+
+	hdr, err := finality.GetBlockHeader(context.Background(), conn, 5)
+	require.NoError(t, err)
+	require.True(t, hdr.Equals(blk.Header))
+
+	_, err = finality.GetBlockHeader(context.Background(), conn, 6)
+	require.Error(t, err)
+
+	// The provisioners of the previous height allow a light client to verify
+	// the proof
+	provisioners, err := finality.GetProvisioners(context.Background(), conn, 4)
+	require.NoError(t, err)
+	require.NoError(t, p.Verify(provisioners, seed))
+
+	_, err = finality.GetProvisioners(context.Background(), conn, 3)
+	require.Error(t, err)
+}
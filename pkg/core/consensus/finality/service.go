@@ -0,0 +1,159 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package finality
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/finality/finalitypb"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service serves the finality proofs, the headers and the provisioners
+// snapshots stored in the database.
+type Service struct {
+	db database.DB
+}
+
+// NewService creates a Service reading from db.
+func NewService(db database.DB) *Service {
+	return &Service{db: db}
+}
+
+// GetFinalityProof returns the finality proof of the block at the requested
+// height.
+func (s *Service) GetFinalityProof(ctx context.Context, req *finalitypb.HeightRequest) (*finalitypb.FinalityProof, error) {
+	hdr, err := s.fetchHeader(req.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := New(hdr)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return p.ToProto(), nil
+}
+
+// GetBlockHeader returns the header of the block at the requested height.
+func (s *Service) GetBlockHeader(ctx context.Context, req *finalitypb.HeightRequest) (*finalitypb.BlockHeader, error) {
+	hdr, err := s.fetchHeader(req.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := message.MarshalHeader(buf, hdr); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &finalitypb.BlockHeader{Header: buf.Bytes()}, nil
+}
+
+// GetProvisioners returns the provisioners in effect after the block at the
+// requested height, which verify the certificate of the next block. Heights
+// above the tip get the current provisioners.
+func (s *Service) GetProvisioners(ctx context.Context, req *finalitypb.HeightRequest) (*finalitypb.Provisioners, error) {
+	var p *user.Provisioners
+
+	err := s.db.View(func(t database.Transaction) error {
+		var err error
+		p, err = t.FetchProvisioners(req.GetHeight())
+		return err
+	})
+
+	if errors.Is(err, database.ErrProvisionersNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no provisioners at height %d", req.GetHeight())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, p); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &finalitypb.Provisioners{Provisioners: buf.Bytes()}, nil
+}
+
+func (s *Service) fetchHeader(height uint64) (*block.Header, error) {
+	var hdr *block.Header
+
+	err := s.db.View(func(t database.Transaction) error {
+		hash, err := t.FetchBlockHashByHeight(height)
+		if err != nil {
+			return err
+		}
+
+		hdr, err = t.FetchBlockHeader(hash)
+		return err
+	})
+
+	if errors.Is(err, database.ErrBlockNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no block at height %d", height)
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return hdr, nil
+}
+
+// Register the Service on a gRPC server.
+func Register(srv *grpc.Server, s finalitypb.FinalityServer) {
+	finalitypb.RegisterFinalityServer(srv, s)
+}
+
+// GetFinalityProof requests the finality proof of the block at the given
+// height to a node.
+func GetFinalityProof(ctx context.Context, conn *grpc.ClientConn, height uint64) (*Proof, error) {
+	resp, err := finalitypb.NewFinalityClient(conn).GetFinalityProof(ctx, &finalitypb.HeightRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+
+	return FromProto(resp)
+}
+
+// GetBlockHeader requests the header of the block at the given height to a
+// node.
+func GetBlockHeader(ctx context.Context, conn *grpc.ClientConn, height uint64) (*block.Header, error) {
+	resp, err := finalitypb.NewFinalityClient(conn).GetBlockHeader(ctx, &finalitypb.HeightRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+
+	hdr := block.NewHeader()
+	if err := message.UnmarshalHeader(bytes.NewBuffer(resp.GetHeader()), hdr); err != nil {
+		return nil, err
+	}
+
+	return hdr, nil
+}
+
+// GetProvisioners requests the provisioners in effect after the block at the
+// given height to a node.
+func GetProvisioners(ctx context.Context, conn *grpc.ClientConn, height uint64) (user.Provisioners, error) {
+	resp, err := finalitypb.NewFinalityClient(conn).GetProvisioners(ctx, &finalitypb.HeightRequest{Height: height})
+	if err != nil {
+		return user.Provisioners{}, err
+	}
+
+	return user.UnmarshalProvisioners(bytes.NewBuffer(resp.GetProvisioners()))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -37,6 +38,7 @@ func MockEmitter(consTimeout time.Duration) *Emitter {
 		RPCBus:      rpc,
 		Keys:        keys,
 		TimerLength: consTimeout,
+		Signer:      signer.NewInMemory(keys),
 	}
 }
 
@@ -47,6 +49,7 @@ func StupidEmitter() (*Emitter, *user.Provisioners) {
 
 	emitter := MockEmitter(time.Second)
 	emitter.Keys = provisionersKeys[0]
+	emitter.Signer = signer.NewInMemory(emitter.Keys)
 
 	return emitter, p
 }
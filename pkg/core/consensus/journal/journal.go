@@ -0,0 +1,338 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package journal
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+)
+
+// DefaultSize is the amount of rounds retained by a Journal when no size is
+// specified.
+const DefaultSize = 100
+
+type (
+	// Vote is a vote received by the node during a step.
+	Vote struct {
+		Sender     []byte
+		Hash       []byte
+		ReceivedAt time.Time
+	}
+
+	// Step records what happened in a single consensus step.
+	Step struct {
+		Step      uint8
+		Phase     string
+		StartedAt time.Time
+		EndedAt   time.Time
+
+		// Committee is the list of the BLS keys of the step committee.
+		Committee [][]byte
+		// Member is true if this node is part of the committee.
+		Member bool
+
+		// Candidate is the hash of the candidate block the step worked on.
+		Candidate []byte
+		// Alternatives are the hashes of the valid candidate blocks received
+		// during a selection step, but not chosen.
+		Alternatives [][]byte
+		Votes        []Vote
+
+		// QuorumHash is the hash which reached quorum. QuorumAt is zero
+		// if no quorum was reached.
+		QuorumHash []byte
+		QuorumAt   time.Time
+		TimedOut   bool
+	}
+
+	// Round records the steps run during a consensus round, and its outcome.
+	Round struct {
+		Round     uint64
+		StartedAt time.Time
+		EndedAt   time.Time
+		Steps     []*Step
+
+		// BlockHash and Certificate are set if the round produced a block.
+		BlockHash   []byte
+		Certificate *block.Certificate
+		Err         string
+	}
+)
+
+// Journal keeps a structured record of the last consensus rounds, to ease
+// post-mortem analysis of rounds which failed to produce a block in time.
+// Rounds are stored in a ring buffer, so that the oldest round is
+// overwritten once the Journal is full.
+//
+// It is safe for concurrent use. A nil Journal is a valid no-op Journal.
+type Journal struct {
+	lock   sync.RWMutex
+	rounds []*Round
+	next   int
+}
+
+// New creates a Journal retaining up to size rounds. A non-positive size
+// defaults to DefaultSize.
+func New(size int) *Journal {
+	if size <= 0 {
+		size = DefaultSize
+	}
+
+	return &Journal{rounds: make([]*Round, size)}
+}
+
+// StartRound opens a new record for the round. An existing record for the
+// same round is reset.
+func (j *Journal) StartRound(round uint64) {
+	if j == nil {
+		return
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	rec := &Round{Round: round, StartedAt: time.Now(), Steps: make([]*Step, 0)}
+
+	for i, r := range j.rounds {
+		if r != nil && r.Round == round {
+			j.rounds[i] = rec
+			return
+		}
+	}
+
+	j.rounds[j.next] = rec
+	j.next = (j.next + 1) % len(j.rounds)
+}
+
+// StartStep records the beginning of a step of the round.
+func (j *Journal) StartStep(round uint64, step uint8, phase string) {
+	j.update(round, step, func(s *Step) {
+		s.Phase = phase
+		s.StartedAt = time.Now()
+	})
+}
+
+// EndStep records the end of a step of the round.
+func (j *Journal) EndStep(round uint64, step uint8) {
+	j.update(round, step, func(s *Step) {
+		s.EndedAt = time.Now()
+	})
+}
+
+// SetCommittee records the committee of a step, and whether this node is
+// part of it.
+func (j *Journal) SetCommittee(round uint64, step uint8, committee [][]byte, member bool) {
+	j.update(round, step, func(s *Step) {
+		s.Committee = committee
+		s.Member = member
+	})
+}
+
+// SetCandidate records the hash of the candidate block a step worked on.
+func (j *Journal) SetCandidate(round uint64, step uint8, hash []byte) {
+	j.update(round, step, func(s *Step) {
+		s.Candidate = copyBytes(hash)
+	})
+}
+
+// SetAlternatives records the hashes of the valid candidate blocks a step
+// did not choose.
+func (j *Journal) SetAlternatives(round uint64, step uint8, hashes [][]byte) {
+	j.update(round, step, func(s *Step) {
+		s.Alternatives = make([][]byte, len(hashes))
+		for i, h := range hashes {
+			s.Alternatives[i] = copyBytes(h)
+		}
+	})
+}
+
+// AddVote records a verified vote received during a step.
+func (j *Journal) AddVote(round uint64, step uint8, sender, hash []byte) {
+	j.update(round, step, func(s *Step) {
+		s.Votes = append(s.Votes, Vote{
+			Sender:     copyBytes(sender),
+			Hash:       copyBytes(hash),
+			ReceivedAt: time.Now(),
+		})
+	})
+}
+
+// SetQuorum records that the votes of a step reached quorum on a hash.
+func (j *Journal) SetQuorum(round uint64, step uint8, hash []byte) {
+	j.update(round, step, func(s *Step) {
+		s.QuorumHash = copyBytes(hash)
+		s.QuorumAt = time.Now()
+	})
+}
+
+// SetTimeout records that a step timed out.
+func (j *Journal) SetTimeout(round uint64, step uint8) {
+	j.update(round, step, func(s *Step) {
+		s.TimedOut = true
+	})
+}
+
+// EndRound records the outcome of the round. The block is ignored if err is
+// not nil.
+func (j *Journal) EndRound(round uint64, blk block.Block, err error) {
+	if j == nil {
+		return
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	r := j.find(round)
+	if r == nil {
+		return
+	}
+
+	r.EndedAt = time.Now()
+
+	if err != nil {
+		r.Err = err.Error()
+		return
+	}
+
+	if blk.Header != nil {
+		r.BlockHash = copyBytes(blk.Header.Hash)
+
+		if blk.Header.Certificate != nil {
+			r.Certificate = blk.Header.Certificate.Copy()
+		}
+	}
+}
+
+// Rounds returns a copy of the records of the rounds between from and to
+// (inclusive), sorted by round.
+func (j *Journal) Rounds(from, to uint64) []Round {
+	res := make([]Round, 0)
+
+	if j == nil {
+		return res
+	}
+
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
+	for _, r := range j.rounds {
+		if r == nil || r.Round < from || r.Round > to {
+			continue
+		}
+
+		res = append(res, r.copy())
+	}
+
+	sort.Slice(res, func(i, k int) bool {
+		return res[i].Round < res[k].Round
+	})
+
+	return res
+}
+
+func (j *Journal) update(round uint64, step uint8, fn func(*Step)) {
+	if j == nil {
+		return
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	r := j.find(round)
+	if r == nil {
+		return
+	}
+
+	var s *Step
+
+	for i := len(r.Steps) - 1; i >= 0; i-- {
+		if r.Steps[i].Step == step {
+			s = r.Steps[i]
+			break
+		}
+	}
+
+	if s == nil {
+		s = &Step{Step: step, Votes: make([]Vote, 0)}
+		r.Steps = append(r.Steps, s)
+	}
+
+	fn(s)
+}
+
+func (j *Journal) find(round uint64) *Round {
+	for _, r := range j.rounds {
+		if r != nil && r.Round == round {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// copy returns a deep copy of the record, sharing no memory with it.
+func (r *Round) copy() Round {
+	cpy := *r
+	cpy.BlockHash = copyBytes(r.BlockHash)
+
+	if r.Certificate != nil {
+		cpy.Certificate = r.Certificate.Copy()
+	}
+
+	cpy.Steps = make([]*Step, len(r.Steps))
+	for i, s := range r.Steps {
+		cpy.Steps[i] = s.copy()
+	}
+
+	return cpy
+}
+
+func (s *Step) copy() *Step {
+	cpy := *s
+	cpy.Committee = copyHashes(s.Committee)
+	cpy.Candidate = copyBytes(s.Candidate)
+	cpy.Alternatives = copyHashes(s.Alternatives)
+	cpy.QuorumHash = copyBytes(s.QuorumHash)
+
+	cpy.Votes = make([]Vote, len(s.Votes))
+	for i, v := range s.Votes {
+		cpy.Votes[i] = Vote{
+			Sender:     copyBytes(v.Sender),
+			Hash:       copyBytes(v.Hash),
+			ReceivedAt: v.ReceivedAt,
+		}
+	}
+
+	return &cpy
+}
+
+func copyHashes(hashes [][]byte) [][]byte {
+	if hashes == nil {
+		return nil
+	}
+
+	cpy := make([][]byte, len(hashes))
+	for i, h := range hashes {
+		cpy[i] = copyBytes(h)
+	}
+
+	return cpy
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	c := make([]byte, len(b))
+	copy(c, b)
+
+	return c
+}
@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package journal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRound(t *testing.T) {
+	require := require.New(t)
+
+	j := New(10)
+	j.StartRound(1)
+
+	j.StartStep(1, 1, "selection")
+	j.SetCommittee(1, 1, [][]byte{{1}, {2}}, true)
+	j.SetCandidate(1, 1, []byte{3})
+	j.EndStep(1, 1)
+
+	j.StartStep(1, 2, "reduction-first-step")
+	j.AddVote(1, 2, []byte{1}, []byte{3})
+	j.AddVote(1, 2, []byte{2}, []byte{3})
+	j.SetQuorum(1, 2, []byte{3})
+	j.EndStep(1, 2)
+
+	j.StartStep(1, 3, "reduction-second-step")
+	j.SetTimeout(1, 3)
+	j.EndStep(1, 3)
+
+	blk := block.NewBlock()
+	blk.Header.Hash = []byte{3}
+	j.EndRound(1, *blk, nil)
+
+	rounds := j.Rounds(0, 10)
+	require.Len(rounds, 1)
+
+	r := rounds[0]
+	require.Equal(uint64(1), r.Round)
+	require.Equal([]byte{3}, r.BlockHash)
+	require.NotNil(r.Certificate)
+	require.Empty(r.Err)
+	require.False(r.EndedAt.IsZero())
+	require.Len(r.Steps, 3)
+
+	require.Equal("selection", r.Steps[0].Phase)
+	require.True(r.Steps[0].Member)
+	require.Len(r.Steps[0].Committee, 2)
+	require.Equal([]byte{3}, r.Steps[0].Candidate)
+
+	require.Len(r.Steps[1].Votes, 2)
+	require.Equal([]byte{3}, r.Steps[1].QuorumHash)
+	require.False(r.Steps[1].QuorumAt.IsZero())
+
+	require.True(r.Steps[2].TimedOut)
+	require.True(r.Steps[2].QuorumAt.IsZero())
+
+	// Returned rounds are copies, sharing no memory with the record
+	rounds[0].Steps[1].Votes[0].Hash = []byte{4}
+	rounds[0].Steps[1].Votes[0].Sender[0] = 9
+	rounds[0].Steps[0].Committee[0][0] = 9
+	rounds[0].Steps[0].Candidate[0] = 9
+	rounds[0].Steps[1].QuorumHash[0] = 9
+	rounds[0].BlockHash[0] = 9
+	rounds[0].Certificate.StepOneCommittee = 9
+
+	r = j.Rounds(1, 1)[0]
+	require.Equal([]byte{3}, r.Steps[1].Votes[0].Hash)
+	require.Equal([]byte{1}, r.Steps[1].Votes[0].Sender)
+	require.Equal([]byte{1}, r.Steps[0].Committee[0])
+	require.Equal([]byte{3}, r.Steps[0].Candidate)
+	require.Equal([]byte{3}, r.Steps[1].QuorumHash)
+	require.Equal([]byte{3}, r.BlockHash)
+	require.NotEqual(uint64(9), r.Certificate.StepOneCommittee)
+}
+
+func TestRingBuffer(t *testing.T) {
+	require := require.New(t)
+
+	j := New(3)
+	for i := uint64(1); i <= 5; i++ {
+		j.StartRound(i)
+	}
+
+	rounds := j.Rounds(0, 10)
+	require.Len(rounds, 3)
+
+	for i, r := range rounds {
+		require.Equal(uint64(i+3), r.Round)
+	}
+
+	// Restarting a round resets its record
+	j.StartStep(4, 1, "selection")
+	j.StartRound(4)
+	require.Empty(j.Rounds(4, 4)[0].Steps)
+
+	j.EndRound(5, block.Block{}, errors.New("max steps reached"))
+	require.Equal("max steps reached", j.Rounds(5, 5)[0].Err)
+
+	// Unknown rounds are ignored
+	j.AddVote(1, 1, []byte{1}, []byte{1})
+	require.Empty(j.Rounds(1, 2))
+
+	var nilJournal *Journal
+	nilJournal.StartRound(1)
+	require.Empty(nilJournal.Rounds(0, 10))
+}
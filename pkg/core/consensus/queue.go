@@ -16,41 +16,137 @@ import (
 )
 
 const (
+	// maxMessages is the amount of messages a Queue holds at most.
 	maxMessages = 4096
+	// maxRoundsAhead is how far in the future, relative to the current round,
+	// the round of a stored message can be.
+	maxRoundsAhead = 10
+	// maxFutureRoundMessages is the amount of messages stored at most for a
+	// round after the next one.
+	maxFutureRoundMessages = 512
+	// defaultTopicQuota is the amount of messages stored at most for a round
+	// and a topic without a quota.
+	defaultTopicQuota = 256
 )
 
+// topicQuotas is the amount of messages of each topic stored at most for a
+// round, so that a flood of messages of a topic can not crowd out the others.
+var topicQuotas = map[topics.Topic]int{
+	topics.NewBlock:      256,
+	topics.Reduction:     2048,
+	topics.Agreement:     2048,
+	topics.AggrAgreement: 256,
+}
+
+// DropReason tells why a Queue dropped a message.
+type DropReason string
+
+const (
+	// DropObsolete is for messages of a round before the current one.
+	DropObsolete DropReason = "obsolete"
+	// DropTooFar is for messages of a round too far in the future.
+	DropTooFar DropReason = "too_far"
+	// DropRoundQuota is for messages of a future round which reached its
+	// quota.
+	DropRoundQuota DropReason = "round_quota"
+	// DropTopicQuota is for messages of a topic which reached its quota for
+	// the round.
+	DropTopicQuota DropReason = "topic_quota"
+	// DropFull is for messages received while the Queue is full.
+	DropFull DropReason = "full"
+	// DropEvicted is for future round messages evicted to make room for a
+	// message of the current or next round.
+	DropEvicted DropReason = "evicted"
+)
+
+// QueueStatus is a snapshot of the state of a Queue.
+type QueueStatus struct {
+	// Round is the current round of the Queue.
+	Round    uint64
+	Items    int
+	Capacity int
+	// Rounds is the amount of messages stored for each round.
+	Rounds map[uint64]int
+	// Dropped is the amount of messages dropped since the creation of the
+	// Queue, by reason.
+	Dropped map[DropReason]uint64
+}
+
+type roundEntries struct {
+	steps  map[uint8][]message.Message
+	topics map[topics.Topic]int
+	items  int
+}
+
+func newRoundEntries() *roundEntries {
+	return &roundEntries{
+		steps:  make(map[uint8][]message.Message),
+		topics: make(map[topics.Topic]int),
+	}
+}
+
+func (re *roundEntries) remove(msgs []message.Message) {
+	for _, m := range msgs {
+		re.topics[m.Category()]--
+	}
+
+	re.items -= len(msgs)
+}
+
 // Queue is a Queue of Events grouped by rounds and steps. It is thread-safe
 // through a sync.RWMutex.
+//
+// Memory is bounded by a global capacity, by a quota of messages for each
+// round after the next one, and by a quota of messages for each topic of a
+// round. Messages of the current and next round have priority: once the
+// Queue is full, they evict messages of the farthest future round.
 // TODO: entries should become buntdb instead.
 type Queue struct {
 	lock    sync.RWMutex
-	entries map[uint64]map[uint8][]message.Message
+	entries map[uint64]*roundEntries
 	items   int
+
+	// round is the current round. Messages of previous rounds are refused.
+	round   uint64
+	dropped map[DropReason]uint64
 }
 
 // NewQueue creates a new Queue. It is primarily used by Collectors to
 // temporarily store messages not yet relevant to the collection process.
 func NewQueue() *Queue {
-	entries := make(map[uint64]map[uint8][]message.Message)
 	return &Queue{
-		entries: entries,
+		entries: make(map[uint64]*roundEntries),
+		dropped: make(map[DropReason]uint64),
 	}
 }
 
+// SetRound sets the current round, and discards the messages of the rounds
+// before it.
+func (eq *Queue) SetRound(round uint64) {
+	eq.lock.Lock()
+	defer eq.lock.Unlock()
+
+	eq.round = round
+	eq.prune(round)
+}
+
 // GetEvents returns the events for a round and step.
 func (eq *Queue) GetEvents(round uint64, step uint8) []message.Message {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
-	if eq.entries[round][step] != nil {
-		messages := eq.entries[round][step]
-
-		eq.entries[round][step] = nil
-		eq.items -= len(messages)
-		return messages
+	re := eq.entries[round]
+	if re == nil || re.steps[step] == nil {
+		return nil
 	}
 
-	return nil
+	messages := re.steps[step]
+	delete(re.steps, step)
+
+	re.remove(messages)
+	eq.items -= len(messages)
+
+	return messages
 }
 
 // PutEvent stores an Event at a given round and step.
@@ -58,54 +154,59 @@ func (eq *Queue) PutEvent(round uint64, step uint8, m message.Message) {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
-	if eq.items >= maxMessages {
-		logrus.WithField("process", "consensus").
-			WithField("round", round).
-			WithField("step", step).
-			WithField("topic", m.Category()).
-			Warnln("dropping message, queue has reached max capacity")
+	if round < eq.round {
+		eq.drop(round, step, m, DropObsolete)
 		return
 	}
 
-	// Initialize the map on this round if it was not yet created
-	if eq.entries[round] == nil {
-		eq.entries[round] = make(map[uint8][]message.Message)
+	if round-eq.round >= maxRoundsAhead {
+		eq.drop(round, step, m, DropTooFar)
+		return
 	}
 
-	// Initialize the array on this step if it was not yet created
-	if eq.entries[round][step] == nil {
-		eq.entries[round][step] = make([]message.Message, 0)
+	re := eq.entries[round]
+	if re == nil {
+		re = newRoundEntries()
+	}
+
+	priority := round <= eq.round+1
+
+	if !priority && re.items >= maxFutureRoundMessages {
+		eq.drop(round, step, m, DropRoundQuota)
+		return
+	}
+
+	if re.topics[m.Category()] >= topicQuota(m.Category()) {
+		eq.drop(round, step, m, DropTopicQuota)
+		return
+	}
+
+	if eq.items >= maxMessages && (!priority || !eq.evict()) {
+		eq.drop(round, step, m, DropFull)
+		return
 	}
 
-	eq.entries[round][step] = append(eq.entries[round][step], m)
+	// Initialize the entries of this round if they were not yet created
+	eq.entries[round] = re
+
+	re.steps[step] = append(re.steps[step], m)
+	re.topics[m.Category()]++
+	re.items++
 	eq.items++
 }
 
 // Clear the queue. This method swaps the internal `entries` map, to avoid
 // a situation where memory is continuously allocated and never freed.
+// The round following the cleared one becomes the current round.
 func (eq *Queue) Clear(round uint64) {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
-	newEntries := make(map[uint64]map[uint8][]message.Message)
-	eq.items = 0
-
-	for r := range eq.entries {
-		if r > round {
-			newEntries[r] = make(map[uint8][]message.Message)
-
-			for s, m := range eq.entries[r] {
-				newEntries[r][s] = m
-				eq.items += len(m)
-
-				delete(eq.entries[r], s)
-			}
-
-			delete(eq.entries, r)
-		}
+	if round+1 > eq.round {
+		eq.round = round + 1
 	}
 
-	eq.entries = newEntries
+	eq.prune(eq.round)
 }
 
 // Flush all events stored for a specific round from the queue, and return them
@@ -114,41 +215,150 @@ func (eq *Queue) Flush(round uint64) []message.Message {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
-	if eq.entries[round] != nil {
-		events := make([]message.Message, 0)
-		evround := eq.entries[round]
+	re := eq.entries[round]
+	if re == nil {
+		return nil
+	}
 
-		steps := make([]uint8, 0, len(evround))
-		for k := range evround {
-			steps = append(steps, k)
-		}
-		// Give priority to oldest steps
-		sort.SliceStable(steps, func(i, j int) bool {
-			return steps[i] < steps[j]
-		})
-
-		for _, step := range steps {
-			events = append(events, evround[step]...)
-			eq.entries[round][step] = nil
-		}
-		// Give priority to AggrAgreement messages otherwise use previous step order
-		sort.SliceStable(events, func(i, j int) bool {
-			topic_i := events[i].Category()
-			if topic_i == topics.AggrAgreement {
-				if events[j].Category() != topics.AggrAgreement {
-					return true
-				}
-				return i < j
-			}
+	events := make([]message.Message, 0, re.items)
+
+	steps := make([]uint8, 0, len(re.steps))
+	for k := range re.steps {
+		steps = append(steps, k)
+	}
+	// Give priority to oldest steps
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i] < steps[j]
+	})
+
+	for _, step := range steps {
+		events = append(events, re.steps[step]...)
+	}
+	// Give priority to AggrAgreement messages otherwise use previous step order
+	sort.SliceStable(events, func(i, j int) bool {
+		topic_i := events[i].Category()
+		if topic_i == topics.AggrAgreement {
 			if events[j].Category() != topics.AggrAgreement {
-				return false
+				return true
 			}
 			return i < j
-		})
+		}
+		if events[j].Category() != topics.AggrAgreement {
+			return false
+		}
+		return i < j
+	})
+
+	delete(eq.entries, round)
+	eq.items -= re.items
+
+	return events
+}
+
+// Status returns a snapshot of the state of the Queue.
+func (eq *Queue) Status() QueueStatus {
+	eq.lock.RLock()
+	defer eq.lock.RUnlock()
+
+	s := QueueStatus{
+		Round:    eq.round,
+		Items:    eq.items,
+		Capacity: maxMessages,
+		Rounds:   make(map[uint64]int, len(eq.entries)),
+		Dropped:  make(map[DropReason]uint64, len(eq.dropped)),
+	}
+
+	for r, re := range eq.entries {
+		if re.items > 0 {
+			s.Rounds[r] = re.items
+		}
+	}
+
+	for reason, n := range eq.dropped {
+		s.Dropped[reason] = n
+	}
+
+	return s
+}
+
+// prune removes the entries of the rounds before round.
+func (eq *Queue) prune(round uint64) {
+	newEntries := make(map[uint64]*roundEntries)
+	eq.items = 0
+
+	for r, re := range eq.entries {
+		if r >= round {
+			newEntries[r] = re
+			eq.items += re.items
+		}
+	}
+
+	eq.entries = newEntries
+}
+
+// evict drops the last message of the highest step of the farthest round
+// after the next one. It returns false if there is no such message.
+func (eq *Queue) evict() bool {
+	var (
+		farthest uint64
+		found    bool
+	)
+
+	for r, re := range eq.entries {
+		if r > eq.round+1 && re.items > 0 && (!found || r > farthest) {
+			farthest, found = r, true
+		}
+	}
+
+	if !found {
+		return false
+	}
+
+	re := eq.entries[farthest]
+
+	var (
+		highest uint8
+		ok      bool
+	)
+
+	for s, msgs := range re.steps {
+		if len(msgs) > 0 && (!ok || s > highest) {
+			highest, ok = s, true
+		}
+	}
+
+	msgs := re.steps[highest]
+	m := msgs[len(msgs)-1]
+
+	re.steps[highest] = msgs[:len(msgs)-1]
+	re.remove([]message.Message{m})
+	eq.items--
+
+	eq.drop(farthest, highest, m, DropEvicted)
+	return true
+}
+
+func (eq *Queue) drop(round uint64, step uint8, m message.Message, reason DropReason) {
+	eq.dropped[reason]++
+
+	l := logrus.WithField("process", "consensus").
+		WithField("round", round).
+		WithField("step", step).
+		WithField("topic", m.Category()).
+		WithField("reason", reason)
+
+	if reason == DropFull {
+		l.Warnln("dropping message, queue has reached max capacity")
+		return
+	}
+
+	l.Debugln("dropping message")
+}
 
-		eq.items -= len(events)
-		return events
+func topicQuota(t topics.Topic) int {
+	if q, ok := topicQuotas[t]; ok {
+		return q
 	}
 
-	return nil
+	return defaultTopicQuota
 }
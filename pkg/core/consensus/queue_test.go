@@ -35,12 +35,15 @@ func TestQueueMaxCap(t *testing.T) {
 	q.Clear(10)
 	assert.Equal(t, 0, q.items)
 
+	q.SetRound(0)
+
 	k := key.NewRandKeys()
 	r := message.MockReduction(make([]byte, 32), 0, 2, []key.Keys{k})
 
 	// Fill queue with >4096 messages
 	for i := 0; i < 4100; i++ {
 		q.PutEvent(0, 2, message.New(topics.Reduction, r))
+		q.PutEvent(0, 3, message.New(topics.Agreement, r))
 	}
 
 	assert.Equal(t, 4096, q.items)
@@ -83,3 +86,81 @@ func TestQueueMaxCap(t *testing.T) {
 		last_step = hdr.Step
 	}
 }
+
+func TestQueueQuotas(t *testing.T) {
+	q := NewQueue()
+	q.SetRound(10)
+
+	k := key.NewRandKeys()
+	r := message.MockReduction(make([]byte, 32), 10, 2, []key.Keys{k})
+	red := message.New(topics.Reduction, r)
+
+	// Obsolete and too far messages are refused
+	q.PutEvent(9, 2, red)
+	q.PutEvent(20, 2, red)
+	assert.Equal(t, 0, q.items)
+
+	// A topic can not fill a round on its own
+	for i := 0; i < 2100; i++ {
+		q.PutEvent(10, 2, red)
+	}
+
+	assert.Equal(t, 2048, q.items)
+
+	q.PutEvent(10, 2, message.New(topics.Agreement, r))
+	assert.Equal(t, 2049, q.items)
+
+	// Rounds after the next one have a quota
+	for i := 0; i < 600; i++ {
+		q.PutEvent(15, 2, red)
+	}
+
+	assert.Equal(t, 512, q.Status().Rounds[15])
+
+	s := q.Status()
+	assert.Equal(t, uint64(1), s.Dropped[DropObsolete])
+	assert.Equal(t, uint64(1), s.Dropped[DropTooFar])
+	assert.Equal(t, uint64(52), s.Dropped[DropTopicQuota])
+	assert.Equal(t, uint64(88), s.Dropped[DropRoundQuota])
+
+	// Clearing the round moves the queue to the next one
+	q.Clear(10)
+	assert.Equal(t, uint64(11), q.Status().Round)
+	assert.Equal(t, 512, q.items)
+}
+
+func TestQueuePriority(t *testing.T) {
+	q := NewQueue()
+	q.SetRound(1)
+
+	k := key.NewRandKeys()
+	r := message.MockReduction(make([]byte, 32), 1, 2, []key.Keys{k})
+
+	q.PutEvent(2, 2, message.New(topics.Agreement, r))
+
+	// Fill the queue with future round messages
+	for round := uint64(3); round < 11; round++ {
+		for i := 0; i < maxFutureRoundMessages; i++ {
+			q.PutEvent(round, 2, message.New(topics.Reduction, r))
+		}
+	}
+
+	// Future rounds can not make room for themselves
+	assert.Equal(t, maxMessages, q.items)
+	assert.Equal(t, uint64(1), q.Status().Dropped[DropFull])
+
+	// Messages of the current and next round evict the farthest round
+	for i := 0; i < 10; i++ {
+		q.PutEvent(1, 2, message.New(topics.Agreement, r))
+		q.PutEvent(2, 2, message.New(topics.Agreement, r))
+	}
+
+	s := q.Status()
+	assert.Equal(t, maxMessages, s.Items)
+	assert.Equal(t, 10, s.Rounds[1])
+	assert.Equal(t, 11, s.Rounds[2])
+	assert.Equal(t, maxFutureRoundMessages-21, s.Rounds[10])
+	assert.Equal(t, maxFutureRoundMessages, s.Rounds[9])
+	assert.Equal(t, uint64(20), s.Dropped[DropEvicted])
+	assert.Len(t, q.Flush(1), 10)
+}
@@ -10,7 +10,9 @@ import (
 	"fmt"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/evidence"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
 	"github.com/sirupsen/logrus"
@@ -26,7 +28,8 @@ var lg = log.WithField("process", "consensus").WithField("actor", "aggr")
 // An Aggregator should be instantiated on a per-step basis and is no longer usable
 // after reaching quorum and sending on `haltChan`.
 type Aggregator struct {
-	handler *Handler
+	handler  *Handler
+	detector *evidence.Detector
 
 	voteSets map[string]struct {
 		*message.StepVotes
@@ -35,10 +38,11 @@ type Aggregator struct {
 }
 
 // NewAggregator returns an instantiated Aggregator, ready for use by both
-// reduction steps.
-func NewAggregator(handler *Handler) *Aggregator {
+// reduction steps. The evidence.Detector is optional.
+func NewAggregator(handler *Handler, detector *evidence.Detector) *Aggregator {
 	return &Aggregator{
-		handler: handler,
+		handler:  handler,
+		detector: detector,
 		voteSets: make(map[string]struct {
 			*message.StepVotes
 			sortedset.Cluster
@@ -52,6 +56,16 @@ func NewAggregator(handler *Handler) *Aggregator {
 // added. The validation of the candidate block is left to the caller.
 func (a *Aggregator) CollectVote(ev message.Reduction) *Result {
 	hdr := ev.State()
+
+	// A Provisioner voting for more than one block hash in the same step is
+	// equivocating. Only the first vote is taken into account.
+	if e := a.detector.Check(topics.Reduction, hdr, ev.SignedHash); e != nil {
+		lg.WithField("round", hdr.Round).
+			WithField("step", hdr.Step).
+			Warn("discarding conflicting vote")
+		return nil
+	}
+
 	hash := string(hdr.BlockHash)
 	sv, found := a.voteSets[hash]
 
@@ -67,7 +67,7 @@ func TestAggregation(t *testing.T) {
 			require := require.New(t)
 			// setting up the helper and the aggregator
 			hlp := NewHelper(messageToSpawn+1, 1*time.Second)
-			aggregator := NewAggregator(hlp.Handler)
+			aggregator := NewAggregator(hlp.Handler, nil)
 
 			// running test-specific setup on the Helper
 			tt.setup(hlp)
@@ -144,3 +144,58 @@ func TestFirstStepReduction(t *testing.T) {
 		})
 	}
 }
+
+// TestObserver tests that an observer reaches quorum on the votes of the
+// committee, without ever sending its own vote.
+func TestObserver(t *testing.T) {
+	step := uint8(2)
+	round := uint64(1)
+	timeout := 10 * time.Second
+
+	hash, err := crypto.RandEntropy(32)
+	require.NoError(t, err)
+
+	hlp := reduction.NewHelper(10, timeout)
+	hlp.Emitter.Observer = true
+
+	// The observer would vote, were it not an observer
+	require.True(t, hlp.Handler.AmMember(round, step))
+
+	sent := make(chan message.Message, 10)
+	l := eventbus.NewSafeCallbackListener(func(m message.Message) {
+		sent <- m
+	})
+
+	hlp.EventBus.Subscribe(topics.Gossip, l)
+	hlp.EventBus.Subscribe(topics.Kadcast, l)
+
+	evChan := make(chan message.Message, hlp.Nr)
+	for _, ev := range hlp.Spawn(hash, round, step) {
+		evChan <- message.New(topics.Reduction, ev)
+	}
+
+	testPhase := consensus.NewTestPhase(t, func(require *require.Assertions, packet consensus.InternalPacket, _ *eventbus.GossipStreamer, _ chan message.Message) {
+		require.False(packet.(message.StepVotesMsg).IsEmpty())
+	}, nil, nil)
+
+	_, db := lite.CreateDBConnection()
+	firstStepReduction := New(testPhase, hlp.Emitter, hlp.ProcessCandidateVerificationRequest, timeout, db, nil)
+
+	msg := consensus.MockNewBlockMsg(t, &header.Header{BlockHash: hash})
+	firstStepReduction.Initialize(msg.Payload().(message.NewBlock))
+
+	ctx := context.Background()
+	r := consensus.RoundUpdate{
+		Round: round,
+		P:     *hlp.P,
+		Hash:  hash,
+		Seed:  []byte{0, 0, 0, 0},
+	}
+
+	next := firstStepReduction.Run(ctx, consensus.NewQueue(), evChan, evChan, r, step)
+	_ = next.Run(ctx, consensus.NewQueue(), evChan, evChan, r, step+1)
+
+	// Give the asynchronous vote a chance to be (wrongly) sent
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, sent)
+}
@@ -9,6 +9,7 @@ package firststep
 import (
 	"bytes"
 	"context"
+	"sync"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/candidate"
@@ -17,6 +18,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
@@ -47,9 +49,22 @@ type Phase struct {
 
 	requestor *candidate.Requestor
 
+	// prefetched are the candidates fetched in the background, as soon as a
+	// vote for an unknown candidate is received.
+	prefetchLock sync.Mutex
+	prefetched   map[string]*prefetch
+
 	next consensus.Phase
 }
 
+// prefetch is a candidate being fetched in the background. Blk and err are
+// set once done is closed.
+type prefetch struct {
+	done chan struct{}
+	blk  block.Block
+	err  error
+}
+
 // New creates and launches the component which responsibility is to reduce the
 // candidates gathered as winner of the selection of all nodes in the committee
 // and reduce them to just one candidate obtaining 64% of the committee vote.
@@ -94,20 +109,29 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 
 	p.handler = reduction.NewHandler(p.Keys, r.P, r.Seed)
 
+	p.prefetchLock.Lock()
+	p.prefetched = make(map[string]*prefetch)
+	p.prefetchLock.Unlock()
+
+	p.Journal.SetCommittee(r.Round, step, p.handler.Committee(r.Round, step).MemberKeys(), p.handler.AmMember(r.Round, step))
+	p.Journal.SetCandidate(r.Round, step, p.selectionResult.Candidate.Header.Hash)
+
 	// send our own Selection
 	a := reduction.NewAsyncSend(p.Reduction, r.Round, step, &p.selectionResult.Candidate)
 
 	if p.handler.AmMember(r.Round, step) {
 		_ = a.Go(ctx, reductionChan, reduction.Republish)
 	} else {
-		if p.handler.AmMember(r.Round, step+1) {
+		// Observers validate every candidate
+		if p.handler.AmMember(r.Round, step+1) || p.Observer {
 			_ = a.Go(ctx, reductionChan, reduction.ValidateOnly)
 		}
 	}
 
 	// Process queued reduction messages
+	p.TimeOut = p.Timeouts.Timeout(p.String(), p.TimeOut)
 	timeoutChan := time.After(p.TimeOut)
-	p.aggregator = reduction.NewAggregator(p.handler)
+	p.aggregator = reduction.NewAggregator(p.handler, p.Detector)
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
 		if ev.Category() == topics.Reduction {
@@ -154,6 +178,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 		case <-timeoutChan:
 			l := lg.WithField("event", "timeout").WithField("duration", p.TimeOut.String())
 			p.aggregator.Log(l, r.Round, step)
+			p.Journal.SetTimeout(r.Round, step)
 
 			// in case of timeout we proceed in the consensus with an empty hash
 			sv := p.createStepVoteMessage(reduction.EmptyResult, r.Round, step, *block.NewBlock())
@@ -182,6 +207,8 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 			WithField("step", r.State().Step).
 			WithField("hash", util.StringifyBytes(r.State().BlockHash)).
 			Warn("error in verifying reduction, message discarded")
+
+		p.Emitter.Penalize(metadata, reputation.InvalidSignature)
 		return nil
 	}
 
@@ -202,12 +229,17 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 	}
 
 	hdr := r.State()
+	p.Journal.AddVote(round, step, hdr.PubKeyBLS, hdr.BlockHash)
+
+	p.prefetchCandidate(ctx, hdr.BlockHash)
 
 	result := p.aggregator.CollectVote(r)
 	if result == nil {
 		return nil
 	}
 
+	p.Journal.SetQuorum(round, step, result.Hash)
+
 	// if the votes converged for an empty hash we invoke halt with no
 	// StepVotes
 	if bytes.Equal(hdr.BlockHash, block.EmptyHash[:]) {
@@ -217,7 +249,7 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 	if !bytes.Equal(hdr.BlockHash, p.selectionResult.Candidate.Header.Hash) {
 		var err error
 
-		p.selectionResult.Candidate, err = p.fetchCandidate(ctx, hdr.BlockHash)
+		p.selectionResult.Candidate, err = p.awaitCandidate(ctx, hdr.BlockHash)
 		if err != nil {
 			log.
 				WithError(err).
@@ -231,6 +263,62 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 	return p.createStepVoteMessage(result, round, step, p.selectionResult.Candidate)
 }
 
+// prefetchCandidate fetches, and verifies, in the background the candidate
+// voted by a reduction, if it is not the candidate of the selection.
+func (p *Phase) prefetchCandidate(ctx context.Context, hash []byte) {
+	if p.requestor == nil || bytes.Equal(hash, block.EmptyHash[:]) ||
+		bytes.Equal(hash, p.selectionResult.Candidate.Header.Hash) {
+		return
+	}
+
+	p.prefetchLock.Lock()
+	defer p.prefetchLock.Unlock()
+
+	if _, ok := p.prefetched[string(hash)]; ok {
+		return
+	}
+
+	pf := &prefetch{done: make(chan struct{})}
+	p.prefetched[string(hash)] = pf
+
+	go func() {
+		defer close(pf.done)
+
+		pf.blk, pf.err = p.fetchCandidate(ctx, hash)
+		if pf.err != nil || p.VerifyFn == nil {
+			return
+		}
+
+		// The outcome is cached by the verification function
+		if err := p.VerifyFn(ctx, pf.blk); err != nil {
+			lg.WithError(err).
+				WithField("hash", util.StringifyBytes(hash)).
+				Debug("speculative candidate verification failed")
+		}
+	}()
+}
+
+// awaitCandidate returns the candidate of the given hash, waiting for its
+// prefetch if any.
+func (p *Phase) awaitCandidate(ctx context.Context, hash []byte) (block.Block, error) {
+	p.prefetchLock.Lock()
+	pf, ok := p.prefetched[string(hash)]
+	p.prefetchLock.Unlock()
+
+	if ok {
+		select {
+		case <-pf.done:
+			if pf.err == nil {
+				return pf.blk, nil
+			}
+		case <-ctx.Done():
+			return block.Block{}, ctx.Err()
+		}
+	}
+
+	return p.fetchCandidate(ctx, hash)
+}
+
 func (p *Phase) fetchCandidate(ctx context.Context, hash []byte) (block.Block, error) {
 	// First, check to see if we have the candidate in the db.
 	var cm block.Block
@@ -273,7 +361,7 @@ func (p *Phase) requestCandidate(ctx context.Context, hash []byte) (block.Block,
 
 func (p *Phase) createStepVoteMessage(r *reduction.Result, round uint64, step uint8, candidate block.Block) *message.StepVotesMsg {
 	if r.IsEmpty() {
-		p.IncreaseTimeout(round)
+		p.IncreaseTimeout(p.String(), round)
 	}
 
 	var cpy *block.Block
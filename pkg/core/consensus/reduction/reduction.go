@@ -63,17 +63,15 @@ type Reduction struct {
 
 // IncreaseTimeout is used when reduction does not reach the quorum or
 // converges over an empty block.
-func (r *Reduction) IncreaseTimeout(round uint64) {
+func (r *Reduction) IncreaseTimeout(phase string, round uint64) {
 	// if we converged on an empty block hash, we increase the timeout
-	r.TimeOut = r.TimeOut * 2
-	if r.TimeOut > 60*time.Second {
-		lg.
-			WithField("timeout", r.TimeOut).
-			WithField("round", round).
-			Warn("max_timeout_reached")
+	r.TimeOut = r.Timeouts.Increase(phase, r.TimeOut)
 
-		r.TimeOut = 60 * time.Second
-	}
+	lg.
+		WithField("timeout", r.TimeOut).
+		WithField("round", round).
+		WithField("phase", phase).
+		Debug("increase_timeout")
 }
 
 // verifyWithDelay calls verifyFn upon the candidate block but also incorporates a
@@ -136,6 +134,15 @@ func (r *Reduction) SendReduction(ctx context.Context, round uint64, step uint8,
 		voteHash = block.EmptyHash[:]
 	}
 
+	if r.Observer {
+		// Observers validate the candidate, but never vote.
+		lg.WithField("round", round).
+			WithField("step", step).
+			WithField("hash", util.StringifyBytes(voteHash)).
+			Debug("observer mode, reduction not sent")
+		return nil, voteHash, consensus.ErrObserver
+	}
+
 	// Generate Reduction message to propagate my vote.
 	hdr := header.Header{
 		Round:     round,
@@ -144,9 +151,15 @@ func (r *Reduction) SendReduction(ctx context.Context, round uint64, step uint8,
 		PubKeyBLS: r.Keys.BLSPubKey,
 	}
 
-	sig, err := r.Sign(hdr)
+	sig, err := r.Sign(topics.Reduction, hdr)
 	if err != nil {
-		panic(err)
+		// The signer may refuse to sign a vote conflicting with a
+		// previous one. Not voting is the safe option.
+		lg.WithError(err).
+			WithField("round", round).
+			WithField("step", step).
+			Error("could not sign reduction")
+		return nil, nil, err
 	}
 
 	red := message.NewReduction(hdr)
@@ -16,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
@@ -92,6 +93,13 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 	}
 
 	p.handler = reduction.NewHandler(p.Keys, r.P, r.Seed)
+
+	p.Journal.SetCommittee(r.Round, step, p.handler.Committee(r.Round, step).MemberKeys(), p.handler.AmMember(r.Round, step))
+
+	if p.firstStepVotesMsg.Candidate != nil {
+		p.Journal.SetCandidate(r.Round, step, p.firstStepVotesMsg.Candidate.Header.Hash)
+	}
+
 	// first we send our own Selection
 
 	var cancel context.CancelFunc
@@ -103,8 +111,9 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 		defer cancel()
 	}
 
+	p.TimeOut = p.Timeouts.Timeout(p.String(), p.TimeOut)
 	timeoutChan := time.After(p.TimeOut)
-	p.aggregator = reduction.NewAggregator(p.handler)
+	p.aggregator = reduction.NewAggregator(p.handler, p.Detector)
 
 	for _, ev := range queue.GetEvents(r.Round, step) {
 		if ev.Category() == topics.Reduction {
@@ -160,9 +169,10 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, _, reductionCha
 		case <-timeoutChan:
 			l := lg.WithField("event", "timeout").WithField("duration", p.TimeOut.String())
 			p.aggregator.Log(l, r.Round, step)
+			p.Journal.SetTimeout(r.Round, step)
 
 			// in case of timeout we increase the timeout and that's it
-			p.IncreaseTimeout(r.Round)
+			p.IncreaseTimeout(p.String(), r.Round)
 			return p.next.Initialize(nil)
 
 		case <-ctx.Done():
@@ -189,6 +199,8 @@ func (p *Phase) collectReduction(r message.Reduction, round uint64, step uint8,
 				"hash":   util.StringifyBytes(hdr.BlockHash),
 			}).
 			Warn("signature verification error for second step reduction, discarding")
+
+		p.Emitter.Penalize(metadata, reputation.InvalidSignature)
 		return nil
 	}
 
@@ -207,7 +219,12 @@ func (p *Phase) collectReduction(r message.Reduction, round uint64, step uint8,
 		lg.WithError(err).Error("could not republish reduction event")
 	}
 
+	p.Journal.AddVote(round, step, hdr.PubKeyBLS, hdr.BlockHash)
+
 	result := p.aggregator.CollectVote(r)
+	if result != nil {
+		p.Journal.SetQuorum(round, step, result.Hash)
+	}
 
 	return p.createStepVoteMessage(result, round, step)
 }
@@ -236,6 +253,15 @@ func (p *Phase) createStepVoteMessage(r *reduction.Result, round uint64, step ui
 }
 
 func (p *Phase) sendAgreement(round uint64, step uint8, svm *message.StepVotesMsg) {
+	if p.Observer {
+		lg.WithFields(log.Fields{
+			"round": round,
+			"step":  step,
+			"hash":  hex.EncodeToString(svm.BlockHash),
+		}).Debugln("observer mode, agreement not sent")
+		return
+	}
+
 	lg.WithFields(log.Fields{
 		"round": round,
 		"step":  step,
@@ -249,9 +275,13 @@ func (p *Phase) sendAgreement(round uint64, step uint8, svm *message.StepVotesMs
 		BlockHash: svm.BlockHash,
 	}
 
-	sig, err := p.Sign(hdr)
+	sig, err := p.Sign(topics.Agreement, hdr)
 	if err != nil {
-		panic(err)
+		lg.WithError(err).WithFields(log.Fields{
+			"round": round,
+			"step":  step,
+		}).Error("could not sign agreement")
+		return
 	}
 
 	lg.WithFields(log.Fields{
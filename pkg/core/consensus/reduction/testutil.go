@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/stretchr/testify/require"
@@ -70,6 +71,7 @@ func NewHelper(provisioners int, timeOut time.Duration) *Helper {
 
 	emitter := consensus.MockEmitter(timeOut)
 	emitter.Keys = provisionersKeys[0]
+	emitter.Signer = signer.NewInMemory(emitter.Keys)
 	seed := []byte{0, 0, 0, 0}
 
 	hlp := &Helper{
@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package selection
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+	"github.com/stretchr/testify/require"
+)
+
+func mockCandidate(generator, hash byte) message.NewBlock {
+	pk := make([]byte, 96)
+	pk[0] = generator
+
+	h := make([]byte, 32)
+	h[0] = hash
+
+	hdr := header.Header{PubKeyBLS: pk, Round: 1, Step: 1, BlockHash: h}
+	return *message.NewNewBlock(hdr, nil, *block.NewBlock())
+}
+
+// TestRankCandidates ensures that the candidates are ranked by the credits of
+// their generator, then by hash, whatever the order they were received in.
+func TestRankCandidates(t *testing.T) {
+	c := user.VotingCommittee{Cluster: sortedset.NewCluster()}
+	c.Insert(mockCandidate(1, 0).State().PubKeyBLS)
+	c.Insert(mockCandidate(2, 0).State().PubKeyBLS)
+	c.Insert(mockCandidate(2, 0).State().PubKeyBLS)
+	c.Insert(mockCandidate(3, 0).State().PubKeyBLS)
+
+	candidates := []message.NewBlock{
+		mockCandidate(3, 9),
+		mockCandidate(1, 5),
+		mockCandidate(2, 7),
+	}
+
+	rankCandidates(candidates, c)
+
+	require.Equal(t, byte(7), candidates[0].State().BlockHash[0])
+	require.Equal(t, byte(5), candidates[1].State().BlockHash[0])
+	require.Equal(t, byte(9), candidates[2].State().BlockHash[0])
+
+	require.True(t, hasGenerator(candidates, mockCandidate(1, 0).State().PubKeyBLS))
+	require.False(t, hasGenerator(candidates, mockCandidate(4, 0).State().PubKeyBLS))
+}
@@ -52,7 +52,7 @@ func TestSelection(t *testing.T) {
 			}
 
 			testPhase := consensus.NewTestPhase(t, ttestCB, nil, nil)
-			sel := selection.New(testPhase, ttest.bg, hlp.Emitter, consensusTimeOut, db)
+			sel := selection.New(testPhase, ttest.bg, hlp.Emitter, nil, consensusTimeOut, db)
 			selFn := sel.Initialize(nil)
 
 			msgChan := make(chan message.Message, 1)
@@ -12,17 +12,22 @@ import (
 	"encoding/hex"
 	"errors"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/util"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/sirupsen/logrus"
@@ -42,24 +47,37 @@ type Phase struct {
 	handler *Handler
 
 	timeout time.Duration
+	// customTimeout is set when the timeout is overridden through the
+	// CUSTOM_SELECTOR_TIMEOUT env variable, which disables its adaptation.
+	customTimeout bool
 
 	next consensus.Phase
 	keys key.Keys
 
 	g blockgenerator.BlockGenerator
 
+	// cutoff ends the collection of the candidates early, once at least a
+	// valid one has been received. Zero disables it.
+	cutoff time.Duration
+
+	// verifyFn verifies the collected candidates speculatively, so that the
+	// verification is (being) done by the time the reduction votes.
+	verifyFn consensus.CandidateVerificationFunc
+
 	db database.DB
 }
 
 // New creates and launches the component which responsibility is to validate
 // and select a Provisioner to propagate NewBlock message.
-func New(next consensus.Phase, g blockgenerator.BlockGenerator, e *consensus.Emitter, timeout time.Duration, db database.DB) *Phase {
+func New(next consensus.Phase, g blockgenerator.BlockGenerator, e *consensus.Emitter, verifyFn consensus.CandidateVerificationFunc, timeout time.Duration, db database.DB) *Phase {
 	selector := &Phase{
-		Emitter: e,
-		timeout: timeout,
-		keys:    e.Keys,
-		g:       g,
-		db:      db,
+		Emitter:  e,
+		timeout:  timeout,
+		keys:     e.Keys,
+		g:        g,
+		verifyFn: verifyFn,
+		cutoff:   time.Duration(config.Get().Consensus.SelectionCutoffMilli) * time.Millisecond,
+		db:       db,
 
 		next: next,
 	}
@@ -73,6 +91,7 @@ func New(next consensus.Phase, g blockgenerator.BlockGenerator, e *consensus.Emi
 				Info("selector will set a custom timeout")
 
 			selector.timeout = time.Duration(customTimeout) * time.Second
+			selector.customTimeout = true
 		} else {
 			log.
 				WithError(err).
@@ -100,22 +119,31 @@ func (p *Phase) String() string {
 func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, newBlockChan, _ chan message.Message, r consensus.RoundUpdate, step uint8) consensus.PhaseFn {
 	ctx, cancel := context.WithCancel(parentCtx)
 
-	defer func() {
-		p.increaseTimeOut()
-		cancel()
-	}()
+	defer cancel()
+
+	if !p.customTimeout {
+		p.timeout = p.Timeouts.Timeout(p.String(), p.timeout)
+	}
 
 	p.handler = NewHandler(p.Keys, r.P, r.Seed)
 
 	isMember := p.handler.AmMember(r.Round, step)
 
+	p.Journal.SetCommittee(r.Round, step, p.handler.Committees[step].MemberKeys(), isMember)
+
 	if log.GetLevel() >= logrus.DebugLevel {
 		log := consensus.WithFields(r.Round, step, "selection_init",
 			nil, p.Keys.BLSPubKey, &p.handler.Committees[step], nil, &r.P)
 		log.WithField("is_member", isMember).Debug()
 	}
 
-	if isMember {
+	if isMember && p.Observer {
+		lg.WithField("round", r.Round).
+			WithField("step", step).
+			Info("observer mode, candidate block not generated")
+	}
+
+	if isMember && !p.Observer {
 		scr, err := p.g.GenerateCandidateMessage(ctx, r, step)
 		if err != nil {
 			lg.WithError(err).Errorln("candidate block generation failed")
@@ -143,28 +171,69 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, newBlockC
 
 	timeoutChan := time.After(p.timeout)
 
+	// Candidates are collected until every generator of the step has sent
+	// one, or until the cutoff (if any) once a valid candidate is known.
+	var cutoffChan <-chan time.Time
+	if p.cutoff > 0 && p.cutoff < p.timeout {
+		cutoffChan = time.After(p.cutoff)
+	}
+
+	cutoffPassed := false
+	generators := p.handler.Committee(r.Round, step).Set.Len()
+	collected := make([]message.NewBlock, 0, generators)
+
 	for {
 		select {
 		case ev := <-newBlockChan:
 			if shouldProcess(ev, r.Round, step, queue) {
 				b := ev.Payload().(message.NewBlock)
+				if hasGenerator(collected, b.State().PubKeyBLS) {
+					// Only the first candidate of each generator is considered
+					continue
+				}
+
 				if err := p.collectNewBlock(b, ev.Metadata()); err != nil {
 					continue
 				}
 
+				p.verifySpeculatively(parentCtx, b.Candidate)
+
+				collected = append(collected, b)
+				if len(collected) < generators && !cutoffPassed {
+					continue
+				}
+
 				go func() {
 					<-timeoutChan
 				}()
 
-				return p.endSelection(b)
+				return p.endSelection(p.selectCandidate(r.Round, step, collected))
+			}
+		case <-cutoffChan:
+			cutoffChan = nil
+			cutoffPassed = true
+
+			if len(collected) > 0 {
+				go func() {
+					<-timeoutChan
+				}()
+
+				return p.endSelection(p.selectCandidate(r.Round, step, collected))
 			}
 		case <-timeoutChan:
+			if len(collected) > 0 {
+				return p.endSelection(p.selectCandidate(r.Round, step, collected))
+			}
+
 			lg.WithField("event", "timeout").
 				WithField("duration", p.timeout.String()).
 				WithField("round", r.Round).
 				WithField("step", step).
 				Info("")
 
+			p.Journal.SetTimeout(r.Round, step)
+			p.increaseTimeOut()
+
 			return p.endSelection(message.EmptyNewBlock())
 		case <-ctx.Done():
 			// preventing timeout leakage
@@ -176,6 +245,59 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, newBlockC
 	}
 }
 
+// selectCandidate chooses the best of the collected candidates, and records
+// it in the journal along with the alternatives.
+func (p *Phase) selectCandidate(round uint64, step uint8, collected []message.NewBlock) message.NewBlock {
+	rankCandidates(collected, p.handler.Committee(round, step))
+
+	best := collected[0]
+	p.Journal.SetCandidate(round, step, best.State().BlockHash)
+
+	if len(collected) > 1 {
+		alternatives := make([][]byte, 0, len(collected)-1)
+		for _, b := range collected[1:] {
+			alternatives = append(alternatives, b.State().BlockHash)
+		}
+
+		p.Journal.SetAlternatives(round, step, alternatives)
+
+		lg.WithField("round", round).
+			WithField("step", step).
+			WithField("hash", util.StringifyBytes(best.State().BlockHash)).
+			WithField("alternatives", len(alternatives)).
+			Info("candidate selected")
+	}
+
+	return best
+}
+
+// rankCandidates sorts the candidates from the best to the worst. The best
+// candidate is the one of the generator with the most credits in the
+// committee, ties being broken by the lowest block hash, so that every node
+// collecting the same candidates chooses the same one.
+func rankCandidates(candidates []message.NewBlock, c user.VotingCommittee) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci := c.OccurrencesOf(candidates[i].State().PubKeyBLS)
+		cj := c.OccurrencesOf(candidates[j].State().PubKeyBLS)
+
+		if ci != cj {
+			return ci > cj
+		}
+
+		return bytes.Compare(candidates[i].State().BlockHash, candidates[j].State().BlockHash) < 0
+	})
+}
+
+func hasGenerator(candidates []message.NewBlock, pubKeyBLS []byte) bool {
+	for _, b := range candidates {
+		if bytes.Equal(b.State().PubKeyBLS, pubKeyBLS) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *Phase) endSelection(result message.NewBlock) consensus.PhaseFn {
 	return p.next.Initialize(result)
 }
@@ -190,7 +312,7 @@ func (p *Phase) verifyNewBlock(msg message.NewBlock) error {
 
 	// Verify message signagure
 	if err := p.handler.VerifySignature(msg); err != nil {
-		return err
+		return reputation.Blame(reputation.InvalidSignature, err)
 	}
 
 	// Sanity-check the candidate block
@@ -217,6 +339,10 @@ func (p *Phase) collectNewBlock(msg message.NewBlock, metadata *message.Metadata
 			WithField("seed", hex.EncodeToString(p.handler.Seed())).
 			WithError(err).Error("failed to verify newblock")
 
+		if o, ok := reputation.OffenseOf(err); ok {
+			p.Penalize(metadata, o)
+		}
+
 		return err
 	}
 
@@ -245,17 +371,31 @@ func (p *Phase) collectNewBlock(msg message.NewBlock, metadata *message.Metadata
 	return nil
 }
 
+// verifySpeculatively verifies the candidate in the background. The outcome
+// is cached by the verification function, so that the reduction does not
+// wait for a full verification when voting on the candidate.
+func (p *Phase) verifySpeculatively(ctx context.Context, candidate block.Block) {
+	if p.verifyFn == nil {
+		return
+	}
+
+	go func() {
+		if err := p.verifyFn(ctx, candidate); err != nil {
+			lg.WithError(err).
+				WithField("hash", util.StringifyBytes(candidate.Header.Hash)).
+				Debug("speculative candidate verification failed")
+		}
+	}()
+}
+
 // increaseTimeOut increases the timeout after a failed selection.
 func (p *Phase) increaseTimeOut() {
-	p.timeout = p.timeout * 2
-	if p.timeout > 60*time.Second {
-		lg.
-			WithField("timeout", p.timeout).
-			Warn("max_timeout_reached")
-
-		p.timeout = 60 * time.Second
+	if p.customTimeout {
+		return
 	}
 
+	p.timeout = p.Timeouts.Increase(p.String(), p.timeout)
+
 	lg.
 		WithField("timeout", p.timeout).
 		Trace("increase_timeout")
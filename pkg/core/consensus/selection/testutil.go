@@ -14,6 +14,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator/candidate"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	crypto "github.com/dusk-network/dusk-crypto/hash"
@@ -38,6 +39,7 @@ func NewHelper(scoreToSpawn int) *Helper {
 	p, provisionersKeys := consensus.MockProvisioners(ProvisionerNr)
 	emitter := consensus.MockEmitter(time.Second)
 	emitter.Keys = provisionersKeys[0]
+	emitter.Signer = signer.NewInMemory(emitter.Keys)
 
 	hlp := &Helper{
 		Emitter:      emitter,
@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// roundsToKeep is the amount of rounds, before the highest signed one, for
+// which the signed votes are remembered. Votes for older rounds are refused.
+const roundsToKeep = 2
+
+// votePreimageSize is the size of the preimage of a vote signature (round,
+// step and block hash), as marshaled by header.MarshalSignableVote.
+const votePreimageSize = 8 + 1 + 32
+
+type vote struct {
+	topic topics.Topic
+	round uint64
+	step  uint8
+}
+
+// guard is a Signer refusing to sign two different block hashes for the same
+// message topic, round and step, and two different seeds for the same round.
+type guard struct {
+	Signer
+
+	lock    sync.Mutex
+	highest uint64
+	signed  map[vote][]byte
+	seeds   map[uint64][]byte
+}
+
+// NewGuard wraps a Signer so that it never signs two different block hashes
+// for the same message topic, round and step. Signing the same vote again is
+// allowed, as BLS signatures are deterministic.
+//
+// Votes are distinguished by topic, as the protocol makes a provisioner sign
+// both a Reduction and an Agreement for the second reduction step, which may
+// carry different hashes.
+//
+// Seeds are signed with the same key as the votes, so the guard only signs
+// one seed per round, and refuses the seeds shaped as a vote preimage, which
+// would otherwise yield a vote signature bypassing the checks above.
+func NewGuard(s Signer) Signer {
+	return &guard{
+		Signer: s,
+		signed: make(map[vote][]byte),
+		seeds:  make(map[uint64][]byte),
+	}
+}
+
+func (g *guard) Sign(t topics.Topic, h header.Header) ([]byte, error) {
+	if err := g.check(t, h); err != nil {
+		return nil, err
+	}
+
+	return g.Signer.Sign(t, h)
+}
+
+func (g *guard) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	if err := g.checkSeed(round, seed); err != nil {
+		return nil, err
+	}
+
+	return g.Signer.SignSeed(round, seed)
+}
+
+func (g *guard) check(t topics.Topic, h header.Header) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if h.Round+roundsToKeep < g.highest {
+		return fmt.Errorf("%w: round %d is too old", ErrDoubleSign, h.Round)
+	}
+
+	v := vote{topic: t, round: h.Round, step: h.Step}
+
+	if hash, ok := g.signed[v]; ok {
+		if !bytes.Equal(hash, h.BlockHash) {
+			return fmt.Errorf("%w: %s already signed for round %d step %d", ErrDoubleSign, t, h.Round, h.Step)
+		}
+
+		return nil
+	}
+
+	g.signed[v] = append([]byte{}, h.BlockHash...)
+	g.advance(h.Round)
+
+	return nil
+}
+
+func (g *guard) checkSeed(round uint64, seed []byte) error {
+	if len(seed) == votePreimageSize {
+		return fmt.Errorf("%w: seed of round %d is shaped as a vote", ErrDoubleSign, round)
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if round+roundsToKeep < g.highest {
+		return fmt.Errorf("%w: round %d is too old", ErrDoubleSign, round)
+	}
+
+	if s, ok := g.seeds[round]; ok {
+		if !bytes.Equal(s, seed) {
+			return fmt.Errorf("%w: seed already signed for round %d", ErrDoubleSign, round)
+		}
+
+		return nil
+	}
+
+	g.seeds[round] = append([]byte{}, seed...)
+	g.advance(round)
+
+	return nil
+}
+
+// advance forgets the votes and seeds of the rounds too old to be signed,
+// once a higher round is signed.
+func (g *guard) advance(round uint64) {
+	if round <= g.highest {
+		return
+	}
+
+	g.highest = round
+
+	for k := range g.signed {
+		if k.round+roundsToKeep < g.highest {
+			delete(g.signed, k)
+		}
+	}
+
+	for r := range g.seeds {
+		if r+roundsToKeep < g.highest {
+			delete(g.seeds, r)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/tidwall/buntdb"
+)
+
+const protectionPrefix = "signed:"
+
+// ErrProtectionLocked is returned when the slashing-protection database is in
+// use by another process.
+var ErrProtectionLocked = errors.New("slashing-protection database is in use by another process")
+
+// Protection is a slashing-protection database. It persists the highest vote
+// (round, step and block hash) signed for each consensus message topic, so
+// that a node restarted mid-round never signs a vote conflicting with one
+// signed before the restart.
+//
+// The database is locked for the lifetime of the Protection, so that two
+// processes sharing it (e.g. two nodes started with the same keys) can not
+// both sign.
+type Protection struct {
+	db   *buntdb.DB
+	lock *os.File
+}
+
+// OpenProtection opens (or creates) the slashing-protection database at path.
+func OpenProtection(path string) (*Protection, error) {
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = lock.Close()
+
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%w: %s", ErrProtectionLocked, path)
+		}
+
+		return nil, err
+	}
+
+	db, err := buntdb.Open(path)
+	if err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	var config buntdb.Config
+	if err := db.ReadConfig(&config); err != nil {
+		_ = db.Close()
+		_ = lock.Close()
+		return nil, err
+	}
+
+	// A vote must be on disk before its signature leaves the signer.
+	config.SyncPolicy = buntdb.Always
+
+	if err := db.SetConfig(config); err != nil {
+		_ = db.Close()
+		_ = lock.Close()
+		return nil, err
+	}
+
+	return &Protection{db: db, lock: lock}, nil
+}
+
+// Check verifies that signing the vote of a message of topic t can not
+// conflict with a vote signed before, and records it. Votes for a lower round
+// or step than the highest recorded one are refused, as are votes for the
+// same round and step with a different hash.
+func (p *Protection) Check(t topics.Topic, h header.Header) error {
+	key := protectionPrefix + t.String()
+
+	return p.db.Update(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(key)
+
+		switch {
+		case errors.Is(err, buntdb.ErrNotFound):
+		case err != nil:
+			return err
+		default:
+			round, step, hash, err := decodeVote(val)
+			if err != nil {
+				return err
+			}
+
+			if h.Round < round || (h.Round == round && h.Step < step) {
+				return fmt.Errorf("%w: %s already signed for round %d step %d", ErrDoubleSign, t, round, step)
+			}
+
+			if h.Round == round && h.Step == step {
+				if !bytes.Equal(hash, h.BlockHash) {
+					return fmt.Errorf("%w: %s already signed for round %d step %d", ErrDoubleSign, t, round, step)
+				}
+
+				return nil
+			}
+		}
+
+		_, _, err = tx.Set(key, encodeVote(h), nil)
+		return err
+	})
+}
+
+// Close the database and release its lock.
+func (p *Protection) Close() error {
+	err := p.db.Close()
+	_ = p.lock.Close()
+
+	return err
+}
+
+func encodeVote(h header.Header) string {
+	buf := make([]byte, 9, 9+len(h.BlockHash))
+	binary.LittleEndian.PutUint64(buf[:8], h.Round)
+	buf[8] = h.Step
+
+	return string(append(buf, h.BlockHash...))
+}
+
+func decodeVote(val string) (uint64, uint8, []byte, error) {
+	if len(val) < 9 {
+		return 0, 0, nil, errors.New("malformed slashing-protection record")
+	}
+
+	b := []byte(val)
+
+	return binary.LittleEndian.Uint64(b[:8]), b[8], b[9:], nil
+}
+
+type protected struct {
+	Signer
+	p *Protection
+}
+
+// NewProtected wraps a Signer so that every vote is checked against, and
+// recorded into, the slashing-protection database before being signed.
+func NewProtected(s Signer, p *Protection) Signer {
+	return &protected{Signer: s, p: p}
+}
+
+func (s *protected) Sign(t topics.Topic, h header.Header) ([]byte, error) {
+	if err := s.p.Check(t, h); err != nil {
+		return nil, err
+	}
+
+	return s.Signer.Sign(t, h)
+}
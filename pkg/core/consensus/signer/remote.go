@@ -0,0 +1,257 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// serviceName is the name of the JSON-RPC service exposed by a signing
+// daemon.
+const serviceName = "Signer"
+
+// ErrNotUnix is returned when serving a signing daemon on another network
+// than a unix socket.
+var ErrNotUnix = errors.New("signer: only unix sockets are supported")
+
+type (
+	// PublicKeyArgs are the arguments of the PublicKey call.
+	PublicKeyArgs struct{}
+
+	// PublicKeyReply is the reply to the PublicKey call.
+	PublicKeyReply struct {
+		PublicKeyBLS []byte
+	}
+
+	// SignArgs are the arguments of the Sign call.
+	SignArgs struct {
+		Topic     topics.Topic
+		Round     uint64
+		Step      uint8
+		BlockHash []byte
+	}
+
+	// SignSeedArgs are the arguments of the SignSeed call.
+	SignSeedArgs struct {
+		Round uint64
+		Seed  []byte
+	}
+
+	// SignReply is the reply to the Sign and SignSeed calls.
+	SignReply struct {
+		Signature []byte
+	}
+)
+
+// Service exposes a Signer through JSON-RPC. It is run by a signing daemon.
+type Service struct {
+	s Signer
+}
+
+// PublicKey returns the BLS public key of the provisioner.
+func (s *Service) PublicKey(_ PublicKeyArgs, reply *PublicKeyReply) error {
+	reply.PublicKeyBLS = s.s.PublicKeyBLS()
+	return nil
+}
+
+// Sign signs a consensus vote.
+func (s *Service) Sign(args SignArgs, reply *SignReply) error {
+	sig, err := s.s.Sign(args.Topic, header.Header{
+		PubKeyBLS: s.s.PublicKeyBLS(),
+		Round:     args.Round,
+		Step:      args.Step,
+		BlockHash: args.BlockHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	reply.Signature = sig
+	return nil
+}
+
+// SignSeed signs the seed of a round.
+func (s *Service) SignSeed(args SignSeedArgs, reply *SignReply) error {
+	sig, err := s.s.SignSeed(args.Round, args.Seed)
+	if err != nil {
+		return err
+	}
+
+	reply.Signature = sig
+	return nil
+}
+
+// Serve accepts connections on the listener and serves the requests of each
+// connection with s, until the listener is closed. The requests are not
+// authenticated, so only unix listeners are accepted.
+func Serve(l net.Listener, s Signer) error {
+	if _, ok := l.(*net.UnixListener); !ok {
+		return ErrNotUnix
+	}
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(serviceName, &Service{s: s}); err != nil {
+		return err
+	}
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		go srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// Remote is a Signer delegating the signatures to a signing daemon, so that
+// the BLS secret key never enters the node process. The connection is
+// re-established if the daemon restarts.
+//
+// The daemon is only reached through a unix socket, as the protocol does not
+// authenticate its clients: the permissions of the socket restrict who can
+// ask for a signature.
+type Remote struct {
+	address string
+	timeout time.Duration
+
+	lock   sync.Mutex
+	client *rpc.Client
+	pk     []byte
+}
+
+// NewRemote connects to the signing daemon listening on the unix socket at
+// address, and fetches the public key of the provisioner.
+func NewRemote(address string, timeout time.Duration) (*Remote, error) {
+	r := &Remote{
+		address: address,
+		timeout: timeout,
+	}
+
+	var reply PublicKeyReply
+	if err := r.call("PublicKey", PublicKeyArgs{}, &reply); err != nil {
+		return nil, err
+	}
+
+	r.pk = reply.PublicKeyBLS
+	return r, nil
+}
+
+// PublicKeyBLS returns the BLS public key of the provisioner.
+func (r *Remote) PublicKeyBLS() []byte {
+	return r.pk
+}
+
+// Sign asks the daemon to sign a consensus vote.
+func (r *Remote) Sign(t topics.Topic, h header.Header) ([]byte, error) {
+	var reply SignReply
+
+	err := r.call("Sign", SignArgs{
+		Topic:     t,
+		Round:     h.Round,
+		Step:      h.Step,
+		BlockHash: h.BlockHash,
+	}, &reply)
+
+	return reply.Signature, err
+}
+
+// SignSeed asks the daemon to sign the seed of a round.
+func (r *Remote) SignSeed(round uint64, seed []byte) ([]byte, error) {
+	var reply SignReply
+	err := r.call("SignSeed", SignSeedArgs{Round: round, Seed: seed}, &reply)
+
+	return reply.Signature, err
+}
+
+// Close the connection to the daemon.
+func (r *Remote) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.client == nil {
+		return nil
+	}
+
+	err := r.client.Close()
+	r.client = nil
+
+	return err
+}
+
+func (r *Remote) call(method string, args, reply interface{}) error {
+	client, err := r.connect()
+	if err != nil {
+		return err
+	}
+
+	call := client.Go(serviceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+
+	select {
+	case <-call.Done:
+	case <-time.After(r.timeout):
+		// The connection is dropped, so that a late reply can not be
+		// mistaken for the reply to another call.
+		r.reset(client)
+		return fmt.Errorf("signer: %s timed out", method)
+	}
+
+	var serverErr rpc.ServerError
+
+	switch {
+	case call.Error == nil:
+		return nil
+	case errors.As(call.Error, &serverErr):
+		// The error crossed the wire as a string. Restore ErrDoubleSign, so
+		// that callers can tell a refusal from a failure.
+		if strings.HasPrefix(string(serverErr), ErrDoubleSign.Error()) {
+			return fmt.Errorf("%w%s", ErrDoubleSign, strings.TrimPrefix(string(serverErr), ErrDoubleSign.Error()))
+		}
+
+		return serverErr
+	default:
+		r.reset(client)
+		return fmt.Errorf("signer: %s failed: %w", method, call.Error)
+	}
+}
+
+func (r *Remote) connect() (*rpc.Client, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.client != nil {
+		return r.client, nil
+	}
+
+	conn, err := net.DialTimeout("unix", r.address, r.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("signer: could not connect to %s: %w", r.address, err)
+	}
+
+	r.client = jsonrpc.NewClient(conn)
+	return r.client, nil
+}
+
+func (r *Remote) reset(client *rpc.Client) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.client == client {
+		_ = r.client.Close()
+		r.client = nil
+	}
+}
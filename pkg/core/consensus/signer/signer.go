@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package signer abstracts the signing of consensus messages, so that the
+// BLS secret key of a provisioner can be kept out of the node process.
+package signer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// ErrDoubleSign is returned when signing a vote would conflict with a vote
+// signed before.
+var ErrDoubleSign = errors.New("refusing to sign a conflicting vote")
+
+// Signer signs consensus messages on behalf of a provisioner.
+type Signer interface {
+	// PublicKeyBLS returns the BLS public key of the provisioner.
+	PublicKeyBLS() []byte
+
+	// Sign signs the vote (round, step and block hash) carried by the header
+	// of a consensus message of topic t.
+	Sign(t topics.Topic, h header.Header) ([]byte, error)
+
+	// SignSeed signs the seed of the candidate block of a round.
+	SignSeed(round uint64, seed []byte) ([]byte, error)
+}
+
+type inMemory struct {
+	keys key.Keys
+}
+
+// NewInMemory returns a Signer using keys held in process memory.
+func NewInMemory(keys key.Keys) Signer {
+	return &inMemory{keys: keys}
+}
+
+func (s *inMemory) PublicKeyBLS() []byte {
+	return s.keys.BLSPubKey
+}
+
+func (s *inMemory) Sign(_ topics.Topic, h header.Header) ([]byte, error) {
+	preimage := new(bytes.Buffer)
+	if err := header.MarshalSignableVote(preimage, h); err != nil {
+		return nil, err
+	}
+
+	return bls.Sign(s.keys.BLSSecretKey, s.keys.BLSPubKey, preimage.Bytes())
+}
+
+func (s *inMemory) SignSeed(_ uint64, seed []byte) ([]byte, error) {
+	return bls.Sign(s.keys.BLSSecretKey, s.keys.BLSPubKey, seed)
+}
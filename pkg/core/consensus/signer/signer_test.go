@@ -0,0 +1,177 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/stretchr/testify/require"
+)
+
+func mockVote(round uint64, step uint8, hash byte) header.Header {
+	h := make([]byte, 32)
+	h[0] = hash
+
+	return header.Header{Round: round, Step: step, BlockHash: h}
+}
+
+func TestGuard(t *testing.T) {
+	require := require.New(t)
+
+	g := NewGuard(NewInMemory(key.NewRandKeys()))
+
+	_, err := g.Sign(topics.Reduction, mockVote(10, 2, 1))
+	require.NoError(err)
+
+	// Signing the same vote again is harmless
+	_, err = g.Sign(topics.Reduction, mockVote(10, 2, 1))
+	require.NoError(err)
+
+	_, err = g.Sign(topics.Reduction, mockVote(10, 2, 2))
+	require.True(errors.Is(err, ErrDoubleSign))
+
+	// Agreements are tracked apart from reduction votes
+	_, err = g.Sign(topics.Agreement, mockVote(10, 2, 2))
+	require.NoError(err)
+
+	// Old rounds are refused once pruned
+	_, err = g.Sign(topics.Reduction, mockVote(20, 1, 1))
+	require.NoError(err)
+
+	_, err = g.Sign(topics.Reduction, mockVote(10, 3, 1))
+	require.True(errors.Is(err, ErrDoubleSign))
+}
+
+func TestGuardSeed(t *testing.T) {
+	require := require.New(t)
+
+	g := NewGuard(NewInMemory(key.NewRandKeys()))
+
+	seed := make([]byte, 48)
+	seed[0] = 1
+
+	_, err := g.SignSeed(10, seed)
+	require.NoError(err)
+
+	// The same seed is signed at every iteration of a round
+	_, err = g.SignSeed(10, seed)
+	require.NoError(err)
+
+	other := make([]byte, 48)
+	_, err = g.SignSeed(10, other)
+	require.True(errors.Is(err, ErrDoubleSign))
+
+	// A seed can not be used to sign a vote preimage
+	preimage := new(bytes.Buffer)
+	require.NoError(header.MarshalSignableVote(preimage, mockVote(11, 2, 2)))
+
+	_, err = g.SignSeed(11, preimage.Bytes())
+	require.True(errors.Is(err, ErrDoubleSign))
+
+	_, err = g.SignSeed(20, seed)
+	require.NoError(err)
+
+	_, err = g.SignSeed(11, seed)
+	require.True(errors.Is(err, ErrDoubleSign))
+}
+
+func TestRemote(t *testing.T) {
+	require := require.New(t)
+
+	keys := key.NewRandKeys()
+	addr := filepath.Join(t.TempDir(), "signer.sock")
+
+	// The requests are not authenticated, so that TCP is refused
+	tl, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	require.ErrorIs(Serve(tl, NewInMemory(keys)), ErrNotUnix)
+	require.NoError(tl.Close())
+
+	l, err := net.Listen("unix", addr)
+	require.NoError(err)
+
+	defer l.Close()
+
+	go func() {
+		_ = Serve(l, NewGuard(NewInMemory(keys)))
+	}()
+
+	r, err := NewRemote(addr, time.Second)
+	require.NoError(err)
+
+	defer r.Close()
+
+	require.Equal(keys.BLSPubKey, r.PublicKeyBLS())
+
+	// The remote signature matches the in memory one
+	h := mockVote(1, 2, 1)
+	sig, err := r.Sign(topics.Reduction, h)
+	require.NoError(err)
+
+	expected, err := NewInMemory(keys).Sign(topics.Reduction, h)
+	require.NoError(err)
+	require.Equal(expected, sig)
+
+	seed, err := r.SignSeed(1, []byte{1, 2, 3})
+	require.NoError(err)
+
+	apk, err := bls.CreateApk(keys.BLSPubKey)
+	require.NoError(err)
+	require.NoError(bls.Verify(apk, seed, []byte{1, 2, 3}))
+
+	// Refusals of the daemon are reported as ErrDoubleSign
+	_, err = r.Sign(topics.Reduction, mockVote(1, 2, 2))
+	require.True(errors.Is(err, ErrDoubleSign))
+}
+
+func TestProtection(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "consensus.keys.protection")
+
+	p, err := OpenProtection(path)
+	require.NoError(err)
+
+	// A second process can not use the same database
+	_, err = OpenProtection(path)
+	require.True(errors.Is(err, ErrProtectionLocked))
+
+	s := NewProtected(NewInMemory(key.NewRandKeys()), p)
+
+	_, err = s.Sign(topics.Reduction, mockVote(10, 5, 1))
+	require.NoError(err)
+
+	_, err = s.Sign(topics.Reduction, mockVote(10, 5, 1))
+	require.NoError(err)
+
+	// Messages of other topics are protected separately
+	_, err = s.Sign(topics.Agreement, mockVote(10, 3, 1))
+	require.NoError(err)
+
+	require.NoError(p.Close())
+
+	// Votes survive a restart
+	p, err = OpenProtection(path)
+	require.NoError(err)
+
+	defer p.Close()
+
+	require.True(errors.Is(p.Check(topics.Reduction, mockVote(10, 5, 2)), ErrDoubleSign))
+	require.True(errors.Is(p.Check(topics.Reduction, mockVote(10, 2, 1)), ErrDoubleSign))
+	require.True(errors.Is(p.Check(topics.Reduction, mockVote(9, 6, 1)), ErrDoubleSign))
+	require.NoError(p.Check(topics.Reduction, mockVote(10, 6, 2)))
+	require.NoError(p.Check(topics.Agreement, mockVote(10, 6, 2)))
+}
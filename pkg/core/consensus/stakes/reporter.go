@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package stakes
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "consensus").WithField("actor", "stakes")
+
+// Reporter computes the stake events of each accepted block, out of the
+// provisioners snapshots stored by the chain, and stores them in the database.
+// As the events of a height replace those above, the events of a reverted
+// branch are discarded when the chain falls back.
+type Reporter struct {
+	db database.DB
+
+	eventBus          *eventbus.EventBus
+	acceptedBlockChan <-chan block.Block
+	acceptedBlockID   uint32
+}
+
+// NewReporter returns a Reporter listening to the blocks accepted on the
+// EventBus.
+func NewReporter(eventBus *eventbus.EventBus, db database.DB) *Reporter {
+	acceptedBlockChan, id := consensus.InitAcceptedBlockUpdate(eventBus)
+
+	return &Reporter{
+		db:                db,
+		eventBus:          eventBus,
+		acceptedBlockChan: acceptedBlockChan,
+		acceptedBlockID:   id,
+	}
+}
+
+// Run the Reporter until the context is canceled.
+func (r *Reporter) Run(ctx context.Context) {
+	go func() {
+		defer r.eventBus.Unsubscribe(topics.AcceptedBlock, r.acceptedBlockID)
+
+		for {
+			select {
+			case b := <-r.acceptedBlockChan:
+				if _, err := r.Report(b.Header.Height); err != nil {
+					lg.WithError(err).WithField("height", b.Header.Height).Warn("could not report stake events")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Report computes and stores the stake events caused by the block at height.
+func (r *Reporter) Report(height uint64) ([]user.StakeEvent, error) {
+	if height == 0 {
+		return nil, nil
+	}
+
+	var prev, next *user.Provisioners
+
+	if err := r.db.View(func(t database.Transaction) error {
+		var err error
+		if prev, err = t.FetchProvisioners(height - 1); err != nil {
+			return err
+		}
+
+		next, err = t.FetchProvisioners(height)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	events := Diff(height, *prev, *next)
+
+	if err := r.db.Update(func(t database.Transaction) error {
+		return t.StoreStakeEvents(height, events)
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		lg.WithField("height", height).
+			WithField("provisioner", util.StringifyBytes(e.PublicKeyBLS)).
+			WithField("previous", e.Previous).
+			WithField("status", e.Status).
+			WithField("reward_delta", e.RewardDelta).
+			Info("stake updated")
+	}
+
+	if IsEpochBoundary(height) {
+		lg.WithField("height", height).
+			WithField("epoch", Epoch(height)).
+			WithField("provisioners", next.Set.Len()).
+			Info("epoch started")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,158 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package stakes
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+)
+
+// Epoch returns the epoch of a height. An epoch starts at every multiple of
+// config.EPOCH, when the provisioners are updated.
+func Epoch(height uint64) uint64 {
+	return height / config.EPOCH
+}
+
+// IsEpochBoundary returns whether a height starts an epoch.
+func IsEpochBoundary(height uint64) bool {
+	return height%config.EPOCH == 0
+}
+
+// Diff returns the stake events caused by the block at height, given the
+// provisioners in effect before (prev) and after (next) it.
+//
+// An event is reported for each stake which changed status, value or reward.
+// The stakes of a provisioner are matched by position, as there is one stake
+// per provisioner at the moment. A stake which is no longer in the
+// provisioners is reported as expired.
+func Diff(height uint64, prev, next user.Provisioners) []user.StakeEvent {
+	events := make([]user.StakeEvent, 0)
+
+	var prevRound uint64
+	if height > 0 {
+		prevRound = height - 1
+	}
+
+	for i := 0; i < next.Set.Len(); i++ {
+		m, err := next.MemberAt(i)
+		if err != nil || m == nil {
+			continue
+		}
+
+		pm := prev.GetMember(m.PublicKeyBLS)
+
+		for j, s := range m.Stakes {
+			e := newEvent(height, m.PublicKeyBLS, s)
+			e.Status = user.StakeStatusAt(s, height)
+			e.RewardDelta = int64(s.Reward)
+
+			if pm != nil && j < len(pm.Stakes) {
+				ps := pm.Stakes[j]
+				e.Previous = user.StakeStatusAt(ps, prevRound)
+				e.RewardDelta = int64(s.Reward) - int64(ps.Reward)
+
+				if e.Previous == e.Status && e.RewardDelta == 0 && ps.Value == s.Value {
+					continue
+				}
+			}
+
+			events = append(events, e)
+		}
+	}
+
+	for i := 0; i < prev.Set.Len(); i++ {
+		pm, err := prev.MemberAt(i)
+		if err != nil || pm == nil {
+			continue
+		}
+
+		var stakes int
+		if m := next.GetMember(pm.PublicKeyBLS); m != nil {
+			stakes = len(m.Stakes)
+		}
+
+		for j := stakes; j < len(pm.Stakes); j++ {
+			e := newEvent(height, pm.PublicKeyBLS, pm.Stakes[j])
+			e.Previous = user.StakeStatusAt(pm.Stakes[j], prevRound)
+			e.Status = user.StakeExpired
+
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
+
+func newEvent(height uint64, pubKeyBLS []byte, s user.Stake) user.StakeEvent {
+	return user.StakeEvent{
+		Height:       height,
+		Epoch:        Epoch(height),
+		PublicKeyBLS: pubKeyBLS,
+		Value:        s.Value,
+		Eligibility:  s.Eligibility,
+		Reward:       s.Reward,
+	}
+}
+
+// EpochReward is the reward accrued by a provisioner during an epoch.
+type EpochReward struct {
+	PublicKeyBLS []byte `json:"bls_key"`
+	Reward       int64  `json:"reward"`
+}
+
+// EpochReport summarizes the stake events of an epoch.
+type EpochReport struct {
+	Epoch       uint64            `json:"epoch"`
+	StartHeight uint64            `json:"start_height"`
+	EndHeight   uint64            `json:"end_height"`
+	Rewards     []EpochReward     `json:"rewards"`
+	Events      []user.StakeEvent `json:"events"`
+}
+
+// EpochBounds returns the first and the last height of an epoch.
+func EpochBounds(epoch uint64) (uint64, uint64) {
+	start := epoch * config.EPOCH
+	return start, start + config.EPOCH - 1
+}
+
+// NewEpochReport builds the EpochReport of an epoch out of its stake events.
+// The rewards are ordered by key.
+func NewEpochReport(epoch uint64, events []user.StakeEvent) EpochReport {
+	start, end := EpochBounds(epoch)
+
+	r := EpochReport{
+		Epoch:       epoch,
+		StartHeight: start,
+		EndHeight:   end,
+		Rewards:     make([]EpochReward, 0),
+		Events:      make([]user.StakeEvent, 0),
+	}
+
+	rewards := make(map[string]int64)
+
+	for _, e := range events {
+		if e.Height < start || e.Height > end {
+			continue
+		}
+
+		r.Events = append(r.Events, e)
+		rewards[string(e.PublicKeyBLS)] += e.RewardDelta
+	}
+
+	for k, v := range rewards {
+		r.Rewards = append(r.Rewards, EpochReward{PublicKeyBLS: []byte(k), Reward: v})
+	}
+
+	sort.Slice(r.Rewards, func(i, j int) bool {
+		return bytes.Compare(r.Rewards[i].PublicKeyBLS, r.Rewards[j].PublicKeyBLS) < 0
+	})
+
+	return r
+}
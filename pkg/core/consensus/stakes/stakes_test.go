@@ -0,0 +1,134 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package stakes
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+func key(i byte) []byte {
+	pk := make([]byte, user.BlsKeySize)
+	pk[0] = i
+
+	return pk
+}
+
+func mockProvisioners(stakes map[byte]user.Stake) user.Provisioners {
+	p := user.NewProvisioners()
+
+	for i, s := range stakes {
+		p.Set.Insert(key(i))
+		p.Members[string(key(i))] = &user.Member{
+			PublicKeyBLS: key(i),
+			Stakes:       []user.Stake{s},
+		}
+	}
+
+	return *p
+}
+
+func TestDiff(t *testing.T) {
+	prev := mockProvisioners(map[byte]user.Stake{
+		1: {Value: 10, Eligibility: 5},
+		2: {Value: 10, Eligibility: 1, Reward: 3},
+		3: {Value: 10, Eligibility: 1},
+		4: {Value: 10, Eligibility: 1},
+	})
+
+	next := mockProvisioners(map[byte]user.Stake{
+		1: {Value: 10, Eligibility: 5},
+		2: {Value: 10, Eligibility: 1, Reward: 5},
+		4: {Value: 10, Eligibility: 1},
+		5: {Value: 20, Eligibility: 10},
+	})
+
+	events := Diff(5, prev, next)
+	require.Len(t, events, 4)
+
+	// The stake of 1 became eligible at height 5
+	require.Equal(t, key(1), events[0].PublicKeyBLS)
+	require.Equal(t, user.StakePending, events[0].Previous)
+	require.Equal(t, user.StakeEligible, events[0].Status)
+
+	// The stake of 2 accrued a reward
+	require.Equal(t, key(2), events[1].PublicKeyBLS)
+	require.Equal(t, user.StakeEligible, events[1].Status)
+	require.Equal(t, int64(2), events[1].RewardDelta)
+
+	// The stake of 5 was added
+	require.Equal(t, key(5), events[2].PublicKeyBLS)
+	require.Equal(t, user.StakeNone, events[2].Previous)
+	require.Equal(t, user.StakePending, events[2].Status)
+
+	// The stake of 3 was removed
+	require.Equal(t, key(3), events[3].PublicKeyBLS)
+	require.Equal(t, user.StakeEligible, events[3].Previous)
+	require.Equal(t, user.StakeExpired, events[3].Status)
+
+	require.Empty(t, Diff(6, next, next))
+}
+
+func TestEpochReport(t *testing.T) {
+	start, end := EpochBounds(1)
+	require.Equal(t, uint64(config.EPOCH), start)
+	require.Equal(t, uint64(2*config.EPOCH-1), end)
+	require.True(t, IsEpochBoundary(start))
+	require.Equal(t, uint64(1), Epoch(end))
+
+	events := []user.StakeEvent{
+		{Height: start - 1, PublicKeyBLS: key(1), RewardDelta: 100},
+		{Height: start, PublicKeyBLS: key(2), RewardDelta: 1},
+		{Height: start + 1, PublicKeyBLS: key(1), RewardDelta: 2},
+		{Height: end, PublicKeyBLS: key(1), RewardDelta: 3},
+		{Height: end + 1, PublicKeyBLS: key(2), RewardDelta: 100},
+	}
+
+	r := NewEpochReport(1, events)
+	require.Len(t, r.Events, 3)
+	require.Equal(t, []EpochReward{{key(1), 5}, {key(2), 1}}, r.Rewards)
+}
+
+func TestReporter(t *testing.T) {
+	_, db := lite.CreateDBConnection()
+
+	prev := mockProvisioners(map[byte]user.Stake{1: {Value: 10, Eligibility: 2}})
+	next := mockProvisioners(map[byte]user.Stake{1: {Value: 10, Eligibility: 2, Reward: 1}})
+
+	require.NoError(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreProvisioners(0, &prev); err != nil {
+			return err
+		}
+
+		return t.StoreProvisioners(2, &next)
+	}))
+
+	r := NewReporter(eventbus.New(), db)
+
+	events, err := r.Report(1)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	events, err = r.Report(2)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, user.StakeEligible, events[0].Status)
+
+	var stored []user.StakeEvent
+
+	require.NoError(t, db.View(func(t database.Transaction) error {
+		stored, err = t.FetchStakeEvents(0, 2)
+		return err
+	}))
+	require.Equal(t, events, stored)
+}
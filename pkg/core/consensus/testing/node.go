@@ -14,6 +14,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
@@ -44,6 +45,7 @@ func newNode(ctx context.Context, assert *assert.Assertions, eb *eventbus.EventB
 		RPCBus:      rb,
 		Keys:        BLSKeys,
 		TimerLength: 5 * time.Second,
+		Signer:      signer.NewInMemory(BLSKeys),
 	}
 	lp := loop.New(e)
 
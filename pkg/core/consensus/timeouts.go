@@ -0,0 +1,156 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMaxTimeOut is the upper bound of a step timeout, unless configured
+// otherwise.
+const DefaultMaxTimeOut = 60 * time.Second
+
+// Timeouts keeps track of the timeout of each consensus phase across rounds.
+// The timeout of a phase doubles each time the phase times out (up to a
+// maximum), and all timeouts shrink by a quarter (down to a minimum) after
+// each round reaching consensus within its first iteration.
+//
+// It is safe for concurrent use. A nil Timeouts leaves the timeout management
+// to the phases, which double their timeout up to DefaultMaxTimeOut.
+type Timeouts struct {
+	lock     sync.RWMutex
+	base     time.Duration
+	min      time.Duration
+	max      time.Duration
+	timeouts map[string]time.Duration
+}
+
+// TimeoutsStatus is a snapshot of the current state of the Timeouts. Phases
+// missing from the Timeouts map have not run yet, and start with the Base
+// timeout.
+type TimeoutsStatus struct {
+	Base     time.Duration
+	Min      time.Duration
+	Max      time.Duration
+	Timeouts map[string]time.Duration
+}
+
+// NewTimeouts creates Timeouts starting from base, and bounded by min and
+// max. A zero min defaults to base, and a zero max to DefaultMaxTimeOut.
+func NewTimeouts(base, min, max time.Duration) *Timeouts {
+	if min <= 0 {
+		min = base
+	}
+
+	if max <= 0 {
+		max = DefaultMaxTimeOut
+	}
+
+	if max < min {
+		max = min
+	}
+
+	return &Timeouts{
+		base:     clamp(base, min, max),
+		min:      min,
+		max:      max,
+		timeouts: make(map[string]time.Duration),
+	}
+}
+
+// Timeout returns the current timeout of a phase, which becomes known to the
+// Timeouts. If t is nil, fallback is returned.
+func (t *Timeouts) Timeout(phase string, fallback time.Duration) time.Duration {
+	if t == nil {
+		return fallback
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	d := t.get(phase)
+	t.timeouts[phase] = d
+
+	return d
+}
+
+// Increase doubles the timeout of a phase and returns it. If t is nil, the
+// doubled current timeout is returned instead.
+func (t *Timeouts) Increase(phase string, current time.Duration) time.Duration {
+	if t == nil {
+		return clamp(current*2, current, DefaultMaxTimeOut)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	d := clamp(t.get(phase)*2, t.min, t.max)
+	t.timeouts[phase] = d
+
+	return d
+}
+
+// Decrease shrinks the timeouts of all known phases by a quarter, without
+// going below the minimum, be they at the base timeout or not. It should be
+// called after a fast successful round.
+func (t *Timeouts) Decrease() {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for phase := range t.timeouts {
+		d := t.get(phase)
+		t.timeouts[phase] = clamp(d-d/4, t.min, t.max)
+	}
+}
+
+// Status returns a snapshot of the current timeouts.
+func (t *Timeouts) Status() TimeoutsStatus {
+	if t == nil {
+		return TimeoutsStatus{Timeouts: make(map[string]time.Duration)}
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	s := TimeoutsStatus{
+		Base:     t.base,
+		Min:      t.min,
+		Max:      t.max,
+		Timeouts: make(map[string]time.Duration, len(t.timeouts)),
+	}
+
+	for phase, d := range t.timeouts {
+		s.Timeouts[phase] = d
+	}
+
+	return s
+}
+
+func (t *Timeouts) get(phase string) time.Duration {
+	if d, ok := t.timeouts[phase]; ok {
+		return d
+	}
+
+	return t.base
+}
+
+func clamp(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+
+	if d > max {
+		return max
+	}
+
+	return d
+}
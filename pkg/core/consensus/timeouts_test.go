@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutsAdaptation(t *testing.T) {
+	require := require.New(t)
+
+	to := NewTimeouts(4*time.Second, 2*time.Second, 20*time.Second)
+	require.Equal(4*time.Second, to.Timeout("selection", time.Second))
+
+	// a timeout doubles the timeout of its phase only
+	require.Equal(8*time.Second, to.Increase("selection", 0))
+	require.Equal(16*time.Second, to.Increase("selection", 0))
+	require.Equal(20*time.Second, to.Increase("selection", 0))
+	require.Equal(4*time.Second, to.Timeout("reduction", 0))
+
+	// fast rounds shrink the timeouts toward the floor, including the ones
+	// which never timed out
+	to.Decrease()
+	require.Equal(15*time.Second, to.Timeout("selection", 0))
+	require.Equal(3*time.Second, to.Timeout("reduction", 0))
+
+	for i := 0; i < 10; i++ {
+		to.Decrease()
+	}
+
+	require.Equal(2*time.Second, to.Timeout("selection", 0))
+
+	s := to.Status()
+	require.Equal(4*time.Second, s.Base)
+	require.Equal(2*time.Second, s.Min)
+	require.Equal(20*time.Second, s.Max)
+	require.Equal(map[string]time.Duration{"selection": 2 * time.Second, "reduction": 2 * time.Second}, s.Timeouts)
+}
+
+func TestTimeoutsDefaults(t *testing.T) {
+	require := require.New(t)
+
+	to := NewTimeouts(5*time.Second, 0, 0)
+	require.Equal(5*time.Second, to.Status().Min)
+	require.Equal(DefaultMaxTimeOut, to.Status().Max)
+
+	to.Increase("selection", 0)
+
+	for i := 0; i < 3; i++ {
+		to.Decrease()
+	}
+
+	require.Equal(5*time.Second, to.Timeout("selection", 0))
+
+	// a nil Timeouts doubles the current timeout up to DefaultMaxTimeOut
+	var nilTimeouts *Timeouts
+	require.Equal(time.Second, nilTimeouts.Timeout("selection", time.Second))
+	require.Equal(2*time.Second, nilTimeouts.Increase("selection", time.Second))
+	require.Equal(DefaultMaxTimeOut, nilTimeouts.Increase("selection", 40*time.Second))
+	require.Equal(DefaultMaxTimeOut, nilTimeouts.Increase("selection", 90*time.Second))
+	nilTimeouts.Decrease()
+}
@@ -0,0 +1,193 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package user
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+)
+
+// DefaultCommitteeCacheSize is the amount of committees retained by the
+// committee cache shared by the node. It comfortably covers the committees
+// pre-generated for a round, plus the ones extracted to verify certificates
+// of a few rounds back.
+const DefaultCommitteeCacheSize = 1024
+
+// committees caches the committees extracted by CreateVotingCommittee, so that
+// the consensus phases, the certificate verification and the chain checks
+// share the result of the sortition.
+var committees = NewCommitteeCache(DefaultCommitteeCacheSize)
+
+type committeeKey struct {
+	seed         string
+	round        uint64
+	step         uint8
+	size         int
+	provisioners [sha256.Size]byte
+}
+
+type committeeEntry struct {
+	key       committeeKey
+	committee VotingCommittee
+}
+
+// CommitteeCacheStats is a snapshot of the counters of a CommitteeCache.
+type CommitteeCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+	Capacity  int
+}
+
+// HitRate returns the ratio of lookups served by the cache.
+func (s CommitteeCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+// CommitteeCache is a bounded LRU cache of voting committees, keyed by the
+// sortition parameters and by a hash of the provisioner set the committee is
+// extracted from.
+//
+// It is safe for concurrent use.
+type CommitteeCache struct {
+	lock     sync.Mutex
+	capacity int
+	entries  map[committeeKey]*list.Element
+	lru      *list.List
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// NewCommitteeCache creates a CommitteeCache retaining up to capacity
+// committees. A non-positive capacity defaults to DefaultCommitteeCacheSize.
+func NewCommitteeCache(capacity int) *CommitteeCache {
+	if capacity <= 0 {
+		capacity = DefaultCommitteeCacheSize
+	}
+
+	return &CommitteeCache{
+		capacity: capacity,
+		entries:  make(map[committeeKey]*list.Element, capacity),
+		lru:      list.New(),
+	}
+}
+
+// Get returns the committee extracted from p for the given sortition
+// parameters, running the sortition only if the committee is not cached.
+func (c *CommitteeCache) Get(p Provisioners, seed []byte, round uint64, step uint8, size int) VotingCommittee {
+	key := committeeKey{
+		seed:         string(seed),
+		round:        round,
+		step:         step,
+		size:         size,
+		provisioners: p.hash(),
+	}
+
+	c.lock.Lock()
+
+	if e, ok := c.entries[key]; ok {
+		c.hits++
+		c.lru.MoveToFront(e)
+		committee := e.Value.(*committeeEntry).committee
+		c.lock.Unlock()
+
+		return committee.copy()
+	}
+
+	c.misses++
+	c.lock.Unlock()
+
+	// The sortition runs outside the lock, as it is by far the most
+	// expensive part. Concurrent misses on the same key compute the same
+	// committee, hence only the first one is stored.
+	committee := p.createVotingCommittee(seed, round, step, size)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = c.lru.PushFront(&committeeEntry{key: key, committee: committee.copy()})
+
+		for c.lru.Len() > c.capacity {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.entries, oldest.Value.(*committeeEntry).key)
+			c.evictions++
+		}
+	}
+
+	return committee
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *CommitteeCache) Stats() CommitteeCacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return CommitteeCacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Len:       c.lru.Len(),
+		Capacity:  c.capacity,
+	}
+}
+
+// CommitteeCacheStatus returns the counters of the committee cache shared by
+// the node.
+func CommitteeCacheStatus() CommitteeCacheStats {
+	return committees.Stats()
+}
+
+// hash returns a digest of everything in the provisioner set which affects
+// the sortition, that is the ordered BLS keys and the value and eligibility
+// of their stakes.
+func (p Provisioners) hash() [sha256.Size]byte {
+	h := sha256.New()
+	buf := make([]byte, 8)
+
+	for _, pk := range p.Set {
+		m := p.GetMember(pk.Bytes())
+		if m == nil {
+			continue
+		}
+
+		_, _ = h.Write(m.PublicKeyBLS)
+
+		binary.LittleEndian.PutUint64(buf, uint64(len(m.Stakes)))
+		_, _ = h.Write(buf)
+
+		for _, s := range m.Stakes {
+			binary.LittleEndian.PutUint64(buf, s.Value)
+			_, _ = h.Write(buf)
+			binary.LittleEndian.PutUint64(buf, s.Eligibility)
+			_, _ = h.Write(buf)
+		}
+	}
+
+	var digest [sha256.Size]byte
+
+	copy(digest[:], h.Sum(nil))
+
+	return digest
+}
+
+// copy returns a deep copy of the committee, so that cached committees are
+// not affected by callers mutating the returned ones.
+func (v VotingCommittee) copy() VotingCommittee {
+	return VotingCommittee{Cluster: v.Cluster.Copy()}
+}
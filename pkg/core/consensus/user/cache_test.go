@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package user_test
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitteeCache(t *testing.T) {
+	require := require.New(t)
+
+	p, _ := consensus.MockProvisioners(10)
+	c := user.NewCommitteeCache(2)
+	seed := []byte{1, 2, 3}
+
+	first := c.Get(*p, seed, 1, 2, 64)
+	second := c.Get(*p, seed, 1, 2, 64)
+	require.True(first.Equal(&second))
+
+	s := c.Stats()
+	require.Equal(uint64(1), s.Hits)
+	require.Equal(uint64(1), s.Misses)
+	require.Equal(0.5, s.HitRate())
+
+	// Mutating a returned committee does not affect the cached one
+	second.RemoveAll(second.Set[0].Bytes())
+	third := c.Get(*p, seed, 1, 2, 64)
+	require.True(first.Equal(&third))
+	require.Equal(64, third.Size())
+
+	// A change in the provisioner set is a miss
+	k := key.NewRandKeys()
+	require.NoError(p.Add(k.BLSPubKey, 1000, 0, 0, 0))
+	c.Get(*p, seed, 1, 2, 64)
+	require.Equal(uint64(2), c.Stats().Misses)
+
+	// The oldest committee is evicted once the capacity is exceeded
+	c.Get(*p, seed, 1, 3, 64)
+	s = c.Stats()
+	require.Equal(uint64(1), s.Evictions)
+	require.Equal(2, s.Len)
+	require.Equal(2, s.Capacity)
+}
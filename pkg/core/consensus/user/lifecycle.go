@@ -0,0 +1,204 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package user
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+)
+
+// StakeStatus is the state of a Stake in its lifecycle.
+type StakeStatus uint8
+
+const (
+	// StakeNone is the status of a Stake which is not in the provisioners.
+	StakeNone StakeStatus = iota
+	// StakePending is the status of a Stake which is not yet eligible.
+	StakePending
+	// StakeEligible is the status of a Stake which takes part in the
+	// sortition.
+	StakeEligible
+	// StakeExpired is the status of a Stake removed from the provisioners.
+	StakeExpired
+)
+
+var stakeStatusNames = [...]string{"none", "pending", "eligible", "expired"}
+
+// String implements fmt.Stringer.
+func (s StakeStatus) String() string {
+	if int(s) < len(stakeStatusNames) {
+		return stakeStatusNames[s]
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(s))
+}
+
+// MarshalText implements encoding.TextMarshaler, so that the status is
+// reported by name.
+func (s StakeStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *StakeStatus) UnmarshalText(text []byte) error {
+	for i, name := range stakeStatusNames {
+		if name == string(text) {
+			*s = StakeStatus(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown stake status %q", text)
+}
+
+// StakeEvent records a change of a Stake of a provisioner, caused by the
+// block at Height.
+type StakeEvent struct {
+	Height       uint64      `json:"height"`
+	Epoch        uint64      `json:"epoch"`
+	PublicKeyBLS []byte      `json:"bls_key"`
+	Value        uint64      `json:"value"`
+	Eligibility  uint64      `json:"eligibility"`
+	Previous     StakeStatus `json:"previous"`
+	Status       StakeStatus `json:"status"`
+	Reward       uint64      `json:"reward"`
+	RewardDelta  int64       `json:"reward_delta"`
+}
+
+// StakeStatusAt returns the status of a Stake of the provisioners at a given
+// round.
+func StakeStatusAt(stake Stake, round uint64) StakeStatus {
+	if round >= stake.Eligibility {
+		return StakeEligible
+	}
+
+	return StakePending
+}
+
+// MarshalStakeEvents marshals a list of StakeEvent into a buffer.
+func MarshalStakeEvents(r *bytes.Buffer, events []StakeEvent) error {
+	if err := encoding.WriteVarInt(r, uint64(len(events))); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := marshalStakeEvent(r, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func marshalStakeEvent(r *bytes.Buffer, e StakeEvent) error {
+	if err := encoding.WriteUint64LE(r, e.Height); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, e.Epoch); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteVarBytes(r, e.PublicKeyBLS); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, e.Value); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, e.Eligibility); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(r, uint8(e.Previous)); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint8(r, uint8(e.Status)); err != nil {
+		return err
+	}
+
+	if err := encoding.WriteUint64LE(r, e.Reward); err != nil {
+		return err
+	}
+
+	return encoding.WriteUint64LE(r, uint64(e.RewardDelta))
+}
+
+// UnmarshalStakeEvents unmarshals a list of StakeEvent from a buffer.
+func UnmarshalStakeEvents(r *bytes.Buffer) ([]StakeEvent, error) {
+	l, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]StakeEvent, 0, l)
+
+	for i := uint64(0); i < l; i++ {
+		e, err := unmarshalStakeEvent(r)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func unmarshalStakeEvent(r *bytes.Buffer) (StakeEvent, error) {
+	var (
+		e                StakeEvent
+		previous, status uint8
+		delta            uint64
+	)
+
+	if err := encoding.ReadUint64LE(r, &e.Height); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &e.Epoch); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadVarBytes(r, &e.PublicKeyBLS); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &e.Value); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &e.Eligibility); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint8(r, &previous); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint8(r, &status); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &e.Reward); err != nil {
+		return StakeEvent{}, err
+	}
+
+	if err := encoding.ReadUint64LE(r, &delta); err != nil {
+		return StakeEvent{}, err
+	}
+
+	e.Previous = StakeStatus(previous)
+	e.Status = StakeStatus(status)
+	e.RewardDelta = int64(delta)
+
+	return e, nil
+}
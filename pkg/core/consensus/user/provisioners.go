@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/dusk-network/bls12_381-sign/go/cgo/bls"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
@@ -238,14 +239,22 @@ func (p Provisioners) GetRawPublicKeyBLS(pubKeyBLS []byte) []byte {
 	return m.RawPublicKeyBLS
 }
 
-// MarshalProvisioners ...
+// MarshalProvisioners marshals the provisioners into a buffer. The members
+// are ordered by key, so that equal sets are marshaled into equal bytes.
 func MarshalProvisioners(r *bytes.Buffer, p *Provisioners) error {
 	if err := encoding.WriteVarInt(r, uint64(len(p.Members))); err != nil {
 		return err
 	}
 
-	for _, member := range p.Members {
-		if err := marshalMember(r, *member); err != nil {
+	keys := make([]string, 0, len(p.Members))
+	for k := range p.Members {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := marshalMember(r, *p.Members[k]); err != nil {
 			return err
 		}
 	}
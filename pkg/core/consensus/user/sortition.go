@@ -97,8 +97,15 @@ func generateSortitionScore(hash []byte, W *big.Int) uint64 {
 
 // CreateVotingCommittee executes the Deterministic Sortition algorithm
 // to determine the committee members for a given step and round.
-// TODO: running this with weird setup causes infinite looping (to reproduce, hardcode `3` on MockProvisioners when calling agreement.NewHelper in the agreement tests).
+// Committees are cached, so that repeated extractions for the same step and
+// provisioner set only run the sortition once.
 func (p Provisioners) CreateVotingCommittee(seed []byte, round uint64, step uint8, size int) VotingCommittee {
+	return committees.Get(p, seed, round, step, size)
+}
+
+// createVotingCommittee runs the sortition, bypassing the committee cache.
+// TODO: running this with weird setup causes infinite looping (to reproduce, hardcode `3` on MockProvisioners when calling agreement.NewHelper in the agreement tests).
+func (p Provisioners) createVotingCommittee(seed []byte, round uint64, step uint8, size int) VotingCommittee {
 	votingCommittee := newCommittee()
 	W := new(big.Int).SetUint64(p.TotalWeight())
 
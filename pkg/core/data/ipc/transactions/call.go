@@ -8,6 +8,7 @@ package transactions
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 
@@ -45,6 +46,10 @@ func UnmarshalCall(r *bytes.Buffer, c *Call) error {
 		return err
 	}
 
+	if lenFnName > uint64(r.Len()) {
+		return fmt.Errorf("attempting to decode function name which is too large %d", lenFnName)
+	}
+
 	c.FnName = make([]byte, lenFnName)
 	if _, err := io.ReadFull(r, c.FnName); err != nil {
 		return err
@@ -8,6 +8,7 @@ package transactions
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 )
@@ -55,7 +56,7 @@ func UnmarshalCrossover(r *bytes.Buffer, f *Crossover) error {
 		return err
 	}
 
-	if _, err := r.Read(f.EncryptedData); err != nil {
+	if _, err := io.ReadFull(r, f.EncryptedData); err != nil {
 		return err
 	}
 
@@ -8,6 +8,7 @@ package transactions
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/sirupsen/logrus"
@@ -15,6 +16,9 @@ import (
 
 // Note types.
 const (
+	// noteSize is the size of an encoded Note.
+	noteSize = 1 + 32 + 32 + 64 + 8 + 96
+
 	NoteTypeTransparent uint8 = 0
 	NoteTypeObfuscated  uint8 = 1
 )
@@ -92,7 +96,7 @@ func UnmarshalNote(r *bytes.Buffer, f *Note) (err error) {
 		return err
 	}
 
-	if _, err := r.Read(f.EncryptedData); err != nil {
+	if _, err := io.ReadFull(r, f.EncryptedData); err != nil {
 		return err
 	}
 
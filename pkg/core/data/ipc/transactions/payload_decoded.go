@@ -9,6 +9,7 @@ package transactions
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
 
 	"golang.org/x/crypto/blake2b"
@@ -143,6 +144,10 @@ func UnmarshalTransactionPayloadDecoded(r *bytes.Buffer, f *TransactionPayloadDe
 		return err
 	}
 
+	if lenInputs > uint64(r.Len()/32) {
+		return fmt.Errorf("attempting to decode too many nullifiers %d", lenInputs)
+	}
+
 	f.Nullifiers = make([][]byte, lenInputs)
 	for i := range f.Nullifiers {
 		f.Nullifiers[i] = make([]byte, 32)
@@ -156,6 +161,10 @@ func UnmarshalTransactionPayloadDecoded(r *bytes.Buffer, f *TransactionPayloadDe
 		return err
 	}
 
+	if lenNotes > uint64(r.Len()/noteSize) {
+		return fmt.Errorf("attempting to decode too many notes %d", lenNotes)
+	}
+
 	f.Notes = make([]*Note, lenNotes)
 	for i := range f.Notes {
 		f.Notes[i] = NewNote()
@@ -185,6 +194,10 @@ func UnmarshalTransactionPayloadDecoded(r *bytes.Buffer, f *TransactionPayloadDe
 		return err
 	}
 
+	if lenProof > uint64(r.Len()) {
+		return fmt.Errorf("attempting to decode spend proof which is too large %d", lenProof)
+	}
+
 	f.SpendProof = make([]byte, lenProof)
 	if _, err := io.ReadFull(r, f.SpendProof); err != nil {
 		return err
@@ -8,10 +8,12 @@ package heavy
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -58,6 +60,10 @@ var (
 	PersistedPrefix = []byte{0x06}
 	// CandidatePrefix is the prefix to identify Candidate messages.
 	CandidatePrefix = []byte{0x07}
+	// ProvisionersPrefix is the prefix to identify the provisioners snapshots.
+	ProvisionersPrefix = []byte{0x08}
+	// StakeEventsPrefix is the prefix to identify the stake events of a block.
+	StakeEventsPrefix = []byte{0x09}
 )
 
 type transaction struct {
@@ -523,6 +529,149 @@ func (t transaction) FetchCandidateMessage(hash []byte) (block.Block, error) {
 	return *cm, nil
 }
 
+// StoreProvisioners stores the snapshot of the provisioners in effect after the
+// block at height, if it differs from the one already in effect.
+//
+// Key = ProvisionersPrefix + height (big-endian)
+// Value = MarshalProvisioners(p)
+func (t transaction) StoreProvisioners(height uint64, p *user.Provisioners) error {
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, p); err != nil {
+		return err
+	}
+
+	// Discard the snapshots of a reverted branch
+	if height < math.MaxUint64 {
+		iter := t.snapshot.NewIterator(&util.Range{Start: heightKey(ProvisionersPrefix, height+1), Limit: util.BytesPrefix(ProvisionersPrefix).Limit}, nil)
+		defer iter.Release()
+
+		for iter.Next() {
+			t.op(optypeDelete, append([]byte{}, iter.Key()...), nil)
+		}
+
+		if err := iter.Error(); err != nil {
+			return err
+		}
+	}
+
+	current, err := t.fetchProvisioners(height)
+	if err != nil && err != database.ErrProvisionersNotFound {
+		return err
+	}
+
+	if bytes.Equal(current, buf.Bytes()) {
+		return nil
+	}
+
+	t.put(heightKey(ProvisionersPrefix, height), buf.Bytes())
+	return nil
+}
+
+// FetchProvisioners returns the most recent provisioners snapshot at or
+// below height.
+func (t transaction) FetchProvisioners(height uint64) (*user.Provisioners, error) {
+	value, err := t.fetchProvisioners(height)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := user.UnmarshalProvisioners(bytes.NewBuffer(value))
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (t transaction) fetchProvisioners(height uint64) ([]byte, error) {
+	limit := util.BytesPrefix(ProvisionersPrefix).Limit
+	if height < math.MaxUint64 {
+		limit = heightKey(ProvisionersPrefix, height+1)
+	}
+
+	iter := t.snapshot.NewIterator(&util.Range{Start: ProvisionersPrefix, Limit: limit}, nil)
+	defer iter.Release()
+
+	if !iter.Last() {
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	return append([]byte{}, iter.Value()...), nil
+}
+
+// StoreStakeEvents stores the stake events caused by the block at height. The
+// events of the heights above, which belong to a reverted branch, are
+// discarded.
+//
+// Key = StakeEventsPrefix + height (big-endian)
+// Value = MarshalStakeEvents(events)
+func (t transaction) StoreStakeEvents(height uint64, events []user.StakeEvent) error {
+	if height < math.MaxUint64 {
+		iter := t.snapshot.NewIterator(&util.Range{Start: heightKey(StakeEventsPrefix, height+1), Limit: util.BytesPrefix(StakeEventsPrefix).Limit}, nil)
+		defer iter.Release()
+
+		for iter.Next() {
+			t.op(optypeDelete, append([]byte{}, iter.Key()...), nil)
+		}
+
+		if err := iter.Error(); err != nil {
+			return err
+		}
+	}
+
+	if len(events) == 0 {
+		t.op(optypeDelete, heightKey(StakeEventsPrefix, height), nil)
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := user.MarshalStakeEvents(buf, events); err != nil {
+		return err
+	}
+
+	t.put(heightKey(StakeEventsPrefix, height), buf.Bytes())
+	return nil
+}
+
+// FetchStakeEvents returns the stake events caused by the blocks between two
+// heights (inclusive), ordered by height.
+func (t transaction) FetchStakeEvents(from, to uint64) ([]user.StakeEvent, error) {
+	limit := util.BytesPrefix(StakeEventsPrefix).Limit
+	if to < math.MaxUint64 {
+		limit = heightKey(StakeEventsPrefix, to+1)
+	}
+
+	iter := t.snapshot.NewIterator(&util.Range{Start: heightKey(StakeEventsPrefix, from), Limit: limit}, nil)
+	defer iter.Release()
+
+	res := make([]user.StakeEvent, 0)
+
+	for iter.Next() {
+		events, err := user.UnmarshalStakeEvents(bytes.NewBuffer(iter.Value()))
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, events...)
+	}
+
+	return res, iter.Error()
+}
+
+// heightKey returns the key of a per-height record. Unlike the other keys,
+// the height is big-endian so that the records are sorted by height.
+func heightKey(prefix []byte, height uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], height)
+
+	return key
+}
+
 // FetchBlockByStateRoot finds a block that is linked to a specified state_root.
 // Loop through all blocks in reverse order.
 func (t *transaction) FetchBlockByStateRoot(fromHeight uint64, stateRoot []byte) (*block.Block, error) {
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"math"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 )
@@ -28,6 +29,8 @@ var (
 	ErrOutputNotFound = errors.New("database: output not found")
 	// ErrStateHashNotFound returned on state hash not linked to any block.
 	ErrStateHashNotFound = errors.New("database: state hash was not found")
+	// ErrProvisionersNotFound returned on a provisioners lookup by height.
+	ErrProvisionersNotFound = errors.New("database: provisioners not found")
 
 	// AnyTxType is used as a filter value on FetchBlockTxByHash.
 	AnyTxType = transactions.TxType(math.MaxUint8)
@@ -97,6 +100,26 @@ type Transaction interface {
 
 	ClearCandidateMessages() error
 
+	// StoreProvisioners stores the snapshot of the provisioners in effect
+	// after the block at height, which verify the certificate of the next
+	// block. A snapshot is stored only if the set differs from the one in
+	// effect at height. The snapshots of the heights above, which belong to
+	// a reverted branch, are discarded.
+	StoreProvisioners(height uint64, p *user.Provisioners) error
+
+	// FetchProvisioners returns the provisioners in effect after the block
+	// at height, that is the most recent snapshot at or below height.
+	FetchProvisioners(height uint64) (*user.Provisioners, error)
+
+	// StoreStakeEvents stores the stake events caused by the block at
+	// height, replacing those already stored. The events of the heights
+	// above, which belong to a reverted branch, are discarded.
+	StoreStakeEvents(height uint64, events []user.StakeEvent) error
+
+	// FetchStakeEvents returns the stake events caused by the blocks between
+	// two heights (inclusive), ordered by height.
+	FetchStakeEvents(from, to uint64) ([]user.StakeEvent, error)
+
 	// ClearDatabase will remove all information from the database.
 	ClearDatabase() error
 
@@ -27,6 +27,8 @@ const (
 	stateInd
 	candidateInd
 	persistedInd
+	provisionersInd
+	stakeEventsInd
 	maxInd
 )
 
@@ -11,7 +11,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -321,6 +323,141 @@ func (t *transaction) FetchCandidateMessage(hash []byte) (block.Block, error) {
 	return *cm, nil
 }
 
+// StoreProvisioners stores the snapshot of the provisioners in effect after the
+// block at height, if it differs from the one already in effect. Like the
+// candidate messages, the snapshots are written directly into the storage.
+func (t *transaction) StoreProvisioners(height uint64, p *user.Provisioners) error {
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, p); err != nil {
+		return err
+	}
+
+	// Discard the snapshots of a reverted branch
+	for k := range t.db.storage[provisionersInd] {
+		if snapshotHeight(k) > height {
+			delete(t.db.storage[provisionersInd], k)
+		}
+	}
+
+	current, err := t.fetchProvisioners(height)
+	if err != nil && err != database.ErrProvisionersNotFound {
+		return err
+	}
+
+	if bytes.Equal(current, buf.Bytes()) {
+		return nil
+	}
+
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, height); err != nil {
+		return err
+	}
+
+	t.db.storage[provisionersInd][toKey(heightBuf.Bytes())] = buf.Bytes()
+	return nil
+}
+
+// FetchProvisioners returns the most recent provisioners snapshot at or
+// below height.
+func (t *transaction) FetchProvisioners(height uint64) (*user.Provisioners, error) {
+	value, err := t.fetchProvisioners(height)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := user.UnmarshalProvisioners(bytes.NewBuffer(value))
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (t *transaction) fetchProvisioners(height uint64) ([]byte, error) {
+	var (
+		value []byte
+		found bool
+		best  uint64
+	)
+
+	for k, v := range t.db.storage[provisionersInd] {
+		h := snapshotHeight(k)
+		if h <= height && (!found || h > best) {
+			value, best, found = v, h, true
+		}
+	}
+
+	if !found {
+		return nil, database.ErrProvisionersNotFound
+	}
+
+	return value, nil
+}
+
+// StoreStakeEvents stores the stake events caused by the block at height,
+// and discards those of the heights above.
+func (t *transaction) StoreStakeEvents(height uint64, events []user.StakeEvent) error {
+	for k := range t.db.storage[stakeEventsInd] {
+		if snapshotHeight(k) >= height {
+			delete(t.db.storage[stakeEventsInd], k)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := user.MarshalStakeEvents(buf, events); err != nil {
+		return err
+	}
+
+	heightBuf := new(bytes.Buffer)
+	if err := utils.WriteUint64(heightBuf, height); err != nil {
+		return err
+	}
+
+	t.db.storage[stakeEventsInd][toKey(heightBuf.Bytes())] = buf.Bytes()
+	return nil
+}
+
+// FetchStakeEvents returns the stake events caused by the blocks between two
+// heights (inclusive), ordered by height.
+func (t *transaction) FetchStakeEvents(from, to uint64) ([]user.StakeEvent, error) {
+	heights := make([]uint64, 0)
+	values := make(map[uint64][]byte)
+
+	for k, v := range t.db.storage[stakeEventsInd] {
+		h := snapshotHeight(k)
+		if h >= from && h <= to {
+			heights = append(heights, h)
+			values[h] = v
+		}
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	res := make([]user.StakeEvent, 0)
+
+	for _, h := range heights {
+		events, err := user.UnmarshalStakeEvents(bytes.NewBuffer(values[h]))
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, events...)
+	}
+
+	return res, nil
+}
+
+func snapshotHeight(k key) uint64 {
+	var height uint64
+	_ = utils.ReadUint64(bytes.NewReader(k[:8]), &height)
+
+	return height
+}
+
 // FetchBlockByStateRoot finds a block that is linked to a specified state_root.
 // Loop through all blocks in reverse order.
 func (t *transaction) FetchBlockByStateRoot(fromHeight uint64, stateRoot []byte) (*block.Block, error) {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -650,6 +651,122 @@ func TestFetchBlockTxByHash(test *testing.T) {
 	})
 }
 
+func mockProvisioners(stakes ...uint64) *user.Provisioners {
+	p := user.NewProvisioners()
+
+	for i, stake := range stakes {
+		pk := make([]byte, user.BlsKeySize)
+		pk[0] = byte(i + 1)
+
+		p.Set.Insert(pk)
+		p.Members[string(pk)] = &user.Member{
+			PublicKeyBLS:    pk,
+			RawPublicKeyBLS: pk,
+			Stakes:          []user.Stake{{Value: stake}},
+		}
+	}
+
+	return p
+}
+
+func TestProvisionersSnapshots(test *testing.T) {
+	a, b, c := mockProvisioners(1), mockProvisioners(1, 2), mockProvisioners(3)
+
+	store := func(height uint64, p *user.Provisioners) {
+		require.NoError(test, db.Update(func(t database.Transaction) error {
+			return t.StoreProvisioners(height, p)
+		}))
+	}
+
+	fetch := func(height uint64) (*user.Provisioners, error) {
+		var p *user.Provisioners
+
+		err := db.View(func(t database.Transaction) error {
+			var err error
+			p, err = t.FetchProvisioners(height)
+			return err
+		})
+
+		return p, err
+	}
+
+	assertSnapshot := func(height uint64, expected *user.Provisioners) {
+		p, err := fetch(height)
+		require.NoError(test, err)
+
+		expectedBuf, buf := new(bytes.Buffer), new(bytes.Buffer)
+		require.NoError(test, user.MarshalProvisioners(expectedBuf, expected))
+		require.NoError(test, user.MarshalProvisioners(buf, p))
+		require.Equal(test, expectedBuf.Bytes(), buf.Bytes(), "height %d", height)
+	}
+
+	store(2, a)
+	store(5, b)
+	store(7, b)
+
+	_, err := fetch(1)
+	require.Equal(test, database.ErrProvisionersNotFound, err)
+
+	// A snapshot is in effect until the next one
+	assertSnapshot(2, a)
+	assertSnapshot(4, a)
+	assertSnapshot(5, b)
+	assertSnapshot(100, b)
+
+	// Storing a snapshot below the others discards them, as they belong to a
+	// reverted branch
+	store(3, c)
+	assertSnapshot(2, a)
+	assertSnapshot(3, c)
+	assertSnapshot(100, c)
+}
+
+func TestStakeEvents(test *testing.T) {
+	event := func(height uint64, status user.StakeStatus) user.StakeEvent {
+		return user.StakeEvent{
+			Height:       height,
+			PublicKeyBLS: make([]byte, user.BlsKeySize),
+			Value:        height,
+			Previous:     user.StakePending,
+			Status:       status,
+			RewardDelta:  -1,
+		}
+	}
+
+	store := func(height uint64, events ...user.StakeEvent) {
+		require.NoError(test, db.Update(func(t database.Transaction) error {
+			return t.StoreStakeEvents(height, events)
+		}))
+	}
+
+	fetch := func(from, to uint64) []user.StakeEvent {
+		var events []user.StakeEvent
+
+		require.NoError(test, db.View(func(t database.Transaction) error {
+			var err error
+			events, err = t.FetchStakeEvents(from, to)
+			return err
+		}))
+
+		return events
+	}
+
+	store(7, event(7, user.StakeExpired))
+	store(3, event(3, user.StakeEligible), event(3, user.StakePending))
+	store(5, event(5, user.StakeEligible))
+
+	// Storing the events of a height discards the events above
+	require.Len(test, fetch(0, 100), 3)
+	require.Equal(test, []user.StakeEvent{event(3, user.StakeEligible), event(3, user.StakePending)}, fetch(3, 4))
+
+	// The events are replaced, or removed if there are none
+	store(5, event(5, user.StakeExpired))
+	require.Equal(test, []user.StakeEvent{event(5, user.StakeExpired)}, fetch(4, 100))
+
+	store(5)
+	require.Empty(test, fetch(4, 100))
+}
+
 func TestClearDatabase(test *testing.T) {
 	err := db.Update(func(t database.Transaction) error {
 		return t.ClearDatabase()
@@ -29,6 +29,11 @@ import (
 
 const (
 	msgChanSize = 1000
+
+	// fastRoundMaxStep is the highest step at which a successful round is
+	// considered fast. Agreement is usually reached while the loop has already
+	// moved to the selection of the second iteration.
+	fastRoundMaxStep = 4
 )
 
 var lg = log.WithField("process", "consensus")
@@ -72,7 +77,7 @@ func CreateStateMachine(e *consensus.Emitter, db database.DB, consensusTimeOut t
 func CreateInitialStep(e *consensus.Emitter, consensusTimeOut time.Duration, bg blockgenerator.BlockGenerator, verifyFn consensus.CandidateVerificationFunc, db database.DB, requestor *candidate.Requestor) consensus.Phase {
 	redu2 := secondstep.New(e, verifyFn, consensusTimeOut)
 	redu1 := firststep.New(redu2, e, verifyFn, consensusTimeOut, db, requestor)
-	selectionStep := selection.New(redu1, bg, e, consensusTimeOut, db)
+	selectionStep := selection.New(redu1, bg, e, verifyFn, consensusTimeOut, db)
 
 	redu2.SetNext(selectionStep)
 	return selectionStep
@@ -122,6 +127,14 @@ func New(e *consensus.Emitter) *Consensus {
 	return c
 }
 
+// Queues returns the queues of the messages received ahead of time, by name.
+func (c *Consensus) Queues() map[string]*consensus.Queue {
+	return map[string]*consensus.Queue{
+		"event": c.eventQueue,
+		"round": c.roundQueue,
+	}
+}
+
 // CreateStateMachine uses Consensus parameters as a shorthand for the static
 // CreateStateMachine.
 func (c *Consensus) CreateStateMachine(db database.DB, consensusTimeOut time.Duration, verifyFn consensus.CandidateVerificationFunc, executeFn consensus.ExecuteTxsFunc) (consensus.Phase, consensus.Controller, error) {
@@ -134,9 +147,19 @@ func (c *Consensus) CreateStateMachine(db database.DB, consensusTimeOut time.Dur
 // Agreement loop (acting roundwise) runs concurrently with the generation-selection-reduction
 // loop (acting step-wise).
 // TODO: consider stopping the phase loop with a Done phase, instead of nil.
-func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.Controller, round consensus.RoundUpdate) consensus.Results {
+func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.Controller, round consensus.RoundUpdate) (res consensus.Results) {
 	defer c.teardown(round)
 
+	// Messages of the previous rounds are refused from now on
+	c.eventQueue.SetRound(round.Round)
+	c.roundQueue.SetRound(round.Round)
+
+	c.Journal.StartRound(round.Round)
+
+	defer func() {
+		c.Journal.EndRound(round.Round, res.Blk, res.Err)
+	}()
+
 	// Allow listeners to report warnings
 	for _, l := range c.listeners {
 		l.SetLogLevel(log.InfoLevel)
@@ -176,7 +199,10 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 	// synchronous consensus loop keeps running until the agreement invokes
 	// context.Done or the context is canceled some other way
 	for step := uint8(1); ; step++ {
+		c.Journal.StartStep(round.Round, step, phaseFunction.String())
 		phaseFunction = phaseFunction.Run(stepCtx, c.eventQueue, c.newBlockChan, c.reductionChan, round, step)
+		c.Journal.EndStep(round.Round, step)
+
 		// if result is nil, this round is over
 		if phaseFunction == nil {
 			lg.
@@ -189,6 +215,10 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 				// Take round results from the agreement goroutine
 			select {
 			case results := <-resultsChan:
+				if results.Err == nil && step <= fastRoundMaxStep {
+					c.Timeouts.Decrease()
+				}
+
 				return results
 			default:
 				return consensus.Results{Blk: block.Block{}, Err: context.Canceled}
@@ -203,10 +233,6 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 			}).
 			Trace("new phase")
 
-		if config.Get().API.Enabled {
-			go report(round.Round, step)
-		}
-
 		if step >= config.ConsensusMaxStep {
 			lg.
 				WithFields(log.Fields{
@@ -239,24 +265,6 @@ func (c *Consensus) teardown(round consensus.RoundUpdate) {
 	}
 }
 
-var steps = []string{"selection", "reduction1", "reduction2"} // nolint
-
-func report(round uint64, step uint8) {
-	/*
-		store := capi.GetBuntStoreInstance()
-		err := store.StoreRoundInfo(round, step, "Forward", steps[(step-1)%3])
-		if err != nil {
-			lg.
-				WithFields(log.Fields{
-					"round": round,
-					"step":  step,
-				}).
-				WithError(err).
-				Error("could not save StoreRoundInfo on api db")
-		}
-	*/
-}
-
 // phase should start by
 // - cleaning the events from the previous round
 // - cleaning the events from the previous steps
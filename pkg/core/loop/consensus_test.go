@@ -15,6 +15,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/journal"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
@@ -99,6 +100,7 @@ func (c *succesfulAgreement) GetControlFn() consensus.ControlFn {
 // agreement completes normally.
 func TestAgreementCompletion(t *testing.T) {
 	e := consensus.MockEmitter(time.Second)
+	e.Journal = journal.New(10)
 	ctx := context.Background()
 	l := New(e)
 
@@ -110,6 +112,13 @@ func TestAgreementCompletion(t *testing.T) {
 
 	require.NotNil(t, results.Blk)
 	require.Nil(t, results.Err)
+
+	// the round outcome is recorded in the journal
+	rounds := e.Journal.Rounds(1, 1)
+	require.Len(t, rounds, 1)
+	require.Empty(t, rounds[0].Err)
+	require.False(t, rounds[0].EndedAt.IsZero())
+	require.NotEmpty(t, rounds[0].Steps)
 }
 
 // stallingStep is used by TestStall to test that any step would
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/finality"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	core "github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -37,6 +38,7 @@ type (
 		Height    uint64 `json:"height"`    // Block height
 		Timestamp int64  `json:"timestamp"` // Block timestamp
 		GasLimit  uint64 `json:"gasLimit"`  // Block gas limit
+		Iteration uint8  `json:"iteration"` // Consensus iteration which produced the block
 
 		PrevBlockHash      []byte `json:"prev-hash"`  // Hash of previous block (32 bytes)
 		Seed               []byte `json:"seed"`       // Marshaled BLS signature or hash of the previous block seed (32 bytes)
@@ -60,6 +62,7 @@ func newQueryBlock(b *block.Block) queryBlock {
 	qb.Header.Height = b.Header.Height
 	qb.Header.Timestamp = b.Header.Timestamp
 	qb.Header.GasLimit = b.Header.GasLimit
+	qb.Header.Iteration = b.Header.Iteration
 	qb.Header.PrevBlockHash = b.Header.PrevBlockHash
 	qb.Header.Seed = b.Header.Seed
 	qb.Header.GeneratorBlsPubkey = b.Header.GeneratorBlsPubkey
@@ -303,6 +306,27 @@ func resolveStep(p graphql.ResolveParams) (interface{}, error) {
 	return 0, errors.New("invalid step source block")
 }
 
+// resolveProof returns the marshaled finality proof of the block, for light
+// clients to verify against the provisioners of the round.
+func resolveProof(p graphql.ResolveParams) (interface{}, error) {
+	b, ok := p.Source.(*queryHeader)
+	if !ok {
+		return nil, errors.New("invalid proof source block")
+	}
+
+	proof, err := finality.New(&block.Header{
+		Height:      b.Height,
+		Iteration:   b.Iteration,
+		Hash:        b.Hash,
+		Certificate: b.Certificate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return finality.Marshal(proof)
+}
+
 // Fetch block headers by a list of hashes.
 func (b blocks) fetchBlocksByHashes(db database.DB, hashes []interface{}) ([]queryBlock, error) {
 	blocks := make([]*block.Block, 0)
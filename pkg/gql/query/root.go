@@ -16,7 +16,7 @@ type Root struct {
 	Query *graphql.Object
 }
 
-// NewRoot returns a Root with blocks, transactions and mempool setup.
+// NewRoot returns a Root with blocks, transactions, mempool and stakes setup.
 func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 	m := mempool{rpcBus: rpcBus}
 
@@ -28,6 +28,8 @@ func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 					"blocks":       blocks{}.getQuery(),
 					"transactions": transactions{}.getQuery(),
 					"mempool":      m.getQuery(),
+					"stakes":       stakeEvents{}.getQuery(),
+					"stakeepoch":   stakeEpoch{}.getQuery(),
 				},
 			},
 		),
@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakes"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	stakesRangeArg  = "range"
+	stakesBlsKeyArg = "blskey"
+	stakesEpochArg  = "epoch"
+)
+
+// File purpose is to define all arguments and resolvers relevant to "stakes"
+// and "stakeepoch" queries only.
+
+type stakeEvents struct{}
+
+func (s stakeEvents) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(StakeEvent),
+		Args: graphql.FieldConfigArgument{
+			stakesRangeArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.Int)),
+			},
+			stakesBlsKeyArg: &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+		},
+		Resolve: s.resolve,
+	}
+}
+
+func (s stakeEvents) resolve(p graphql.ResolveParams) (interface{}, error) {
+	heights, ok := p.Args[stakesRangeArg].([]interface{})
+	if !ok || len(heights) != 2 {
+		return nil, errors.New("invalid range")
+	}
+
+	from, okFrom := heights[0].(int)
+	to, okTo := heights[1].(int)
+
+	if !okFrom || !okTo || from < 0 || to < from {
+		return nil, errors.New("invalid range")
+	}
+
+	var pubKeyBLS []byte
+
+	if key, ok := p.Args[stakesBlsKeyArg].(string); ok {
+		var err error
+		if pubKeyBLS, err = hex.DecodeString(key); err != nil {
+			return nil, errors.New("invalid blskey")
+		}
+	}
+
+	events, err := fetchStakeEvents(p, uint64(from), uint64(to))
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]user.StakeEvent, 0, len(events))
+
+	for _, e := range events {
+		if len(pubKeyBLS) == 0 || bytes.Equal(e.PublicKeyBLS, pubKeyBLS) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return filtered, nil
+}
+
+type stakeEpoch struct{}
+
+func (s stakeEpoch) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: EpochReport,
+		Args: graphql.FieldConfigArgument{
+			stakesEpochArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+		},
+		Resolve: s.resolve,
+	}
+}
+
+func (s stakeEpoch) resolve(p graphql.ResolveParams) (interface{}, error) {
+	epoch, ok := p.Args[stakesEpochArg].(int)
+	if !ok || epoch < 0 {
+		return nil, errors.New("invalid epoch")
+	}
+
+	from, to := stakes.EpochBounds(uint64(epoch))
+
+	events, err := fetchStakeEvents(p, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return stakes.NewEpochReport(uint64(epoch), events), nil
+}
+
+func fetchStakeEvents(p graphql.ResolveParams, from, to uint64) ([]user.StakeEvent, error) {
+	db, ok := p.Context.Value("database").(database.DB)
+	if !ok {
+		return nil, errors.New("context does not store database conn")
+	}
+
+	var events []user.StakeEvent
+
+	err := db.View(func(t database.Transaction) error {
+		var err error
+		events, err = t.FetchStakeEvents(from, to)
+		return err
+	})
+
+	return events, err
+}
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestStakes(t *testing.T) {
+	pk1 := make([]byte, user.BlsKeySize)
+	pk2 := make([]byte, user.BlsKeySize)
+	pk2[0] = 1
+
+	assert.NoError(t, db.Update(func(t database.Transaction) error {
+		if err := t.StoreStakeEvents(1, []user.StakeEvent{
+			{Height: 1, PublicKeyBLS: pk1, Value: 10, Eligibility: 2, Status: user.StakePending},
+			{Height: 1, PublicKeyBLS: pk2, Value: 20, Eligibility: 1, Previous: user.StakeEligible, Status: user.StakeExpired},
+		}); err != nil {
+			return err
+		}
+
+		return t.StoreStakeEvents(2, []user.StakeEvent{
+			{Height: 2, PublicKeyBLS: pk1, Value: 10, Eligibility: 2, Previous: user.StakePending, Status: user.StakeEligible, Reward: 5, RewardDelta: 5},
+		})
+	}))
+
+	defer func() {
+		_ = db.Update(func(t database.Transaction) error {
+			return t.StoreStakeEvents(0, nil)
+		})
+	}()
+
+	query := fmt.Sprintf(`
+		{
+		  stakes(range: [0, 5], blskey: "%x") {
+			height
+			previous
+			status
+			reward_delta
+		  },
+		  stakeepoch(epoch: 0) {
+			start_height
+			end_height
+			rewards {
+			  reward
+			}
+		  }
+		}
+		`, pk1)
+
+	response := `
+		{
+		  "data": {
+			"stakes": [
+			  {
+				"height": 1,
+				"previous": "none",
+				"status": "pending",
+				"reward_delta": 0
+			  },
+			  {
+				"height": 2,
+				"previous": "pending",
+				"status": "eligible",
+				"reward_delta": 5
+			  }
+			],
+			"stakeepoch": {
+			  "start_height": 0,
+			  "end_height": 2159,
+			  "rewards": [
+				{
+				  "reward": 5
+				},
+				{
+				  "reward": 0
+				}
+			  ]
+			}
+		  }
+		}
+	`
+	assertQuery(t, query, response)
+}
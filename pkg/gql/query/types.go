@@ -78,6 +78,13 @@ var Header = graphql.NewObject(
 				Type:    graphql.Int,
 				Resolve: resolveStep,
 			},
+			"iteration": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"proof": &graphql.Field{
+				Type:    Base64,
+				Resolve: resolveProof,
+			},
 		},
 	},
 )
@@ -148,6 +155,83 @@ var ContractInfo = graphql.NewObject(
 	},
 )
 
+// StakeEvent is the graphql object representing a change of a stake.
+var StakeEvent = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "StakeEvent",
+		Fields: graphql.Fields{
+			"height": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"epoch": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"bls_key": &graphql.Field{
+				Type: Hex,
+			},
+			"value": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"eligibility": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"previous": &graphql.Field{
+				Type: graphql.String,
+			},
+			"status": &graphql.Field{
+				Type: graphql.String,
+			},
+			"reward": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"reward_delta": &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	},
+)
+
+// EpochReward is the graphql object representing the reward accrued by a
+// provisioner during an epoch.
+var EpochReward = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "EpochReward",
+		Fields: graphql.Fields{
+			"bls_key": &graphql.Field{
+				Type: Hex,
+			},
+			"reward": &graphql.Field{
+				Type: graphql.Float,
+			},
+		},
+	},
+)
+
+// EpochReport is the graphql object representing the stake events of an
+// epoch.
+var EpochReport = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "EpochReport",
+		Fields: graphql.Fields{
+			"epoch": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"start_height": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"end_height": &graphql.Field{
+				Type: graphql.Float,
+			},
+			"rewards": &graphql.Field{
+				Type: graphql.NewList(EpochReward),
+			},
+			"events": &graphql.Field{
+				Type: graphql.NewList(StakeEvent),
+			},
+		},
+	},
+)
+
 // Hex is the graphql object representing a hex scalar.
 var Hex = graphql.NewScalar(graphql.ScalarConfig{
 	Name:        "Hex",
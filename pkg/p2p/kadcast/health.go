@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package kadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+var errNotListening = errors.New("kadcast stream not open yet")
+
+// Health reports the state of the connections to the Kadcast service: the
+// Listen stream of the Reader, and the client connections of the writers.
+// A nil Health reports nothing.
+type Health struct {
+	lock       sync.RWMutex
+	listening  bool
+	opened     bool
+	since      time.Time
+	lastErr    error
+	reconnects uint64
+
+	conns   []*grpc.ClientConn
+	pending func() int
+}
+
+// NewHealth creates a Health watching the given client connections.
+func NewHealth(conns ...*grpc.ClientConn) *Health {
+	return &Health{
+		since:   time.Now(),
+		lastErr: errNotListening,
+		conns:   conns,
+	}
+}
+
+// Check returns an error if the Listen stream is down, or if a client
+// connection is failing. It satisfies the checker signature of the API
+// healthcheck.
+func (h *Health) Check(_ context.Context) error {
+	if h == nil {
+		return nil
+	}
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if !h.listening {
+		return fmt.Errorf("kadcast stream down since %s: %v", h.since.Format(time.RFC3339), h.lastErr)
+	}
+
+	for _, conn := range h.conns {
+		switch s := conn.GetState(); s {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("kadcast connection %s", s)
+		}
+	}
+
+	if h.pending != nil {
+		if n := h.pending(); n > 0 {
+			return fmt.Errorf("%d kadcast messages waiting for delivery", n)
+		}
+	}
+
+	return nil
+}
+
+// Reconnects returns the number of times the Listen stream was reopened.
+func (h *Health) Reconnects() uint64 {
+	if h == nil {
+		return 0
+	}
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.reconnects
+}
+
+func (h *Health) setListening() {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.opened {
+		h.reconnects++
+	}
+
+	h.opened = true
+	h.listening = true
+	h.since = time.Now()
+	h.lastErr = nil
+}
+
+func (h *Health) setDown(err error) {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.listening {
+		h.since = time.Now()
+	}
+
+	h.listening = false
+	h.lastErr = err
+}
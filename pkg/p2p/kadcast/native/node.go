@@ -0,0 +1,605 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package native
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	mrand "math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rcudp"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var log = logger.WithFields(logger.Fields{"process": "kadcast", "mode": "native"})
+
+const (
+	defaultBeta             = 3
+	defaultRedundancyFactor = 2
+	defaultRefreshInterval  = 30 * time.Second
+
+	// seenTTL is how long the hash of a broadcast message is remembered, so
+	// that it is delivered once.
+	seenTTL = 5 * time.Minute
+	// listenQueueSize is the number of received messages a listener buffers.
+	listenQueueSize = 1000
+	// maxPendingPings is the number of Pings awaiting a Pong.
+	maxPendingPings = 1024
+)
+
+var errClosed = status.Error(codes.Unavailable, "kadcast node closed")
+
+// Config of a Node.
+type Config struct {
+	// Address is the public address of the node, the other nodes reach it
+	// on. A zero port is replaced by the port the node binds to.
+	Address string
+	// Listen is the address the UDP socket binds to. Defaults to Address.
+	Listen string
+	// Bootstrap are the addresses of the nodes to join the network through.
+	Bootstrap []string
+	// Beta is the number of peers of each bucket a message is delegated to.
+	Beta int
+	// RedundancyFactor is the ratio of FEC encoded blocks to source blocks.
+	RedundancyFactor uint8
+	// RefreshInterval is how often the routing table is refreshed.
+	RefreshInterval time.Duration
+}
+
+// Node runs the Kadcast protocol natively, over Raptor coded UDP (see rcudp).
+// Peers are kept in a XOR-metric routing table. A message broadcast at height
+// h is delegated to Beta peers of each bucket up to h, each of them being
+// expected to broadcast it again below its own bucket.
+//
+// A peer only enters the routing table once it answered a Ping with its
+// token, as the sender of any other message could be spoofed.
+//
+// Node implements rusk.NetworkClient, so that it is a drop-in replacement of
+// the network service of Rusk for the Kadcast Reader and writers.
+type Node struct {
+	cfg Config
+
+	addr   *net.UDPAddr
+	laddr  *net.UDPAddr
+	id     ID
+	table  *Table
+	reader *rcudp.UDPReader
+
+	lock      sync.Mutex
+	seen      map[[sha256.Size]byte]time.Time
+	pings     map[string]pendingPing
+	listeners map[*stream]struct{}
+
+	quit chan struct{}
+}
+
+// NewNode creates a Node. It does not bind its socket until Start is called.
+// Zero values of the Config fall back to defaults.
+func NewNode(cfg Config) (*Node, error) {
+	if cfg.Listen == "" {
+		cfg.Listen = cfg.Address
+	}
+
+	if cfg.Beta <= 0 {
+		cfg.Beta = defaultBeta
+	}
+
+	if cfg.RedundancyFactor == 0 {
+		cfg.RedundancyFactor = defaultRedundancyFactor
+	}
+
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultRefreshInterval
+	}
+
+	addr, err := net.ResolveUDPAddr("udp4", cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	laddr, err := net.ResolveUDPAddr("udp4", cfg.Listen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		cfg:       cfg,
+		addr:      addr,
+		laddr:     laddr,
+		seen:      make(map[[sha256.Size]byte]time.Time),
+		pings:     make(map[string]pendingPing),
+		listeners: make(map[*stream]struct{}),
+		quit:      make(chan struct{}),
+	}, nil
+}
+
+// Start binds the socket of the Node, and joins the network through the
+// bootstrap nodes.
+func (n *Node) Start() error {
+	reader, err := rcudp.NewUDPReader(n.laddr, n.collect)
+	if err != nil {
+		return err
+	}
+
+	if err := reader.Listen(); err != nil {
+		return err
+	}
+
+	n.reader = reader
+
+	if n.laddr.Port == 0 {
+		n.laddr.Port = reader.LocalAddr().Port
+	}
+
+	if n.addr.Port == 0 {
+		n.addr.Port = n.laddr.Port
+	}
+
+	n.id = ComputeID(n.addr)
+	n.table = NewTable(n.id)
+
+	go reader.Serve()
+
+	log.WithField("addr", n.addr.String()).Info("kadcast node started")
+
+	n.bootstrap()
+
+	go n.maintain()
+
+	return nil
+}
+
+// Close leaves the network, and terminates the listeners.
+func (n *Node) Close() error {
+	close(n.quit)
+
+	if n.reader == nil {
+		return nil
+	}
+
+	return n.reader.Close()
+}
+
+// Addr returns the public address of the Node.
+func (n *Node) Addr() *net.UDPAddr {
+	return n.addr
+}
+
+// Table returns the routing table of the Node.
+func (n *Node) Table() *Table {
+	return n.table
+}
+
+// Listen returns a stream of the messages received from the network.
+// Implements rusk.NetworkClient.
+func (n *Node) Listen(ctx context.Context, _ *rusk.Null, _ ...grpc.CallOption) (rusk.Network_ListenClient, error) {
+	s := &stream{ctx: ctx, node: n, msgs: make(chan *rusk.Message, listenQueueSize)}
+
+	n.lock.Lock()
+	n.listeners[s] = struct{}{}
+	n.lock.Unlock()
+
+	return s, nil
+}
+
+// Broadcast delegates a message to Beta peers of each bucket up to the height
+// of the message.
+// Implements rusk.NetworkClient.
+func (n *Node) Broadcast(_ context.Context, in *rusk.BroadcastMessage, _ ...grpc.CallOption) (*rusk.Null, error) {
+	if err := n.broadcast(in.Message, in.KadcastHeight); err != nil {
+		return nil, err
+	}
+
+	return &rusk.Null{}, nil
+}
+
+// Propagate broadcasts a message to the whole network.
+// Implements rusk.NetworkClient.
+func (n *Node) Propagate(_ context.Context, in *rusk.PropagateMessage, _ ...grpc.CallOption) (*rusk.Null, error) {
+	if err := n.broadcast(in.Message, Buckets-1); err != nil {
+		return nil, err
+	}
+
+	return &rusk.Null{}, nil
+}
+
+// Send a message to a single node.
+// Implements rusk.NetworkClient.
+func (n *Node) Send(_ context.Context, in *rusk.SendMessage, _ ...grpc.CallOption) (*rusk.Null, error) {
+	to, err := net.ResolveUDPAddr("udp4", in.TargetAddress)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := n.write(to, kindSend, in.Message, 0); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &rusk.Null{}, nil
+}
+
+// AliveNodes returns the addresses of up to MaxNodes random peers of the
+// routing table.
+// Implements rusk.NetworkClient.
+func (n *Node) AliveNodes(_ context.Context, in *rusk.AliveNodesRequest, _ ...grpc.CallOption) (*rusk.AliveNodesResponse, error) {
+	peers := n.table.All()
+	mrand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+
+	if limit := int(in.MaxNodes); limit > 0 && len(peers) > limit {
+		peers = peers[:limit]
+	}
+
+	addrs := make([]string, len(peers))
+	for i, p := range peers {
+		addrs[i] = p.Addr.String()
+	}
+
+	return &rusk.AliveNodesResponse{Address: addrs}, nil
+}
+
+func (n *Node) broadcast(msg []byte, height uint32) error {
+	if height >= Buckets {
+		height = Buckets - 1
+	}
+
+	b, err := encode(kindBroadcast, uint16(n.addr.Port), msg)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	_, blocks, err := rcudp.CompileRaptorRFC5053(0, b, n.cfg.RedundancyFactor)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	type delegate struct {
+		addr   *net.UDPAddr
+		height byte
+	}
+
+	var delegates []delegate
+
+	for i := 0; i <= int(height); i++ {
+		for _, p := range n.table.Delegates(i, n.cfg.Beta) {
+			delegates = append(delegates, delegate{p.Addr, byte(i)})
+		}
+	}
+
+	// The blocks are written one delegate after the other, as WriteBlocks
+	// sets their height field in place
+	go func() {
+		for _, d := range delegates {
+			if err := rcudp.WriteBlocks(n.laddr, d.addr, blocks, d.height); err != nil {
+				log.WithError(err).WithField("r_addr", d.addr.String()).Warn("broadcast failed")
+			}
+		}
+	}()
+
+	return nil
+}
+
+// write sends a message to a single node.
+func (n *Node) write(to *net.UDPAddr, k kind, payload []byte, height byte) error {
+	b, err := encode(k, uint16(n.addr.Port), payload)
+	if err != nil {
+		return err
+	}
+
+	_, blocks, err := rcudp.CompileRaptorRFC5053(height, b, n.cfg.RedundancyFactor)
+	if err != nil {
+		return err
+	}
+
+	return rcudp.WriteBlocks(n.laddr, to, blocks, height)
+}
+
+// collect handles a message decoded by the UDP reader.
+func (n *Node) collect(height byte, src string, decoded []byte) error {
+	h, payload, err := decode(decoded)
+	if err != nil {
+		return err
+	}
+
+	srcAddr, err := net.ResolveUDPAddr("udp4", src)
+	if err != nil {
+		return err
+	}
+
+	// The packets are sent from a random port, the sender listens on the
+	// port of the header
+	sender := &net.UDPAddr{IP: srcAddr.IP, Port: int(h.port)}
+	if sender.String() == n.addr.String() {
+		return nil
+	}
+
+	switch h.kind {
+	case kindPing:
+		return n.processPing(sender, payload)
+	case kindPong:
+		return n.processPong(sender, payload)
+	case kindFindNodes:
+		return n.processFindNodes(sender, payload)
+	case kindNodes:
+		return n.processNodes(payload)
+	case kindBroadcast:
+		if n.isDuplicate(payload) {
+			return nil
+		}
+
+		n.deliver(payload, height, sender)
+	case kindSend:
+		n.deliver(payload, 0, sender)
+	}
+
+	return nil
+}
+
+// pendingPing is a Ping awaiting its Pong.
+type pendingPing struct {
+	token [tokenSize]byte
+	sent  time.Time
+}
+
+// ping sends a Ping carrying a random token to addr. The peer enters the
+// routing table once it echoes the token.
+func (n *Node) ping(addr *net.UDPAddr) error {
+	var p pendingPing
+	if _, err := rand.Read(p.token[:]); err != nil {
+		return err
+	}
+
+	p.sent = time.Now()
+
+	n.lock.Lock()
+	_, ok := n.pings[addr.String()]
+	if !ok && len(n.pings) >= maxPendingPings {
+		n.lock.Unlock()
+		return errors.New("too many pending pings")
+	}
+
+	n.pings[addr.String()] = p
+	n.lock.Unlock()
+
+	return n.write(addr, kindPing, p.token[:], 0)
+}
+
+// processPing answers a Ping with its token, and pings back the unknown
+// senders, so that they enter the routing table once they answer.
+func (n *Node) processPing(sender *net.UDPAddr, payload []byte) error {
+	if len(payload) != tokenSize {
+		return errors.New("invalid ping payload")
+	}
+
+	if err := n.write(sender, kindPong, payload, 0); err != nil {
+		return err
+	}
+
+	if n.table.Contains(sender) || n.isPinging(sender) {
+		return nil
+	}
+
+	return n.ping(sender)
+}
+
+// processPong records the sender in the routing table, if it echoes the
+// token of the Ping sent to it.
+func (n *Node) processPong(sender *net.UDPAddr, payload []byte) error {
+	n.lock.Lock()
+	p, ok := n.pings[sender.String()]
+	if ok && bytes.Equal(p.token[:], payload) {
+		delete(n.pings, sender.String())
+	} else {
+		ok = false
+	}
+	n.lock.Unlock()
+
+	if !ok {
+		log.WithField("r_addr", sender.String()).Debug("unsolicited pong discarded")
+		return nil
+	}
+
+	n.table.Seen(sender, n.staleAfter())
+	return nil
+}
+
+func (n *Node) isPinging(addr *net.UDPAddr) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	_, ok := n.pings[addr.String()]
+	return ok
+}
+
+func (n *Node) processFindNodes(sender *net.UDPAddr, payload []byte) error {
+	if len(payload) != IDLen {
+		return errors.New("invalid find nodes payload")
+	}
+
+	var target ID
+	copy(target[:], payload)
+
+	peers := n.table.Closest(target, BucketSize+1)
+
+	// The sender knows about itself
+	res := peers[:0]
+
+	for _, p := range peers {
+		if p.Addr.String() != sender.String() {
+			res = append(res, p)
+		}
+	}
+
+	if len(res) > BucketSize {
+		res = res[:BucketSize]
+	}
+
+	return n.write(sender, kindNodes, encodeNodes(res), 0)
+}
+
+// processNodes pings the unknown nodes of a Nodes message. They enter the
+// routing table once they answer.
+func (n *Node) processNodes(payload []byte) error {
+	addrs, err := decodeNodes(payload, BucketSize)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if addr.String() == n.addr.String() || n.table.Contains(addr) {
+			continue
+		}
+
+		if err := n.ping(addr); err != nil {
+			log.WithError(err).WithField("r_addr", addr.String()).Debug("ping failed")
+		}
+	}
+
+	return nil
+}
+
+func (n *Node) isDuplicate(payload []byte) bool {
+	h := sha256.Sum256(payload)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.seen[h]; ok {
+		return true
+	}
+
+	n.seen[h] = time.Now()
+	return false
+}
+
+// deliver a received message to the listeners. Listeners too slow to keep up
+// miss messages, rather than blocking the Node.
+func (n *Node) deliver(payload []byte, height byte, sender *net.UDPAddr) {
+	msg := &rusk.Message{
+		Message: payload,
+		Metadata: &rusk.MessageMetadata{
+			KadcastHeight: uint32(height),
+			SrcAddress:    sender.String(),
+		},
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for s := range n.listeners {
+		select {
+		case s.msgs <- msg:
+		default:
+			log.Warn("kadcast listener queue full, message dropped")
+		}
+	}
+}
+
+func (n *Node) removeListener(s *stream) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.listeners, s)
+}
+
+// bootstrap pings the bootstrap nodes, and asks them for the nodes closest
+// to this one.
+func (n *Node) bootstrap() {
+	for _, b := range n.cfg.Bootstrap {
+		addr, err := net.ResolveUDPAddr("udp4", b)
+		if err != nil {
+			log.WithError(err).WithField("addr", b).Warn("invalid bootstrap address")
+			continue
+		}
+
+		if addr.String() == n.addr.String() {
+			continue
+		}
+
+		if err := n.ping(addr); err != nil {
+			log.WithError(err).WithField("addr", b).Warn("could not reach bootstrap node")
+			continue
+		}
+
+		if err := n.write(addr, kindFindNodes, n.id[:], 0); err != nil {
+			log.WithError(err).WithField("addr", b).Warn("could not reach bootstrap node")
+		}
+	}
+}
+
+// maintain refreshes the routing table until the Node is closed: silent
+// peers are pinged, then expired, and the closest peers and a random one are
+// asked for more nodes.
+func (n *Node) maintain() {
+	ticker := time.NewTicker(n.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-n.quit:
+			return
+		}
+
+		n.table.Expire(time.Now().Add(-n.staleAfter()))
+
+		if n.table.Len() == 0 {
+			n.bootstrap()
+		}
+
+		for _, p := range n.table.All() {
+			if time.Since(p.LastSeen) > n.cfg.RefreshInterval {
+				_ = n.ping(p.Addr)
+			}
+		}
+
+		for _, p := range n.table.Closest(n.id, n.cfg.Beta) {
+			_ = n.write(p.Addr, kindFindNodes, n.id[:], 0)
+		}
+
+		var target ID
+		if _, err := rand.Read(target[:]); err == nil {
+			for _, p := range n.table.Closest(target, 1) {
+				_ = n.write(p.Addr, kindFindNodes, target[:], 0)
+			}
+		}
+
+		n.expireSeen()
+	}
+}
+
+func (n *Node) expireSeen() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for h, t := range n.seen {
+		if time.Since(t) > seenTTL {
+			delete(n.seen, h)
+		}
+	}
+
+	// The unanswered Pings are sent again by the next refresh
+	for addr, p := range n.pings {
+		if time.Since(p.sent) > n.cfg.RefreshInterval {
+			delete(n.pings, addr)
+		}
+	}
+}
+
+// staleAfter is how long a silent peer stays in the routing table.
+func (n *Node) staleAfter() time.Duration {
+	return 3 * n.cfg.RefreshInterval
+}
@@ -0,0 +1,174 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package native
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"github.com/stretchr/testify/require"
+)
+
+func startNetwork(t *testing.T, size int) []*Node {
+	nodes := make([]*Node, 0, size)
+
+	for i := 0; i < size; i++ {
+		cfg := Config{
+			Address:         "127.0.0.1:0",
+			RefreshInterval: 200 * time.Millisecond,
+		}
+
+		if i > 0 {
+			cfg.Bootstrap = []string{nodes[0].Addr().String()}
+		}
+
+		n, err := NewNode(cfg)
+		require.NoError(t, err)
+		require.NoError(t, n.Start())
+
+		nodes = append(nodes, n)
+	}
+
+	// Wait for the nodes to discover each other
+	require.Eventually(t, func() bool {
+		for _, n := range nodes {
+			if n.Table().Len() < size-1 {
+				return false
+			}
+		}
+
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+
+	return nodes
+}
+
+// TestBroadcast ensures a message broadcast at the max height reaches every
+// node, when the nodes broadcast it again below the height they received it
+// at, like the Kadcast writer does.
+func TestBroadcast(t *testing.T) {
+	nodes := startNetwork(t, 8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan string, 100)
+
+	for _, n := range nodes[1:] {
+		stream, err := n.Listen(ctx, &rusk.Null{})
+		require.NoError(t, err)
+
+		go func(n *Node, stream rusk.Network_ListenClient) {
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					return
+				}
+
+				received <- n.Addr().String()
+
+				if h := msg.Metadata.KadcastHeight; h > 0 {
+					_, _ = n.Broadcast(ctx, &rusk.BroadcastMessage{KadcastHeight: h - 1, Message: msg.Message})
+				}
+			}
+		}(n, stream)
+	}
+
+	payload := bytes.Repeat([]byte("dusk"), 5000)
+	_, err := nodes[0].Broadcast(ctx, &rusk.BroadcastMessage{KadcastHeight: Buckets - 1, Message: payload})
+	require.NoError(t, err)
+
+	// Each node delivers the message once
+	delivered := make(map[string]int)
+
+	for len(delivered) < len(nodes)-1 {
+		select {
+		case addr := <-received:
+			delivered[addr]++
+		case <-time.After(10 * time.Second):
+			t.Fatalf("message delivered to %d nodes out of %d", len(delivered), len(nodes)-1)
+		}
+	}
+
+	for addr, count := range delivered {
+		require.Equal(t, 1, count, addr)
+	}
+
+	for _, n := range nodes {
+		require.NoError(t, n.Close())
+	}
+}
+
+func TestSend(t *testing.T) {
+	nodes := startNetwork(t, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := nodes[2].Listen(ctx, &rusk.Null{})
+	require.NoError(t, err)
+
+	_, err = nodes[1].Send(ctx, &rusk.SendMessage{TargetAddress: nodes[2].Addr().String(), Message: []byte("pippo")})
+	require.NoError(t, err)
+
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("pippo"), msg.Message)
+	require.Equal(t, nodes[1].Addr().String(), msg.Metadata.SrcAddress)
+	require.Zero(t, msg.Metadata.KadcastHeight)
+
+	resp, err := nodes[0].AliveNodes(ctx, &rusk.AliveNodesRequest{MaxNodes: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Address, 1)
+
+	// Closing the node terminates the stream
+	require.NoError(t, nodes[2].Close())
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+
+	require.NoError(t, nodes[0].Close())
+	require.NoError(t, nodes[1].Close())
+}
+
+// TestUnsolicitedPeers ensures the peers only enter the routing table once
+// they answered a Ping, so that spoofed messages can not poison it.
+func TestUnsolicitedPeers(t *testing.T) {
+	nodes := startNetwork(t, 1)
+
+	other, err := NewNode(Config{Address: "127.0.0.1:0", RefreshInterval: time.Hour})
+	require.NoError(t, err)
+	require.NoError(t, other.Start())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := nodes[0].Listen(ctx, &rusk.Null{})
+	require.NoError(t, err)
+
+	// Neither a message nor a Pong with an unknown token add the sender
+	require.NoError(t, other.write(nodes[0].Addr(), kindPong, make([]byte, tokenSize), 0))
+	_, err = other.Send(ctx, &rusk.SendMessage{TargetAddress: nodes[0].Addr().String(), Message: []byte("pippo")})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	require.False(t, nodes[0].Table().Contains(other.Addr()))
+
+	// A Ping is answered, and the sender enters the table once it answers
+	// the Ping back
+	require.NoError(t, other.ping(nodes[0].Addr()))
+	require.Eventually(t, func() bool {
+		return nodes[0].Table().Contains(other.Addr()) && other.Table().Contains(nodes[0].Addr())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, other.Close())
+	require.NoError(t, nodes[0].Close())
+}
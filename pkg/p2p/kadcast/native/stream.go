@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package native
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// stream of the messages received by a Node. It mimics the client side of
+// the Listen stream of the network service of Rusk.
+type stream struct {
+	ctx  context.Context
+	node *Node
+	msgs chan *rusk.Message
+}
+
+// Recv returns the next message received by the Node. It fails once the
+// context of the stream is canceled, or the Node is closed.
+func (s *stream) Recv() (*rusk.Message, error) {
+	select {
+	case msg := <-s.msgs:
+		return msg, nil
+	case <-s.ctx.Done():
+		s.node.removeListener(s)
+		return nil, status.FromContextError(s.ctx.Err()).Err()
+	case <-s.node.quit:
+		s.node.removeListener(s)
+		return nil, errClosed
+	}
+}
+
+// Header implements grpc.ClientStream.
+func (s *stream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+// Trailer implements grpc.ClientStream.
+func (s *stream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+// CloseSend implements grpc.ClientStream.
+func (s *stream) CloseSend() error {
+	return nil
+}
+
+// Context implements grpc.ClientStream.
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg implements grpc.ClientStream. Nothing is sent on a Listen stream.
+func (s *stream) SendMsg(interface{}) error {
+	return errors.New("send on a listen stream")
+}
+
+// RecvMsg implements grpc.ClientStream.
+func (s *stream) RecvMsg(m interface{}) error {
+	msg, err := s.Recv()
+	if err != nil {
+		return err
+	}
+
+	out, ok := m.(*rusk.Message)
+	if !ok {
+		return errors.New("unexpected message type")
+	}
+
+	out.Message = msg.Message
+	out.Metadata = msg.Metadata
+
+	return nil
+}
@@ -0,0 +1,231 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package native
+
+import (
+	"crypto/sha256"
+	"math/bits"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// IDLen is the length of a node ID, in bytes.
+	IDLen = 16
+	// Buckets is the number of buckets of the routing table, one per bit of
+	// the ID.
+	Buckets = IDLen * 8
+	// BucketSize is the max number of peers of a bucket.
+	BucketSize = 20
+)
+
+// ID of a Kadcast node. It is derived from the address of the node, so that
+// a node can not choose its position in the network.
+type ID [IDLen]byte
+
+// ComputeID returns the ID of the node listening on addr.
+func ComputeID(addr *net.UDPAddr) ID {
+	var id ID
+
+	h := sha256.Sum256([]byte(addr.String()))
+	copy(id[:], h[:])
+
+	return id
+}
+
+// BucketIndex returns the index of the bucket of b in the routing table of a,
+// that is the position of the highest bit set in their XOR distance. It
+// returns -1 if a and b are equal.
+func BucketIndex(a, b ID) int {
+	for i := 0; i < IDLen; i++ {
+		if x := a[i] ^ b[i]; x != 0 {
+			return (IDLen-i)*8 - 1 - bits.LeadingZeros8(x)
+		}
+	}
+
+	return -1
+}
+
+// closer returns true if a is closer than b to target.
+func closer(target, a, b ID) bool {
+	for i := 0; i < IDLen; i++ {
+		da, db := a[i]^target[i], b[i]^target[i]
+		if da != db {
+			return da < db
+		}
+	}
+
+	return false
+}
+
+// Peer is an entry of the routing table.
+type Peer struct {
+	ID       ID
+	Addr     *net.UDPAddr
+	LastSeen time.Time
+}
+
+// Table is the routing table of a node: the known peers, bucketed by their
+// XOR distance to the node. Each bucket is ordered from the least to the most
+// recently seen peer.
+type Table struct {
+	self ID
+
+	lock    sync.RWMutex
+	buckets [Buckets][]Peer
+}
+
+// NewTable creates the routing table of the node self.
+func NewTable(self ID) *Table {
+	return &Table{self: self}
+}
+
+// Seen records an answer from the peer listening on addr, inserting it into
+// its bucket if there is room. A full bucket makes room by evicting its least
+// recently seen peer, if it was not seen for longer than stale. It returns
+// true if the peer is in the table.
+func (t *Table) Seen(addr *net.UDPAddr, stale time.Duration) bool {
+	id := ComputeID(addr)
+
+	idx := BucketIndex(t.self, id)
+	if idx < 0 {
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	bucket := t.buckets[idx]
+	now := time.Now()
+
+	for i, p := range bucket {
+		if p.ID == id {
+			// Move to the tail, as the most recently seen
+			p.LastSeen = now
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			t.buckets[idx] = append(bucket, p)
+
+			return true
+		}
+	}
+
+	if len(bucket) >= BucketSize {
+		if now.Sub(bucket[0].LastSeen) < stale {
+			return false
+		}
+
+		bucket = bucket[1:]
+	}
+
+	t.buckets[idx] = append(bucket, Peer{ID: id, Addr: addr, LastSeen: now})
+	return true
+}
+
+// Contains returns true if the peer listening on addr is in the table.
+func (t *Table) Contains(addr *net.UDPAddr) bool {
+	id := ComputeID(addr)
+
+	idx := BucketIndex(t.self, id)
+	if idx < 0 {
+		return false
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for _, p := range t.buckets[idx] {
+		if p.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Expire removes the peers which were not seen since before.
+func (t *Table) Expire(before time.Time) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	n := 0
+
+	for i, bucket := range t.buckets {
+		kept := bucket[:0]
+
+		for _, p := range bucket {
+			if p.LastSeen.Before(before) {
+				n++
+				continue
+			}
+
+			kept = append(kept, p)
+		}
+
+		t.buckets[i] = kept
+	}
+
+	return n
+}
+
+// Delegates returns up to beta random peers of a bucket.
+func (t *Table) Delegates(idx, beta int) []Peer {
+	t.lock.RLock()
+	bucket := append([]Peer{}, t.buckets[idx]...)
+	t.lock.RUnlock()
+
+	rand.Shuffle(len(bucket), func(i, j int) { bucket[i], bucket[j] = bucket[j], bucket[i] })
+
+	if len(bucket) > beta {
+		bucket = bucket[:beta]
+	}
+
+	return bucket
+}
+
+// Closest returns up to n peers, sorted by their distance to target.
+func (t *Table) Closest(target ID, n int) []Peer {
+	peers := t.All()
+
+	sort.Slice(peers, func(i, j int) bool {
+		return closer(target, peers[i].ID, peers[j].ID)
+	})
+
+	if len(peers) > n {
+		peers = peers[:n]
+	}
+
+	return peers
+}
+
+// All returns all the peers of the table.
+func (t *Table) All() []Peer {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var peers []Peer
+	for _, bucket := range t.buckets {
+		peers = append(peers, bucket...)
+	}
+
+	return peers
+}
+
+// Len returns the number of peers of the table.
+func (t *Table) Len() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	n := 0
+	for _, bucket := range t.buckets {
+		n += len(bucket)
+	}
+
+	return n
+}
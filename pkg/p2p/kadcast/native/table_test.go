@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package native
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketIndex(t *testing.T) {
+	var a, b ID
+
+	require.Equal(t, -1, BucketIndex(a, b))
+
+	b[IDLen-1] = 1
+	require.Equal(t, 0, BucketIndex(a, b))
+
+	b[IDLen-1] = 0x80
+	require.Equal(t, 7, BucketIndex(a, b))
+
+	b[0] = 0x01
+	require.Equal(t, Buckets-8, BucketIndex(a, b))
+
+	b[0] = 0x80
+	require.Equal(t, Buckets-1, BucketIndex(a, b))
+}
+
+func TestTable(t *testing.T) {
+	self := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 7000}
+	table := NewTable(ComputeID(self))
+
+	// The node itself is never inserted
+	require.False(t, table.Seen(self, time.Minute))
+
+	for port := 7001; port < 7101; port++ {
+		table.Seen(&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}, time.Minute)
+	}
+
+	// Buckets are capped
+	require.Less(t, table.Len(), 100)
+
+	for i := 0; i < Buckets; i++ {
+		require.LessOrEqual(t, len(table.Delegates(i, BucketSize+1)), BucketSize)
+
+		for _, p := range table.Delegates(i, 3) {
+			require.Equal(t, i, BucketIndex(table.self, p.ID))
+		}
+	}
+
+	// Closest peers are sorted by distance
+	target := ComputeID(&net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1})
+	closest := table.Closest(target, 5)
+	require.Len(t, closest, 5)
+
+	for i := 1; i < len(closest); i++ {
+		require.True(t, closer(target, closest[i-1].ID, closest[i].ID))
+	}
+
+	// Stale peers are evicted from full buckets
+	n := table.Len()
+	require.Equal(t, n, table.Expire(time.Now().Add(time.Second)))
+	require.Zero(t, table.Len())
+}
+
+func TestWire(t *testing.T) {
+	b, err := encode(kindFindNodes, 7000, []byte("pippo"))
+	require.NoError(t, err)
+
+	h, payload, err := decode(b)
+	require.NoError(t, err)
+	require.Equal(t, kindFindNodes, h.kind)
+	require.Equal(t, uint16(7000), h.port)
+	require.Equal(t, []byte("pippo"), payload)
+
+	// Each message is unique
+	b2, err := encode(kindFindNodes, 7000, []byte("pippo"))
+	require.NoError(t, err)
+	require.NotEqual(t, b, b2)
+
+	_, _, err = decode(b[:headerSize-1])
+	require.Error(t, err)
+
+	b[0] = 0xff
+	_, _, err = decode(b)
+	require.Error(t, err)
+
+	peers := []Peer{
+		{Addr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 7000}},
+		{Addr: &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 7000}},
+		{Addr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 7001}},
+	}
+
+	addrs, err := decodeNodes(encodeNodes(peers), BucketSize)
+	require.NoError(t, err)
+	require.Len(t, addrs, 2)
+	require.Equal(t, "10.0.0.1:7000", addrs[0].String())
+	require.Equal(t, "10.0.0.2:7001", addrs[1].String())
+
+	_, err = decodeNodes(encodeNodes(peers), 1)
+	require.Error(t, err)
+
+	_, err = decodeNodes([]byte{1, 2, 3}, BucketSize)
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package native
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// kind of a Kadcast message.
+type kind uint8
+
+const (
+	kindPing kind = iota
+	kindPong
+	kindFindNodes
+	kindNodes
+	kindBroadcast
+	kindSend
+)
+
+const (
+	// headerSize is the size of the header of a message: kind, listening
+	// port of the sender and nonce.
+	headerSize = 1 + 2 + 8
+	// nodeEntrySize is the size of an entry of a Nodes message: IPv4 and
+	// port.
+	nodeEntrySize = net.IPv4len + 2
+	// tokenSize is the size of the payload of the Ping and Pong messages: a
+	// random token, echoed by the Pong.
+	tokenSize = 8
+)
+
+var (
+	errShortMessage = errors.New("message too short")
+	byteOrder       = binary.LittleEndian
+)
+
+// header of a Kadcast message. The sender is identified by the source IP of
+// the packets and by the port it listens on, as the packets are sent from a
+// random port. The nonce makes each message unique, so that the FEC layer
+// does not mistake it for an already decoded one.
+type header struct {
+	kind  kind
+	port  uint16
+	nonce uint64
+}
+
+// encode a message of the given kind.
+func encode(k kind, port uint16, payload []byte) ([]byte, error) {
+	b := make([]byte, headerSize, headerSize+len(payload))
+	b[0] = byte(k)
+	byteOrder.PutUint16(b[1:3], port)
+
+	if _, err := rand.Read(b[3:headerSize]); err != nil {
+		return nil, err
+	}
+
+	return append(b, payload...), nil
+}
+
+// decode the header of a message, and return its payload.
+func decode(b []byte) (header, []byte, error) {
+	if len(b) < headerSize {
+		return header{}, nil, errShortMessage
+	}
+
+	h := header{
+		kind:  kind(b[0]),
+		port:  byteOrder.Uint16(b[1:3]),
+		nonce: byteOrder.Uint64(b[3:headerSize]),
+	}
+
+	if h.kind > kindSend {
+		return header{}, nil, fmt.Errorf("unknown message kind %d", h.kind)
+	}
+
+	if h.port == 0 {
+		return header{}, nil, errors.New("invalid sender port")
+	}
+
+	return h, b[headerSize:], nil
+}
+
+// encodeNodes encodes the IPv4 addresses of the peers. Other addresses are
+// skipped.
+func encodeNodes(peers []Peer) []byte {
+	b := make([]byte, 0, len(peers)*nodeEntrySize)
+
+	for _, p := range peers {
+		ip := p.Addr.IP.To4()
+		if ip == nil {
+			continue
+		}
+
+		b = append(b, ip...)
+		b = append(b, 0, 0)
+		byteOrder.PutUint16(b[len(b)-2:], uint16(p.Addr.Port))
+	}
+
+	return b
+}
+
+// decodeNodes decodes the payload of a Nodes message, up to limit addresses.
+func decodeNodes(b []byte, limit int) ([]*net.UDPAddr, error) {
+	if len(b)%nodeEntrySize != 0 {
+		return nil, errors.New("invalid nodes payload")
+	}
+
+	n := len(b) / nodeEntrySize
+	if n > limit {
+		return nil, fmt.Errorf("too many nodes %d", n)
+	}
+
+	addrs := make([]*net.UDPAddr, 0, n)
+
+	for i := 0; i < n; i++ {
+		e := b[i*nodeEntrySize : (i+1)*nodeEntrySize]
+
+		port := byteOrder.Uint16(e[net.IPv4len:])
+		if port == 0 {
+			continue
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		copy(ip, e[:net.IPv4len])
+
+		addrs = append(addrs, &net.UDPAddr{IP: ip, Port: int(port)})
+	}
+
+	return addrs, nil
+}
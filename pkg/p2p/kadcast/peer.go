@@ -8,17 +8,21 @@ package kadcast
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast/native"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast/writer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/container/ring"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 	logger "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -36,6 +40,12 @@ type Peer struct {
 	reader  *Reader
 
 	connections []*grpc.ClientConn
+	node        *native.Node
+	health      *Health
+
+	// queue shapes the messages sent by the writers, see EnableShaping
+	shaping *shaping.Config
+	queue   *shaping.Queue
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -54,12 +64,27 @@ func NewKadcastPeer(pCtx context.Context, eventBus *eventbus.EventBus, processor
 	}
 }
 
+// EnableShaping queues the messages sent to the Kadcast service by class,
+// within the limits of cfg. It has to be called before Launch.
+func (p *Peer) EnableShaping(cfg shaping.Config) {
+	p.shaping = &cfg
+}
+
 // Launch starts kadcast peer reader and writers, binds them to the event buss,
 // and establishes connection to rusk network server.
 func (p *Peer) Launch() {
-	// gRPC rusk client
 	cfg := config.Get().Kadcast
 
+	if p.shaping != nil {
+		p.queue = shaping.NewQueue(p.ctx, *p.shaping)
+	}
+
+	if cfg.Native.Enabled {
+		p.launchNative()
+		return
+	}
+
+	// gRPC rusk client
 	log.WithField("grpc_addr", cfg.Grpc.Address).
 		WithField("grpc_network", cfg.Grpc.Network).
 		Info("launch peer connections")
@@ -72,36 +97,122 @@ func (p *Peer) Launch() {
 	p.createWriters(ctx)
 
 	// a reader for Kadcast messages
-	client, conn := CreateNetworkClient(ctx, cfg.Grpc.Network, cfg.Grpc.Address, cfg.Grpc.DialTimeout)
+	client, conn := p.dial(ctx)
 	p.reader = NewReader(ctx, p.eventBus, p.gossip, p.processor, client)
 
 	p.connections = append(p.connections, conn)
 
+	p.health = NewHealth(p.connections...)
+	p.health.pending = p.pending
+	p.reader.health = p.health
+
 	go p.reader.Listen()
 }
 
-func (p *Peer) createWriters(ctx context.Context) {
+// launchNative starts a native Kadcast node, and binds the reader and writers
+// to it in place of the network service of Rusk.
+func (p *Peer) launchNative() {
 	cfg := config.Get().Kadcast
 
+	node, err := native.NewNode(native.Config{
+		Address:          cfg.Address,
+		Listen:           cfg.Native.Listen,
+		Bootstrap:        cfg.BootstrapAddr,
+		Beta:             cfg.Native.Beta,
+		RedundancyFactor: cfg.Native.RedundancyFactor,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("could not create native kadcast node")
+	}
+
+	if err = node.Start(); err != nil {
+		log.WithError(err).Fatal("could not start native kadcast node")
+	}
+
+	log.WithField("addr", node.Addr().String()).
+		Info("launch native kadcast node")
+
+	p.node = node
+
+	p.writers = append(p.writers,
+		writer.NewBroadcast(p.ctx, p.eventBus, p.gossip, node, p.queue),
+		writer.NewSendToOne(p.ctx, p.eventBus, p.gossip, node, p.queue),
+		writer.NewSendToMany(p.ctx, p.eventBus, p.gossip, node, p.queue),
+	)
+
+	p.reader = NewReader(p.ctx, p.eventBus, p.gossip, p.processor, node)
+
+	p.health = NewHealth()
+	p.health.pending = p.pending
+	p.reader.health = p.health
+
+	go p.reader.Listen()
+}
+
+// Health returns the Health of the connections to the Kadcast service.
+func (p *Peer) Health() *Health {
+	return p.health
+}
+
+func (p *Peer) createWriters(ctx context.Context) {
 	// Broadcast
-	client, conn := CreateNetworkClient(ctx, cfg.Grpc.Network, cfg.Grpc.Address, cfg.Grpc.DialTimeout)
-	w := writer.NewBroadcast(ctx, p.eventBus, p.gossip, client)
+	client, conn := p.dial(ctx)
+	w := writer.NewBroadcast(ctx, p.eventBus, p.gossip, client, p.queue)
 	p.connections = append(p.connections, conn)
 	p.writers = append(p.writers, w)
 
 	// Send to One
-	client, conn = CreateNetworkClient(ctx, cfg.Grpc.Network, cfg.Grpc.Address, cfg.Grpc.DialTimeout)
-	w = writer.NewSendToOne(ctx, p.eventBus, p.gossip, client)
+	client, conn = p.dial(ctx)
+	w = writer.NewSendToOne(ctx, p.eventBus, p.gossip, client, p.queue)
 	p.connections = append(p.connections, conn)
 	p.writers = append(p.writers, w)
 
 	// Send to Many
-	client, conn = CreateNetworkClient(ctx, cfg.Grpc.Network, cfg.Grpc.Address, cfg.Grpc.DialTimeout)
-	w = writer.NewSendToMany(ctx, p.eventBus, p.gossip, client)
+	client, conn = p.dial(ctx)
+	w = writer.NewSendToMany(ctx, p.eventBus, p.gossip, client, p.queue)
 	p.connections = append(p.connections, conn)
 	p.writers = append(p.writers, w)
 }
 
+// dial creates a client connection to the Kadcast service. The connection is
+// established in the background, and re-established with an exponential
+// backoff whenever it breaks, so that the node survives restarts of the
+// service.
+func (p *Peer) dial(ctx context.Context) (rusk.NetworkClient, *grpc.ClientConn) {
+	cfg := config.Get().Kadcast
+
+	conn, err := dial(ctx, cfg.Grpc.Network, cfg.Grpc.Address,
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  minReconnectDelay,
+				Multiplier: 2,
+				Jitter:     0.2,
+				MaxDelay:   maxReconnectDelay,
+			},
+			MinConnectTimeout: time.Duration(cfg.Grpc.DialTimeout) * time.Second,
+		}),
+	)
+	if err != nil {
+		// Only a misconfiguration makes a non-blocking dial fail
+		log.WithError(err).Fatal("could not create kadcast client")
+	}
+
+	return rusk.NewNetworkClient(conn), conn
+}
+
+// pending returns the number of messages the writers are waiting to deliver.
+func (p *Peer) pending() int {
+	n := 0
+
+	for _, w := range p.writers {
+		if pw, ok := w.(interface{ Pending() int }); ok {
+			n += pw.Pending()
+		}
+	}
+
+	return n
+}
+
 // Close terminates kadcast peer instance.
 func (p *Peer) Close() {
 	if p.ctx != nil {
@@ -119,11 +230,28 @@ func (p *Peer) Close() {
 		}
 	}
 
+	if p.node != nil {
+		_ = p.node.Close()
+	}
+
 	log.Info("peer closed")
 }
 
-// CreateNetworkClient creates a client for the Kadcast network layer.
-func CreateNetworkClient(ctx context.Context, network, address string, dialTimeout int) (rusk.NetworkClient, *grpc.ClientConn) {
+// CreateNetworkClient creates a client for the Kadcast network layer. It
+// blocks until the connection is established, or dialTimeout seconds elapse.
+func CreateNetworkClient(ctx context.Context, network, address string, dialTimeout int) (rusk.NetworkClient, *grpc.ClientConn, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, time.Duration(dialTimeout)*time.Second)
+	defer cancel()
+
+	conn, err := dial(dialCtx, network, address, grpc.WithBlock())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rusk.NewNetworkClient(conn), conn, nil
+}
+
+func dial(ctx context.Context, network, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	var prefix string
 
 	switch network {
@@ -132,20 +260,13 @@ func CreateNetworkClient(ctx context.Context, network, address string, dialTimeo
 	case "unix":
 		prefix = "unix://"
 	default:
-		panic("unsupported network " + network)
+		return nil, fmt.Errorf("unsupported network %s", network)
 	}
 
 	ctxWithVersion := InjectRuskVersion(ctx)
+	opts = append(opts, grpc.WithInsecure(), grpc.WithAuthority("dummy"))
 
-	dialCtx, cancel := context.WithTimeout(ctxWithVersion, time.Duration(dialTimeout)*time.Second)
-	defer cancel()
-
-	conn, err := grpc.DialContext(dialCtx, prefix+address, grpc.WithInsecure(), grpc.WithAuthority("dummy"), grpc.WithBlock())
-	if err != nil {
-		log.Panic(err)
-	}
-
-	return rusk.NewNetworkClient(conn), conn
+	return grpc.DialContext(ctxWithVersion, prefix+address, opts...)
 }
 
 // InjectRuskVersion injects the rusk version into the grpc headers.
@@ -69,7 +69,10 @@ func TestListenStreamReader(t *testing.T) {
 	ruskc := rusk.NewNetworkClient(grpcConn)
 
 	// create our kadcli (gRPC) Reader
-	r := NewReader(context.Background(), eb, g, p, ruskc)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := NewReader(ctx, eb, g, p, ruskc)
 
 	// subscribe to gRPC stream
 	go r.Listen()
@@ -104,7 +107,7 @@ func TestNoBroadcastWriter(t *testing.T) {
 	cli := NewMockNetworkClient(rcvChan)
 
 	// create our kadcli Writer
-	_ = writer.NewBroadcast(context.Background(), eb, g, cli)
+	_ = writer.NewBroadcast(context.Background(), eb, g, cli, nil)
 
 	// create a mock message
 	buf, err := createBlockMessage()
@@ -141,7 +144,7 @@ func TestBroadcastWriter(t *testing.T) {
 	cli := NewMockNetworkClient(rcvChan)
 
 	// create our kadcli Writer
-	_ = writer.NewBroadcast(context.Background(), eb, g, cli)
+	_ = writer.NewBroadcast(context.Background(), eb, g, cli, nil)
 
 	// create a mock message
 	buf, err := createBlockMessage()
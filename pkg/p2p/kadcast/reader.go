@@ -11,18 +11,28 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"io"
+	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/traffic"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
 // Reader is a proxy between Kadcast grpc service and Message Processor. It
 // receives a wire message and ,if it's valid, redirects it to Message Processor.
 // In addition, it turns any response message from Processor into
@@ -33,6 +43,7 @@ type Reader struct {
 	gossip    *protocol.Gossip
 
 	client rusk.NetworkClient
+	health *Health
 
 	ctx context.Context
 }
@@ -48,29 +59,62 @@ func NewReader(ctx context.Context, publisher eventbus.Publisher, g *protocol.Go
 	}
 }
 
-// Listen starts accepting and processing stream data.
+// Listen starts accepting and processing stream data. The stream is reopened
+// with an exponential backoff whenever it fails, until the context of the
+// Reader is canceled.
 func (r *Reader) Listen() {
+	go r.supervise()
+}
+
+func (r *Reader) supervise() {
+	b := util.Backoff{Min: minReconnectDelay, Max: maxReconnectDelay}
+
+	for {
+		err := r.listen(&b)
+		reportStreamErr(err)
+
+		if r.ctx.Err() != nil {
+			r.health.setDown(r.ctx.Err())
+			return
+		}
+
+		r.health.setDown(err)
+
+		delay := b.Next()
+		log.WithField("retry_in", delay).Info("reopening kadcast stream")
+
+		select {
+		case <-time.After(delay):
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// listen opens the stream, and processes its messages until it fails.
+func (r *Reader) listen(b *util.Backoff) error {
 	// create stream handler
 	stream, err := r.client.Listen(r.ctx, &rusk.Null{})
 	if err != nil {
-		log.WithError(err).Fatal("open stream error")
-		return
+		return err
 	}
 
+	r.health.setListening()
+
 	// listen for messages
-	go func() {
-		for {
-			// receive a message
-			msg, err := stream.Recv()
-			if err != nil {
-				reportStreamErr(err)
-				return
-			}
-
-			// Message received
-			go r.processMessage(msg)
+	for {
+		// receive a message
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
 		}
-	}()
+
+		// The stream is healthy, a later failure starts the backoff over
+		b.Reset()
+
+		// Message received
+		go r.processMessage(msg)
+	}
 }
 
 // processMessage propagates the received kadcast message into the event bus.
@@ -94,6 +138,15 @@ func (r *Reader) processMessage(msg *rusk.Message) {
 	if !checksum.Verify(m, cs) {
 		log.WithError(errors.New("invalid checksum")).
 			Warnln("error verifying message cs")
+		r.processor.Penalize(msg.Metadata.SrcAddress, reputation.BadChecksum)
+		return
+	}
+
+	traffic.Record(traffic.Kadcast, msg.Metadata.SrcAddress, traffic.In, m, len(msg.Message))
+
+	// drop messages of banned peers. Kadcast has no connection to close.
+	if r.processor.IsBanned(msg.Metadata.SrcAddress) {
+		log.WithField("r_addr", msg.Metadata.SrcAddress).Trace("message from banned peer dropped")
 		return
 	}
 
@@ -103,7 +156,7 @@ func (r *Reader) processMessage(msg *rusk.Message) {
 	}
 
 	// collect (process) the message
-	respBufs, err := r.processor.Collect(msg.Metadata.SrcAddress, m, nil, protocol.FullNode, &metadata)
+	respBufs, err := r.processor.Collect(msg.Metadata.SrcAddress, m, nil, protocol.FullNode, protocol.LocalFeatures, &metadata)
 	if err != nil {
 		var topic string
 		if len(m) > 0 {
@@ -128,6 +181,11 @@ func (r *Reader) processMessage(msg *rusk.Message) {
 func reportStreamErr(err error) {
 	m := "listener_loop terminated"
 
+	if err == io.EOF {
+		log.Warn(m + ": stream closed by the server")
+		return
+	}
+
 	s, ok := status.FromError(err)
 	if ok {
 		switch s.Code() {
@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package kadcast
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast/writer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/rusk"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestReaderReconnect ensures the Reader waits for the Kadcast service to be
+// up, and reopens the stream whenever it terminates.
+func TestReaderReconnect(t *testing.T) {
+	rcvChan := make(chan message.Message, 10)
+	errChan := make(chan error, 1)
+
+	eb := eventbus.New()
+	p := peer.NewMessageProcessor(eb)
+	g := protocol.NewGossip()
+
+	p.Register(topics.Block, func(_ string, m message.Message) ([]bytes.Buffer, error) {
+		rcvChan <- m
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The client is created before the service is up
+	conn, err := dial(ctx, "tcp", RUSK_ADDR)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	h := NewHealth(conn)
+	r := NewReader(ctx, eb, g, p, rusk.NewNetworkClient(conn))
+	r.health = h
+
+	r.Listen()
+
+	require.Error(t, h.Check(ctx))
+
+	srv, err := NewRuskMock(g, errChan)
+	require.NoError(t, err)
+
+	defer srv.Stop()
+
+	// The mock closes the stream after each message, so that receiving two
+	// messages requires a reconnection
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errChan:
+			t.Fatal(err)
+		case m := <-rcvChan:
+			require.Equal(t, topics.Block, m.Category())
+		case <-time.After(10 * time.Second):
+			t.Fatal("no message received")
+		}
+	}
+
+	require.NotZero(t, h.Reconnects())
+}
+
+// TestWriterOutbox ensures the writers buffer the messages while the Kadcast
+// service is unavailable, and deliver them in order once it is back.
+func TestWriterOutbox(t *testing.T) {
+	rcvChan := make(chan *rusk.BroadcastMessage, 10)
+
+	eb := eventbus.New()
+	g := protocol.NewGossip()
+
+	cli := &unavailableNetworkClient{MockNetworkClient: NewMockNetworkClient(rcvChan)}
+	atomic.StoreInt32(&cli.failures, 3)
+
+	w := writer.NewBroadcast(context.Background(), eb, g, cli, nil)
+
+	for h := byte(2); h <= 4; h++ {
+		buf, err := createBlockMessage()
+		require.NoError(t, err)
+
+		m := message.NewWithMetadata(topics.Block, *buf, &message.Metadata{KadcastHeight: h})
+		require.Empty(t, eb.Publish(topics.Kadcast, m))
+	}
+
+	for h := uint32(1); h <= 3; h++ {
+		select {
+		case m := <-rcvChan:
+			require.Equal(t, h, m.KadcastHeight)
+		case <-time.After(10 * time.Second):
+			t.Fatal("buffered message not delivered")
+		}
+	}
+
+	require.Zero(t, w.(*writer.Broadcast).Pending())
+}
+
+// unavailableNetworkClient fails with codes.Unavailable a number of times,
+// before delivering the messages.
+type unavailableNetworkClient struct {
+	*MockNetworkClient
+	failures int32
+}
+
+func (c *unavailableNetworkClient) Broadcast(ctx context.Context, in *rusk.BroadcastMessage, opts ...grpc.CallOption) (*rusk.Null, error) {
+	if atomic.AddInt32(&c.failures, -1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+
+	return c.MockNetworkClient.Broadcast(ctx, in, opts...)
+}
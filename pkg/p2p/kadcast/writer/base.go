@@ -11,6 +11,8 @@ import (
 	"context"
 	"encoding/binary"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/traffic"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -35,9 +37,27 @@ type Base struct {
 	client         rusk.NetworkClient
 	ctx            context.Context
 
+	// messages waiting for the Kadcast service to be available
+	outbox *outbox
+
+	// queue shapes the messages sent to the Kadcast service. It is shared by
+	// the writers, and nil if the traffic is not shaped.
+	queue *shaping.Queue
+
 	topic topics.Topic
 }
 
+// push queues a message written by a writer, according to its topic and
+// priority.
+func (b *Base) push(data []byte, priority byte, send func() error) error {
+	var class shaping.Class
+	if len(data) > 0 {
+		class = shaping.Classify(topics.Topic(data[0]), priority)
+	}
+
+	return b.queue.Push(class, len(data), send)
+}
+
 // Send is a wrapper of rusk.NetworkClient Send method.
 func (b *Base) Send(data []byte, addr string) error {
 	// create the message
@@ -58,8 +78,16 @@ func (b *Base) Send(data []byte, addr string) error {
 		Message:       blob.Bytes(),
 	}
 
-	// send message
-	if _, err := b.client.Send(b.ctx, m); err != nil {
+	// send message, or buffer it if the service is unavailable
+	err := b.outbox.deliver(func() error {
+		if err := b.send(m); err != nil {
+			return err
+		}
+
+		traffic.Record(traffic.Kadcast, addr, traffic.Out, data, len(m.Message))
+		return nil
+	})
+	if err != nil {
 		log.WithError(err).Warn("failed to send message")
 		return err
 	}
@@ -67,6 +95,17 @@ func (b *Base) Send(data []byte, addr string) error {
 	return nil
 }
 
+func (b *Base) send(m *rusk.SendMessage) error {
+	_, err := b.client.Send(b.ctx, m)
+	return err
+}
+
+// Pending returns the number of messages waiting for the Kadcast service to
+// be available.
+func (b *Base) Pending() int {
+	return b.outbox.Len()
+}
+
 // Close unsubscribes.
 func (b *Base) Close() error {
 	b.subscriber.Unsubscribe(b.topic, b.subscriptionID)
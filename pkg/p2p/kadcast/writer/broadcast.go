@@ -11,6 +11,8 @@ import (
 	"context"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/traffic"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -27,13 +29,15 @@ type Broadcast struct {
 }
 
 // NewBroadcast ...
-func NewBroadcast(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip, rusk rusk.NetworkClient) ring.Writer {
+func NewBroadcast(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip, rusk rusk.NetworkClient, q *shaping.Queue) ring.Writer {
 	b := &Broadcast{
 		Base: Base{
 			subscriber: s,
 			gossip:     g,
 			client:     rusk,
 			ctx:        ctx,
+			outbox:     newOutbox(ctx),
+			queue:      q,
 			topic:      topics.Kadcast,
 		},
 	}
@@ -44,33 +48,26 @@ func NewBroadcast(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip
 
 // Subscribe subscribes to eventbus Kadcast messages.
 func (w *Broadcast) Subscribe() {
-	mapper := func(t topics.Topic) byte {
-		const (
-			High = byte(1)
-			Low  = byte(0)
-		)
-
-		switch t {
-		case topics.NewBlock, topics.Candidate, topics.GetCandidate,
-			topics.Reduction, topics.AggrAgreement, topics.Agreement:
-			return High
-		default:
-			return Low
-		}
-	}
-
 	// Kadcast subs
-	l := eventbus.NewStreamListenerWithParams(w, MaxWriterQueueSize, mapper)
+	l := eventbus.NewStreamListenerWithParams(w, MaxWriterQueueSize, shaping.Priority)
 	w.subscriptionID = w.subscriber.Subscribe(w.topic, l)
 }
 
 // Write implements. ring.Writer.
 func (w *Broadcast) Write(data []byte, metadata *message.Metadata, priority byte) (int, error) {
-	if err := w.broadcast(data, metadata, priority); err != nil {
-		// A returned error here is treated as unrecoverable err.
-		log.WithError(err).WithField("handler", w.topic.String()).Warn("write failed")
+	send := func() error {
+		err := w.broadcast(data, metadata, priority)
+		if err != nil {
+			// A returned error here is treated as unrecoverable err.
+			log.WithError(err).WithField("handler", w.topic.String()).Warn("write failed")
+		}
+
+		return err
 	}
 
+	// The errors are logged by send, be it queued or not
+	_ = w.push(data, priority, send)
+
 	return 0, nil
 }
 
@@ -101,8 +98,16 @@ func (w *Broadcast) broadcast(data []byte, metadata *message.Metadata, _ byte) e
 		KadcastHeight: uint32(h),
 		Message:       b.Bytes(),
 	}
-	// broadcast message
-	if _, err := w.client.Broadcast(w.ctx, m); err != nil {
+	// broadcast message, or buffer it if the service is unavailable
+	err := w.outbox.deliver(func() error {
+		if _, err := w.client.Broadcast(w.ctx, m); err != nil {
+			return err
+		}
+
+		traffic.Record(traffic.Kadcast, traffic.Broadcast, traffic.Out, data, len(m.Message))
+		return nil
+	})
+	if err != nil {
 		log.WithError(err).Warn("failed to broadcast message")
 		return err
 	}
@@ -0,0 +1,160 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package writer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxOutboxSize is the max number of messages buffered while the Kadcast
+	// service is unavailable. The oldest messages are dropped beyond it.
+	MaxOutboxSize = 512
+	// MaxOutboxAge is how long a message stays buffered. Consensus messages
+	// are stale past it, so the outbox only covers short outages.
+	MaxOutboxAge = 30 * time.Second
+
+	minRetryDelay = 250 * time.Millisecond
+	maxRetryDelay = 5 * time.Second
+)
+
+type pending struct {
+	send   func() error
+	queued time.Time
+}
+
+// outbox buffers the messages which could not be delivered because the
+// Kadcast service is unavailable, and retries them in order with an
+// exponential backoff.
+type outbox struct {
+	ctx context.Context
+
+	lock     sync.Mutex
+	msgs     []pending
+	retrying bool
+}
+
+func newOutbox(ctx context.Context) *outbox {
+	return &outbox{ctx: ctx}
+}
+
+// deliver calls send, or buffers it if the service is unavailable. Messages
+// are buffered behind any message already waiting, to preserve their order.
+func (o *outbox) deliver(send func() error) error {
+	o.lock.Lock()
+	waiting := len(o.msgs) > 0
+	o.lock.Unlock()
+
+	if !waiting {
+		err := send()
+		if !unavailable(err) {
+			return err
+		}
+	}
+
+	o.push(send)
+	return nil
+}
+
+// Len returns the number of buffered messages.
+func (o *outbox) Len() int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	return len(o.msgs)
+}
+
+func (o *outbox) push(send func() error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if len(o.msgs) >= MaxOutboxSize {
+		o.msgs = o.msgs[1:]
+		log.Warn("kadcast outbox full, dropping oldest message")
+	}
+
+	o.msgs = append(o.msgs, pending{send: send, queued: time.Now()})
+
+	if !o.retrying {
+		o.retrying = true
+		go o.retry()
+	}
+}
+
+// retry delivers the buffered messages until the outbox is empty, or the
+// context is canceled.
+func (o *outbox) retry() {
+	b := util.Backoff{Min: minRetryDelay, Max: maxRetryDelay}
+
+	for {
+		select {
+		case <-time.After(b.Next()):
+		case <-o.ctx.Done():
+			return
+		}
+
+		for {
+			p, ok := o.peek()
+			if !ok {
+				return
+			}
+
+			if time.Since(p.queued) > MaxOutboxAge {
+				o.pop()
+				log.Debug("kadcast outbox message expired")
+				continue
+			}
+
+			err := p.send()
+			if unavailable(err) {
+				break
+			}
+
+			if err != nil {
+				log.WithError(err).Warn("buffered message failed")
+			}
+
+			o.pop()
+			b.Reset()
+		}
+	}
+}
+
+// peek returns the oldest message. If the outbox is empty, retrying is
+// stopped.
+func (o *outbox) peek() (pending, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if len(o.msgs) == 0 {
+		o.retrying = false
+		return pending{}, false
+	}
+
+	return o.msgs[0], true
+}
+
+func (o *outbox) pop() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if len(o.msgs) > 0 {
+		o.msgs = o.msgs[1:]
+	}
+}
+
+// unavailable returns true if err is caused by the connection to the Kadcast
+// service being down.
+func unavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -25,13 +26,15 @@ type SendToMany struct {
 }
 
 // NewSendToMany ...
-func NewSendToMany(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip, rusk rusk.NetworkClient) ring.Writer {
+func NewSendToMany(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip, rusk rusk.NetworkClient, q *shaping.Queue) ring.Writer {
 	w := &SendToMany{
 		Base: Base{
 			subscriber: s,
 			gossip:     g,
 			client:     rusk,
 			ctx:        ctx,
+			outbox:     newOutbox(ctx),
+			queue:      q,
 			topic:      topics.KadcastSendToMany,
 		},
 	}
@@ -48,10 +51,18 @@ func (w *SendToMany) Subscribe() {
 
 // Write ...
 func (w *SendToMany) Write(data []byte, metadata *message.Metadata, priority byte) (int, error) {
-	if err := w.sendToMany(data, metadata, priority); err != nil {
-		log.WithError(err).Warn("write failed")
+	send := func() error {
+		err := w.sendToMany(data, metadata, priority)
+		if err != nil {
+			log.WithError(err).Warn("write failed")
+		}
+
+		return err
 	}
 
+	// The errors are logged by send, be it queued or not
+	_ = w.push(data, priority, send)
+
 	return 0, nil
 }
 
@@ -61,18 +72,20 @@ func (w *SendToMany) sendToMany(data []byte, metadata *message.Metadata, _ byte)
 		return errors.New("empty message metadata")
 	}
 
-	// get N active nodes
-	req := &rusk.AliveNodesRequest{MaxNodes: uint32(metadata.NumNodes)}
+	// get N active nodes, or wait for the service to be available
+	return w.outbox.deliver(func() error {
+		req := &rusk.AliveNodesRequest{MaxNodes: uint32(metadata.NumNodes)}
 
-	resp, err := w.client.AliveNodes(w.ctx, req)
-	if err != nil {
-		log.WithError(err).Warn("get alive nodes failed")
-		return err
-	}
+		resp, err := w.client.AliveNodes(w.ctx, req)
+		if err != nil {
+			log.WithError(err).Warn("get alive nodes failed")
+			return err
+		}
 
-	for _, addr := range resp.Address {
-		_ = w.Send(data, addr)
-	}
+		for _, addr := range resp.Address {
+			_ = w.Send(data, addr)
+		}
 
-	return nil
+		return nil
+	})
 }
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -25,13 +26,15 @@ type SendToOne struct {
 }
 
 // NewSendToOne ...
-func NewSendToOne(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip, rusk rusk.NetworkClient) ring.Writer {
+func NewSendToOne(ctx context.Context, s eventbus.Subscriber, g *protocol.Gossip, rusk rusk.NetworkClient, q *shaping.Queue) ring.Writer {
 	w := &SendToOne{
 		Base: Base{
 			subscriber: s,
 			gossip:     g,
 			client:     rusk,
 			ctx:        ctx,
+			outbox:     newOutbox(ctx),
+			queue:      q,
 			topic:      topics.KadcastSendToOne,
 		},
 	}
@@ -47,10 +50,18 @@ func (w *SendToOne) Subscribe() {
 
 // Write implements. ring.Writer.
 func (w *SendToOne) Write(data []byte, metadata *message.Metadata, priority byte) (int, error) {
-	if err := w.sendToOne(data, metadata, priority); err != nil {
-		log.WithError(err).Warn("write failed")
+	send := func() error {
+		err := w.sendToOne(data, metadata, priority)
+		if err != nil {
+			log.WithError(err).Warn("write failed")
+		}
+
+		return err
 	}
 
+	// The errors are logged by send, be it queued or not
+	_ = w.push(data, priority, send)
+
 	return 0, nil
 }
 
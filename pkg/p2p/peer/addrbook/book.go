@@ -0,0 +1,422 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package addrbook
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "addrbook")
+
+const (
+	// maxNew is the capacity of the new table.
+	maxNew = 1024
+	// maxTried is the capacity of the tried table.
+	maxTried = 256
+	// maxNewPerGroup and maxTriedPerGroup cap the addresses of a single
+	// group, so that an attacker controlling a few networks can not fill the
+	// book.
+	maxNewPerGroup   = 32
+	maxTriedPerGroup = 8
+	// maxAttempts is the number of consecutive failed attempts after which a
+	// new address is forgotten.
+	maxAttempts = 3
+)
+
+// ErrInvalidAddress is returned when adding an address which is not a
+// routable host:port.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// KnownAddress is an address of the book.
+type KnownAddress struct {
+	Addr        string    `json:"addr"`
+	Source      string    `json:"source"`
+	Attempts    int       `json:"attempts"`
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success"`
+	Tried       bool      `json:"tried"`
+}
+
+// Book is the address book of the peers. It follows the new/tried scheme:
+// addresses heard from the network enter the new table, and move to the
+// tried table once connected successfully. Both tables are bucketed by
+// network Group, each bucket being capped.
+type Book struct {
+	lock  sync.Mutex
+	path  string
+	addrs map[string]*KnownAddress
+
+	// buckets count the addresses of each group, per table
+	newBuckets   map[string]int
+	triedBuckets map[string]int
+
+	rnd *rand.Rand
+}
+
+// New creates a Book persisted at path, loading the addresses stored there
+// if any. If path is empty, the Book is kept in memory.
+func New(path string) (*Book, error) {
+	b := &Book{
+		path:         path,
+		addrs:        make(map[string]*KnownAddress),
+		newBuckets:   make(map[string]int),
+		triedBuckets: make(map[string]int),
+		rnd:          rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+
+	if path == "" {
+		return b, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []*KnownAddress
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+
+	for _, ka := range stored {
+		if _, ok := b.addrs[ka.Addr]; ok || validate(ka.Addr) != nil {
+			continue
+		}
+
+		b.addrs[ka.Addr] = ka
+		b.buckets(ka.Tried)[Group(ka.Addr)]++
+	}
+
+	return b, nil
+}
+
+// Save persists the Book, if it has a path.
+func (b *Book) Save() error {
+	if b.path == "" {
+		return nil
+	}
+
+	b.lock.Lock()
+
+	stored := make([]*KnownAddress, 0, len(b.addrs))
+	for _, ka := range b.addrs {
+		stored = append(stored, ka)
+	}
+
+	data, err := json.Marshal(stored)
+	b.lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that a crash does not leave a
+	// truncated book behind
+	tmp := b.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, b.path)
+}
+
+// Add an address heard from src to the new table. It returns false if the
+// address is already known, or if its bucket is full.
+func (b *Book) Add(addr, src string) (bool, error) {
+	if err := validate(addr); err != nil {
+		return false, err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.addrs[addr]; ok {
+		return false, nil
+	}
+
+	group := Group(addr)
+	if b.newBuckets[group] >= maxNewPerGroup {
+		return false, nil
+	}
+
+	if b.count(false) >= maxNew {
+		b.evictNew()
+	}
+
+	b.addrs[addr] = &KnownAddress{Addr: addr, Source: src}
+	b.newBuckets[group]++
+
+	return true, nil
+}
+
+// MarkFailed records a failed connection attempt to addr. New addresses
+// failing too many times in a row are forgotten.
+func (b *Book) MarkFailed(addr string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ka, ok := b.addrs[addr]
+	if !ok {
+		return
+	}
+
+	ka.Attempts++
+	ka.LastAttempt = time.Now()
+
+	if !ka.Tried && ka.Attempts >= maxAttempts {
+		b.remove(ka)
+	}
+}
+
+// MarkGood records a successful connection to addr, moving it to the tried
+// table. If the bucket of addr is full in the tried table, its least
+// recently successful address goes back to the new table.
+func (b *Book) MarkGood(addr string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	ka, ok := b.addrs[addr]
+	if !ok {
+		if validate(addr) != nil {
+			return
+		}
+
+		ka = &KnownAddress{Addr: addr}
+		b.addrs[addr] = ka
+		b.newBuckets[Group(addr)]++
+	}
+
+	ka.Attempts = 0
+	ka.LastSuccess = time.Now()
+
+	if ka.Tried {
+		return
+	}
+
+	group := Group(addr)
+	if b.triedBuckets[group] >= maxTriedPerGroup || b.count(true) >= maxTried {
+		b.demoteOldest(group)
+	}
+
+	b.newBuckets[group]--
+	b.triedBuckets[group]++
+	ka.Tried = true
+}
+
+// Pick a random address to connect to, out of the groups not in exclude.
+// Tried and new addresses are picked with the same probability. It returns
+// false if no address is eligible.
+func (b *Book) Pick(exclude map[string]struct{}) (string, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var tried, fresh []string
+
+	for addr, ka := range b.addrs {
+		if _, ok := exclude[Group(addr)]; ok {
+			continue
+		}
+
+		if ka.Tried {
+			tried = append(tried, addr)
+		} else {
+			fresh = append(fresh, addr)
+		}
+	}
+
+	// Iterating a map is not deterministic, sort so that the pick depends
+	// only on the random source
+	sort.Strings(tried)
+	sort.Strings(fresh)
+
+	candidates := fresh
+	if len(tried) > 0 && (len(fresh) == 0 || b.rnd.Intn(2) == 0) {
+		candidates = tried
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	return candidates[b.rnd.Intn(len(candidates))], true
+}
+
+// Sample returns up to n random addresses of the book, tried addresses
+// first.
+func (b *Book) Sample(n int) []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var tried, fresh []string
+
+	for addr, ka := range b.addrs {
+		if ka.Tried {
+			tried = append(tried, addr)
+		} else {
+			fresh = append(fresh, addr)
+		}
+	}
+
+	sort.Strings(tried)
+	sort.Strings(fresh)
+	b.rnd.Shuffle(len(tried), func(i, j int) { tried[i], tried[j] = tried[j], tried[i] })
+	b.rnd.Shuffle(len(fresh), func(i, j int) { fresh[i], fresh[j] = fresh[j], fresh[i] })
+
+	res := append(tried, fresh...)
+	if len(res) > n {
+		res = res[:n]
+	}
+
+	return res
+}
+
+// Len returns the number of addresses in the new and tried tables.
+func (b *Book) Len() (int, int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.count(false), b.count(true)
+}
+
+// buckets returns the buckets of a table.
+func (b *Book) buckets(tried bool) map[string]int {
+	if tried {
+		return b.triedBuckets
+	}
+
+	return b.newBuckets
+}
+
+// count returns the size of a table.
+// The lock must be held.
+func (b *Book) count(tried bool) int {
+	n := 0
+	for _, c := range b.buckets(tried) {
+		n += c
+	}
+
+	return n
+}
+
+// remove forgets an address.
+// The lock must be held.
+func (b *Book) remove(ka *KnownAddress) {
+	buckets := b.buckets(ka.Tried)
+	group := Group(ka.Addr)
+
+	buckets[group]--
+	if buckets[group] <= 0 {
+		delete(buckets, group)
+	}
+
+	delete(b.addrs, ka.Addr)
+}
+
+// evictNew forgets the new address with the most failed attempts, the
+// oldest attempt breaking ties.
+// The lock must be held.
+func (b *Book) evictNew() {
+	var worst *KnownAddress
+
+	for _, ka := range b.addrs {
+		if ka.Tried {
+			continue
+		}
+
+		if worst == nil || ka.Attempts > worst.Attempts ||
+			(ka.Attempts == worst.Attempts && ka.LastAttempt.Before(worst.LastAttempt)) {
+			worst = ka
+		}
+	}
+
+	if worst != nil {
+		lg.WithField("addr", worst.Addr).Trace("evicting new address")
+		b.remove(worst)
+	}
+}
+
+// demoteOldest moves the least recently successful tried address of a group
+// (or of the whole table if the group has none) back to the new table.
+// The lock must be held.
+func (b *Book) demoteOldest(group string) {
+	var oldest *KnownAddress
+
+	inGroup := b.triedBuckets[group] > 0
+
+	for _, ka := range b.addrs {
+		if !ka.Tried || (inGroup && Group(ka.Addr) != group) {
+			continue
+		}
+
+		if oldest == nil || ka.LastSuccess.Before(oldest.LastSuccess) {
+			oldest = ka
+		}
+	}
+
+	if oldest == nil {
+		return
+	}
+
+	g := Group(oldest.Addr)
+
+	b.triedBuckets[g]--
+	if b.triedBuckets[g] <= 0 {
+		delete(b.triedBuckets, g)
+	}
+
+	oldest.Tried = false
+	b.newBuckets[g]++
+}
+
+func validate(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host == "" || port == "" || port == "0" {
+		return ErrInvalidAddress
+	}
+
+	if ip := net.ParseIP(host); ip != nil && (ip.IsUnspecified() || ip.IsMulticast()) {
+		return ErrInvalidAddress
+	}
+
+	return nil
+}
+
+// Group returns the network group of an address: the /16 of an IPv4, the /32
+// of an IPv6, or the host itself otherwise. Peers are diversified across
+// groups, so that an attacker needs addresses in many networks to eclipse a
+// node.
+func Group(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(16, 32)).String() + "/16"
+	}
+
+	return ip.Mask(net.CIDRMask(32, 128)).String() + "/32"
+}
@@ -0,0 +1,171 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package addrbook_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/addrbook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	require.Equal(t, "10.1.0.0/16", addrbook.Group("10.1.2.3:7000"))
+	require.Equal(t, addrbook.Group("10.1.2.3:7000"), addrbook.Group("10.1.200.1:8000"))
+	require.NotEqual(t, addrbook.Group("10.1.2.3:7000"), addrbook.Group("10.2.2.3:7000"))
+	require.Equal(t, "2001:db8::/32", addrbook.Group("[2001:db8:1::1]:7000"))
+	require.Equal(t, "dusk.network", addrbook.Group("dusk.network:7000"))
+}
+
+func TestAdd(t *testing.T) {
+	b, err := addrbook.New("")
+	require.NoError(t, err)
+
+	added, err := b.Add("10.0.0.1:7000", "seed")
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// Known addresses are not added twice
+	added, err = b.Add("10.0.0.1:7000", "seed")
+	require.NoError(t, err)
+	require.False(t, added)
+
+	// Invalid addresses are rejected
+	for _, addr := range []string{"pippo", "10.0.0.1", "0.0.0.0:7000", "10.0.0.1:0"} {
+		_, err = b.Add(addr, "seed")
+		require.Equal(t, addrbook.ErrInvalidAddress, err, addr)
+	}
+
+	// A single group can not fill the book
+	for i := 0; i < 256; i++ {
+		_, err = b.Add(fmt.Sprintf("10.0.%d.2:7000", i), "seed")
+		require.NoError(t, err)
+	}
+
+	fresh, tried := b.Len()
+	require.Equal(t, 32, fresh)
+	require.Zero(t, tried)
+}
+
+func TestMarkGoodAndFailed(t *testing.T) {
+	b, err := addrbook.New("")
+	require.NoError(t, err)
+
+	_, err = b.Add("10.0.0.1:7000", "seed")
+	require.NoError(t, err)
+	_, err = b.Add("10.1.0.1:7000", "seed")
+	require.NoError(t, err)
+
+	b.MarkGood("10.0.0.1:7000")
+
+	fresh, tried := b.Len()
+	require.Equal(t, 1, fresh)
+	require.Equal(t, 1, tried)
+
+	// New addresses failing repeatedly are forgotten
+	for i := 0; i < 3; i++ {
+		b.MarkFailed("10.1.0.1:7000")
+	}
+
+	fresh, _ = b.Len()
+	require.Zero(t, fresh)
+
+	// Tried addresses are kept
+	for i := 0; i < 3; i++ {
+		b.MarkFailed("10.0.0.1:7000")
+	}
+
+	_, tried = b.Len()
+	require.Equal(t, 1, tried)
+
+	// Tried addresses of a single group are capped, the oldest going back
+	// to the new table
+	for i := 0; i < 10; i++ {
+		b.MarkGood(fmt.Sprintf("10.0.%d.2:7000", i))
+	}
+
+	fresh, tried = b.Len()
+	require.Equal(t, 8, tried)
+	require.Equal(t, 3, fresh)
+}
+
+func TestPick(t *testing.T) {
+	b, err := addrbook.New("")
+	require.NoError(t, err)
+
+	_, ok := b.Pick(nil)
+	require.False(t, ok)
+
+	_, err = b.Add("10.0.0.1:7000", "seed")
+	require.NoError(t, err)
+	_, err = b.Add("10.1.0.1:7000", "seed")
+	require.NoError(t, err)
+
+	// Excluded groups are never picked
+	exclude := map[string]struct{}{addrbook.Group("10.0.0.1:7000"): {}}
+
+	for i := 0; i < 10; i++ {
+		addr, ok := b.Pick(exclude)
+		require.True(t, ok)
+		require.Equal(t, "10.1.0.1:7000", addr)
+	}
+
+	exclude[addrbook.Group("10.1.0.1:7000")] = struct{}{}
+
+	_, ok = b.Pick(exclude)
+	require.False(t, ok)
+}
+
+func TestSample(t *testing.T) {
+	b, err := addrbook.New("")
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err = b.Add(fmt.Sprintf("10.%d.0.1:7000", i), "seed")
+		require.NoError(t, err)
+	}
+
+	b.MarkGood("10.3.0.1:7000")
+
+	sample := b.Sample(3)
+	require.Len(t, sample, 3)
+	// Tried addresses come first
+	require.Equal(t, "10.3.0.1:7000", sample[0])
+
+	require.Len(t, b.Sample(10), 5)
+}
+
+func TestPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addrbook")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "peers.json")
+
+	b, err := addrbook.New(path)
+	require.NoError(t, err)
+
+	_, err = b.Add("10.0.0.1:7000", "seed")
+	require.NoError(t, err)
+	_, err = b.Add("10.1.0.1:7000", "seed")
+	require.NoError(t, err)
+
+	b.MarkGood("10.1.0.1:7000")
+	require.NoError(t, b.Save())
+
+	b, err = addrbook.New(path)
+	require.NoError(t, err)
+
+	fresh, tried := b.Len()
+	require.Equal(t, 1, fresh)
+	require.Equal(t, 1, tried)
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -39,6 +40,7 @@ type connectFunc func(context.Context, *Reader, *Writer)
 type Connector struct {
 	eventBus      eventbus.Broker
 	gossip        *protocol.Gossip
+	processor     *MessageProcessor
 	readerFactory *ReaderFactory
 
 	l net.Listener
@@ -46,8 +48,22 @@ type Connector struct {
 	lock     sync.RWMutex
 	registry map[string]struct{}
 
+	// outbound connections are maintained by the Manager, if any
+	manager *Manager
+
 	services protocol.ServiceFlag
 
+	// encrypted transport, see EnableEncryption
+	identity          *Identity
+	requireEncryption bool
+	pins              Pins
+
+	// compression of the messages, see EnableCompression
+	compression bool
+
+	// shaping of the outbound traffic, see EnableShaping
+	shaping *shaping.Config
+
 	connectFunc connectFunc
 }
 
@@ -68,6 +84,7 @@ func NewConnector(eb eventbus.Broker, gossip *protocol.Gossip, port string,
 	c := &Connector{
 		eventBus:      eb,
 		gossip:        gossip,
+		processor:     processor,
 		readerFactory: NewReaderFactory(processor),
 		l:             listener,
 		registry:      make(map[string]struct{}),
@@ -81,7 +98,7 @@ func NewConnector(eb eventbus.Broker, gossip *protocol.Gossip, port string,
 		for {
 			conn, err := c.l.Accept()
 			if err != nil {
-				plog.WithField("r_addr", conn.RemoteAddr().String()).
+				plog.WithField("l_addr", c.l.Addr().String()).
 					WithError(err).
 					Warnln("error accepting conn request")
 				return
@@ -98,6 +115,59 @@ func NewConnector(eb eventbus.Broker, gossip *protocol.Gossip, port string,
 	return c
 }
 
+// EnableEncryption upgrades the connections to the encrypted transport when
+// the peers support it. If required is true, peers not supporting it are
+// refused. The peers dialed at a pinned address must present the pinned
+// identity, see Pins.
+func (c *Connector) EnableEncryption(id *Identity, required bool, pins Pins) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.identity = id
+	c.requireEncryption = required
+	c.pins = pins
+}
+
+// EnableCompression compresses the messages exchanged with the peers which
+// support it.
+func (c *Connector) EnableCompression() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.compression = true
+}
+
+// EnableShaping queues the messages sent to each peer by class, within the
+// limits of cfg.
+func (c *Connector) EnableShaping(cfg shaping.Config) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.shaping = &cfg
+}
+
+// newConnection wraps conn into a Connection, with the encryption,
+// compression and shaping settings of the Connector.
+func (c *Connector) newConnection(conn net.Conn) *Connection {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	pConn := NewConnection(conn, c.gossip)
+	if c.identity != nil {
+		pConn.EnableEncryption(c.identity, c.requireEncryption, c.pins)
+	}
+
+	if c.compression {
+		pConn.EnableCompression()
+	}
+
+	if c.shaping != nil {
+		pConn.EnableShaping(*c.shaping)
+	}
+
+	return pConn
+}
+
 // Close the listener.
 func (c *Connector) Close() error {
 	return c.l.Close()
@@ -127,13 +197,18 @@ func (c *Connector) logPeerCount() {
 
 // ProcessNewAddress will handle a new Addr message from the network.
 // Satisfies the peer.ProcessorFunc interface.
+// If a Manager maintains the outbound connections, the address is handed over
+// to it. Otherwise, it is dialed right away.
 func (c *Connector) ProcessNewAddress(srcPeerID string, m message.Message) ([]bytes.Buffer, error) {
-	maxConn := 0
-	if maxConn == 0 {
-		maxConn = defaultMaxConnections
+	c.lock.RLock()
+	manager := c.manager
+	c.lock.RUnlock()
+
+	if manager != nil {
+		return manager.ProcessNewAddress(srcPeerID, m)
 	}
 
-	if c.GetConnectionsCount() > maxConn {
+	if c.GetConnectionsCount() >= defaultMaxConnections {
 		return nil, errors.New("max amount of connections reached")
 	}
 
@@ -144,13 +219,22 @@ func (c *Connector) ProcessNewAddress(srcPeerID string, m message.Message) ([]by
 // Connect dials a connection with its string, then on succession
 // we pass the connection and the address to the OnConn method.
 func (c *Connector) Connect(addr string) error {
+	return c.connect(addr, nil)
+}
+
+// connect dials addr and performs the handshake. If done is not nil, it is
+// called once the connection terminates.
+func (c *Connector) connect(addr string, done func()) error {
+	if c.processor.IsBanned(addr) {
+		return errors.New("peer is banned")
+	}
+
 	conn, err := c.Dial(addr)
 	if err != nil {
 		return err
 	}
 
-	c.proposeConnection(conn)
-	return nil
+	return c.proposeConnection(conn, done)
 }
 
 // Dial dials up a connection, given its address string.
@@ -168,10 +252,19 @@ func (c *Connector) Dial(addr string) (net.Conn, error) {
 }
 
 func (c *Connector) acceptConnection(conn net.Conn) {
-	pConn := NewConnection(conn, c.gossip)
-	peerReader := c.readerFactory.SpawnReader(pConn)
 	raddr := conn.RemoteAddr().String()
 
+	if c.processor.IsBanned(raddr) {
+		plog.WithField("r_addr", raddr).WithField("type", "inbound").
+			Debugln("refused connection from banned peer")
+
+		_ = conn.Close()
+		return
+	}
+
+	pConn := c.newConnection(conn)
+	peerReader := c.readerFactory.SpawnReader(pConn)
+
 	if err := peerReader.Accept(c.services); err != nil {
 		plog.WithField("r_addr", raddr).
 			WithError(err).
@@ -193,15 +286,15 @@ func (c *Connector) acceptConnection(conn net.Conn) {
 	}()
 }
 
-func (c *Connector) proposeConnection(conn net.Conn) {
-	pConn := NewConnection(conn, c.gossip)
+func (c *Connector) proposeConnection(conn net.Conn, done func()) error {
+	pConn := c.newConnection(conn)
 	peerWriter := NewWriter(pConn, c.eventBus)
 
 	if err := peerWriter.Connect(c.services); err != nil {
 		plog.WithField("r_addr", conn.RemoteAddr().String()).
 			WithField("type", "outbound").
 			WithError(err).Warnln("error performing handshake")
-		return
+		return err
 	}
 
 	address := peerWriter.Addr()
@@ -213,10 +306,29 @@ func (c *Connector) proposeConnection(conn net.Conn) {
 
 	c.addPeer(peerWriter.Addr())
 
+	// Ask the peer for more addresses, when an address book is maintained
+	c.lock.RLock()
+	manager := c.manager
+	c.lock.RUnlock()
+
+	if manager != nil {
+		getAddrs := topics.GetAddrs.ToBuffer()
+		if _, err := (&GossipConnector{Connection: pConn}).Write(getAddrs.Bytes(), nil, 0); err != nil {
+			plog.WithField("r_addr", address).WithError(err).
+				Warnln("could not request addresses")
+		}
+	}
+
 	go func() {
 		c.connectFunc(context.Background(), peerReader, peerWriter)
 		c.removePeer(peerWriter.Addr())
+
+		if done != nil {
+			done()
+		}
 	}()
+
+	return nil
 }
 
 func (c *Connector) addPeer(address string) {
@@ -248,6 +360,14 @@ func (c *Connector) removePeer(address string) {
 	}
 }
 
+func (c *Connector) hasPeer(address string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	_, ok := c.registry[address]
+	return ok
+}
+
 // GetConnectionsCount returns the amount of active connections the node has.
 func (c *Connector) GetConnectionsCount() int {
 	c.lock.RLock()
@@ -16,9 +16,13 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 )
 
-// Handshake with another peer.
+// Handshake with another peer. Once the versions are exchanged, the
+// connection uses the features supported by both peers: it is upgraded to the
+// encrypted transport, and the messages are compressed, if negotiated.
 func (w *Writer) Handshake(services protocol.ServiceFlag) error {
-	if err := w.writeLocalMsgVersion(w.gossip, services); err != nil {
+	features := w.localFeatures()
+
+	if err := w.writeLocalMsgVersion(w.gossip, services, features); err != nil {
 		return err
 	}
 
@@ -26,37 +30,59 @@ func (w *Writer) Handshake(services protocol.ServiceFlag) error {
 		return err
 	}
 
-	peerServices, err := w.readRemoteMsgVersion()
+	version, err := w.readRemoteMsgVersion()
 	if err != nil {
 		return err
 	}
 
-	w.services = peerServices
-	return w.writeVerAck(w.gossip)
+	w.negotiate(version, features)
+
+	if err := w.writeVerAck(w.gossip); err != nil {
+		return err
+	}
+
+	return w.negotiateEncryption(true)
 }
 
-// Handshake with another peer.
+// Handshake with another peer. Once the versions are exchanged, the
+// connection uses the features supported by both peers: it is upgraded to the
+// encrypted transport, and the messages are compressed, if negotiated.
 func (p *Reader) Handshake(services protocol.ServiceFlag) error {
-	peerServices, err := p.readRemoteMsgVersion()
+	features := p.localFeatures()
+
+	version, err := p.readRemoteMsgVersion()
 	if err != nil {
 		return err
 	}
 
-	p.services = peerServices
+	p.negotiate(version, features)
 
 	if err := p.writeVerAck(p.gossip); err != nil {
 		return err
 	}
 
-	if err := p.writeLocalMsgVersion(p.gossip, services); err != nil {
+	if err := p.writeLocalMsgVersion(p.gossip, services, features); err != nil {
 		return err
 	}
 
-	return p.readVerAck()
+	if err := p.readVerAck(); err != nil {
+		return err
+	}
+
+	return p.negotiateEncryption(false)
+}
+
+// negotiate keeps the node type and the version of the peer, and the features
+// supported by both ends.
+func (c *Connection) negotiate(peer *VersionMessage, local protocol.Features) {
+	c.services = peer.Services.NodeType()
+	c.version = peer.Version
+	c.features = local.Negotiate(peer.Features)
+	c.compressed = c.features.Has(protocol.FeatureCompressedFrames)
 }
 
-func (c *Connection) writeLocalMsgVersion(g *protocol.Gossip, services protocol.ServiceFlag) error {
-	message, e := c.createVersionBuffer(services)
+func (c *Connection) writeLocalMsgVersion(g *protocol.Gossip, services protocol.ServiceFlag, features protocol.Features) error {
+	message, e := c.createVersionBuffer(services, features)
 	if e != nil {
 		return e
 	}
@@ -65,6 +91,8 @@ func (c *Connection) writeLocalMsgVersion(g *protocol.Gossip, services protocol.
 		return err
 	}
 
+	c.localVersion = append([]byte(nil), message.Bytes()...)
+
 	if err := g.Process(message); err != nil {
 		return err
 	}
@@ -73,39 +101,44 @@ func (c *Connection) writeLocalMsgVersion(g *protocol.Gossip, services protocol.
 	return e
 }
 
-func (c *Connection) readRemoteMsgVersion() (protocol.ServiceFlag, error) {
+func (c *Connection) readRemoteMsgVersion() (*VersionMessage, error) {
 	msgBytes, err := c.ReadMessage()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	m, cs, err := checksum.Extract(msgBytes)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if !checksum.Verify(m, cs) {
-		return 0, errors.New("invalid checksum")
+		return nil, errors.New("invalid checksum")
 	}
 
+	c.remoteVersion = append([]byte(nil), m...)
 	decodedMsg := bytes.NewBuffer(m)
 
 	topic, err := topics.Extract(decodedMsg)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if topic != topics.Version {
-		return 0, fmt.Errorf("did not receive the expected '%s' message - got %s",
+		return nil, fmt.Errorf("did not receive the expected '%s' message - got %s",
 			topics.Version, topic)
 	}
 
 	version, err := decodeVersionMessage(decodedMsg)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return version.Services, verifyVersionMessage(version)
+	if err := verifyVersionMessage(version); err != nil {
+		return nil, err
+	}
+
+	return version, nil
 }
 
 func (c *Connection) readVerAck() error {
@@ -155,10 +188,10 @@ func (c *Connection) writeVerAck(g *protocol.Gossip) error {
 	return nil
 }
 
-func (c *Connection) createVersionBuffer(services protocol.ServiceFlag) (*bytes.Buffer, error) {
+func (c *Connection) createVersionBuffer(services protocol.ServiceFlag, features protocol.Features) (*bytes.Buffer, error) {
 	version := protocol.NodeVer
 
-	message, err := newVersionMessageBuffer(version, services)
+	message, err := newVersionMessageBuffer(version, services, features)
 	if err != nil {
 		return nil, err
 	}
@@ -167,11 +200,11 @@ func (c *Connection) createVersionBuffer(services protocol.ServiceFlag) (*bytes.
 }
 
 func verifyVersionMessage(v *VersionMessage) error {
-	if protocol.NodeVer.Major != v.Version.Major {
-		return errors.New("version mismatch")
+	if err := protocol.CheckNodeVersion(v.Version); err != nil {
+		return err
 	}
 
-	if v.Services != protocol.FullNode {
+	if !v.Services.KnownNodeType() {
 		return errors.New("unknown service flag")
 	}
 
@@ -7,8 +7,13 @@
 package peer
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,7 +21,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	_ "github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 )
 
@@ -59,3 +68,383 @@ func TestHandshake(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// handshakePair performs the handshake between two ends of a pipe, and
+// returns the outbound and inbound connections along with the errors of both
+// ends.
+func handshakePair(out, in *Identity, required bool, opts ...func(w, r *Connection)) (*Writer, *Reader, error, error) {
+	client, srv := net.Pipe()
+
+	eb := eventbus.New()
+
+	wConn := NewConnection(client, protocol.NewGossip())
+	if out != nil {
+		wConn.EnableEncryption(out, required, nil)
+	}
+
+	rConn := NewConnection(srv, protocol.NewGossip())
+	if in != nil {
+		rConn.EnableEncryption(in, required, nil)
+	}
+
+	for _, opt := range opts {
+		opt(wConn, rConn)
+	}
+
+	pw := NewWriter(wConn, eb)
+	pr := NewReaderFactory(NewMessageProcessor(eb)).SpawnReader(rConn)
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- pr.Handshake(protocol.FullNode)
+	}()
+
+	wErr := pw.Handshake(protocol.FullNode)
+	if wErr != nil {
+		// Unblock the inbound end
+		_ = client.Close()
+	}
+
+	rErr := <-errChan
+	if rErr != nil {
+		_ = srv.Close()
+	}
+
+	return pw, pr, wErr, rErr
+}
+
+func TestEncryptedHandshake(t *testing.T) {
+	outID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	inID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	pw, pr, wErr, rErr := handshakePair(outID, inID, true)
+	require.NoError(t, wErr)
+	require.NoError(t, rErr)
+
+	defer func() {
+		_ = pw.Conn.Close()
+		_ = pr.Conn.Close()
+	}()
+
+	// Both ends authenticated each other, and kept the node type only
+	require.Equal(t, inID.PublicKey(), pw.PeerIdentity())
+	require.Equal(t, outID.PublicKey(), pr.PeerIdentity())
+	require.Equal(t, protocol.FullNode, pw.services)
+	require.Equal(t, protocol.FullNode, pr.services)
+
+	// Messages go through the encrypted transport
+	go func() {
+		_ = pw.writeVerAck(pw.gossip)
+	}()
+
+	require.NoError(t, pr.readVerAck())
+}
+
+func TestLegacyHandshake(t *testing.T) {
+	id, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	// An encrypted peer falls back to plaintext with a legacy peer
+	pw, pr, wErr, rErr := handshakePair(id, nil, false)
+	require.NoError(t, wErr)
+	require.NoError(t, rErr)
+	require.Nil(t, pw.PeerIdentity())
+	require.Nil(t, pr.PeerIdentity())
+
+	_ = pw.Conn.Close()
+
+	// Unless encryption is required
+	_, _, wErr, _ = handshakePair(id, nil, true)
+	require.ErrorIs(t, wErr, ErrEncryptionRequired)
+}
+
+// TestPinnedIdentity ensures a dialed peer must present the identity pinned
+// for its address, over the encrypted transport.
+func TestPinnedIdentity(t *testing.T) {
+	outID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	inID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	otherID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	// The remote address of a pipe is "pipe"
+	pin := func(id *Identity) func(w, _ *Connection) {
+		return func(w, _ *Connection) {
+			w.pins = Pins{"pipe": id.PublicKey()}
+		}
+	}
+
+	pw, pr, wErr, rErr := handshakePair(outID, inID, false, pin(inID))
+	require.NoError(t, wErr)
+	require.NoError(t, rErr)
+
+	_ = pw.Conn.Close()
+	_ = pr.Conn.Close()
+
+	_, _, wErr, _ = handshakePair(outID, inID, false, pin(otherID))
+	require.ErrorIs(t, wErr, ErrIdentityMismatch)
+
+	// A pinned peer can not fall back to the plain transport
+	_, _, wErr, _ = handshakePair(outID, nil, false, pin(inID))
+	require.ErrorIs(t, wErr, ErrEncryptionRequired)
+}
+
+// TestTranscriptMismatch ensures the features altered on the wire before the
+// encrypted transport fail the handshake.
+func TestTranscriptMismatch(t *testing.T) {
+	outID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	inID, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	client, mitmIn := net.Pipe()
+	mitmOut, srv := net.Pipe()
+
+	defer func() {
+		for _, c := range []net.Conn{client, mitmIn, mitmOut, srv} {
+			_ = c.Close()
+		}
+	}()
+
+	// Strip the compression from the version message of the initiator, and
+	// relay the rest
+	go func() {
+		g := protocol.NewGossip()
+
+		b, err := g.ReadMessage(mitmIn)
+		if err != nil {
+			return
+		}
+
+		m, _, _ := checksum.Extract(b)
+		buf := bytes.NewBuffer(m)
+		_, _ = topics.Extract(buf)
+
+		v, err := decodeVersionMessage(buf)
+		if err != nil {
+			return
+		}
+
+		msg, _ := newVersionMessageBuffer(v.Version, v.Services, v.Features&^protocol.FeatureCompressedFrames)
+		_ = topics.Prepend(msg, topics.Version)
+		_ = g.Process(msg)
+
+		if _, err := mitmOut.Write(msg.Bytes()); err != nil {
+			return
+		}
+
+		go func() {
+			_, _ = io.Copy(mitmIn, mitmOut)
+		}()
+
+		_, _ = io.Copy(mitmOut, mitmIn)
+	}()
+
+	eb := eventbus.New()
+
+	wConn := NewConnection(client, protocol.NewGossip())
+	wConn.EnableEncryption(outID, true, nil)
+	wConn.EnableCompression()
+
+	rConn := NewConnection(srv, protocol.NewGossip())
+	rConn.EnableEncryption(inID, true, nil)
+	rConn.EnableCompression()
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- NewReaderFactory(NewMessageProcessor(eb)).SpawnReader(rConn).Handshake(protocol.FullNode)
+	}()
+
+	wErr := NewWriter(wConn, eb).Handshake(protocol.FullNode)
+	rErr := <-errChan
+
+	require.ErrorIs(t, wErr, ErrTranscriptMismatch)
+	require.ErrorIs(t, rErr, ErrTranscriptMismatch)
+}
+
+// TestVersionServices ensures the capabilities are only advertised in the
+// features, as the nodes predating them refuse any service besides the node
+// type.
+func TestVersionServices(t *testing.T) {
+	id, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	client, srv := net.Pipe()
+
+	defer func() {
+		_ = client.Close()
+		_ = srv.Close()
+	}()
+
+	wConn := NewConnection(client, protocol.NewGossip())
+	wConn.EnableEncryption(id, false, nil)
+	wConn.EnableCompression()
+
+	go func() {
+		_ = NewWriter(wConn, eventbus.New()).Handshake(protocol.FullNode)
+	}()
+
+	v, err := NewConnection(srv, protocol.NewGossip()).readRemoteMsgVersion()
+	require.NoError(t, err)
+	require.Equal(t, protocol.FullNode, v.Services)
+	require.True(t, v.Features.Has(protocol.FeatureEncryptedTransport|protocol.FeatureCompressedFrames))
+}
+
+// TestFeatureNegotiation ensures the connections use the features supported
+// by both ends.
+func TestFeatureNegotiation(t *testing.T) {
+	pw, pr, wErr, rErr := handshakePair(nil, nil, false, func(w, _ *Connection) {
+		w.EnableCompression()
+	})
+	require.NoError(t, wErr)
+	require.NoError(t, rErr)
+
+	_ = pw.Conn.Close()
+
+	require.Equal(t, protocol.LocalFeatures, pw.Features())
+	require.Equal(t, protocol.LocalFeatures, pr.Features())
+	require.Equal(t, protocol.NodeVer, pw.PeerVersion())
+	require.Equal(t, protocol.NodeVer, pr.PeerVersion())
+	require.False(t, pw.compressed)
+}
+
+// TestLegacyVersionMessage ensures the capabilities of the nodes predating
+// the feature bits are read from their services.
+func TestLegacyVersionMessage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, (&protocol.Version{Major: 0, Minor: 4, Patch: 3}).Encode(buf))
+	require.NoError(t, encoding.WriteUint64LE(buf, 0))
+	require.NoError(t, encoding.WriteUint64LE(buf, uint64(protocol.FullNode|protocol.Compressed)))
+
+	v, err := decodeVersionMessage(buf)
+	require.NoError(t, err)
+	require.NoError(t, verifyVersionMessage(v))
+	require.Equal(t, protocol.LegacyFeatures|protocol.FeatureCompressedFrames, v.Features)
+
+	// Light nodes are accepted, unknown node types are not
+	buf, err = newVersionMessageBuffer(protocol.NodeVer, protocol.LightNode, protocol.LocalFeatures)
+	require.NoError(t, err)
+
+	v, err = decodeVersionMessage(buf)
+	require.NoError(t, err)
+	require.NoError(t, verifyVersionMessage(v))
+	require.Equal(t, protocol.LocalFeatures, v.Features)
+
+	v.Services = 0x42
+	require.Error(t, verifyVersionMessage(v))
+}
+
+func TestCanRoute(t *testing.T) {
+	require.True(t, canRoute(protocol.FullNode, protocol.LocalFeatures, topics.Reduction))
+	require.True(t, canRoute(protocol.PrunedNode, protocol.LocalFeatures, topics.Reduction))
+	require.False(t, canRoute(protocol.LightNode, protocol.LocalFeatures, topics.Reduction))
+	require.True(t, canRoute(protocol.LightNode, protocol.LocalFeatures, topics.Block))
+
+	// Topics gated by a feature
+	require.True(t, canRoute(protocol.FullNode, protocol.FeatureAggrAgreement, topics.AggrAgreement))
+	require.False(t, canRoute(protocol.FullNode, 0, topics.AggrAgreement))
+	require.False(t, canRoute(0, protocol.LocalFeatures, topics.Tx))
+}
+
+func TestLoadIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity.pem")
+
+	id, err := LoadIdentity(path)
+	require.NoError(t, err)
+
+	loaded, err := LoadIdentity(path)
+	require.NoError(t, err)
+	require.Equal(t, id.PublicKey(), loaded.PublicKey())
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	both := func(w, r *Connection) {
+		w.EnableCompression()
+		r.EnableCompression()
+	}
+
+	pw, pr, wErr, rErr := handshakePair(nil, nil, false, both)
+	require.NoError(t, wErr)
+	require.NoError(t, rErr)
+	require.True(t, pw.compressed)
+	require.True(t, pr.compressed)
+
+	// The flag does not alter the node type
+	require.Equal(t, protocol.FullNode, pw.services)
+
+	// Compressed messages go through the ReadLoop
+	processor := pr.processor
+	received := make(chan []byte, 1)
+
+	processor.Register(topics.Inv, func(_ string, m message.Message) ([]bytes.Buffer, error) {
+		inv := m.Payload().(message.Inv)
+		buf := new(bytes.Buffer)
+		err := inv.Encode(buf)
+		received <- buf.Bytes()
+		return nil, err
+	})
+
+	go pr.ReadLoop(context.Background(), nil)
+
+	inv := message.Inv{}
+	for i := 0; i < 100; i++ {
+		inv.AddItem(message.InvTypeBlock, make([]byte, 32))
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, inv.Encode(buf))
+	expected := buf.Bytes()
+
+	require.NoError(t, topics.Prepend(buf, topics.Inv))
+	require.Greater(t, buf.Len(), protocol.CompressionThreshold)
+
+	g := &GossipConnector{Connection: pw.Connection}
+	go func() {
+		_, _ = g.Write(buf.Bytes(), nil, 0)
+	}()
+
+	select {
+	case b := <-received:
+		require.Equal(t, expected, b)
+	case <-time.After(5 * time.Second):
+		t.Fatal("compressed message not received")
+	}
+
+	_ = pw.Conn.Close()
+
+	// Compression is only used if both ends enable it
+	pw, pr, wErr, rErr = handshakePair(nil, nil, false, func(w, _ *Connection) {
+		w.EnableCompression()
+	})
+	require.NoError(t, wErr)
+	require.NoError(t, rErr)
+	require.False(t, pw.compressed)
+	require.False(t, pr.compressed)
+
+	_ = pw.Conn.Close()
+}
+
+func TestParsePins(t *testing.T) {
+	id, err := NewIdentity(nil)
+	require.NoError(t, err)
+
+	key := hex.EncodeToString(id.PublicKey())
+
+	pins, err := ParsePins([]string{"10.0.0.1:7000=" + key})
+	require.NoError(t, err)
+	require.Equal(t, id.PublicKey(), pins["10.0.0.1:7000"])
+
+	for _, e := range []string{"10.0.0.1:7000", "seed.dusk.network:7000=" + key, "10.0.0.1:7000=00"} {
+		_, err := ParsePins([]string{e})
+		require.Error(t, err, e)
+	}
+}
@@ -0,0 +1,227 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/addrbook"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+const (
+	defaultOutbound = 8
+	// maxAddrs is the amount of addresses sent in response to GetAddrs.
+	maxAddrs     = 10
+	fillInterval = 30 * time.Second
+	saveInterval = 5 * time.Minute
+)
+
+// Manager maintains a target amount of outbound connections, out of the
+// addresses of an address book. Outbound peers are picked in distinct
+// network groups (see addrbook.Group), so that a single network can not
+// eclipse the node. When a peer drops, a replacement is dialed.
+type Manager struct {
+	connector *Connector
+	book      *addrbook.Book
+	target    int
+
+	lock sync.Mutex
+	// outbound peers, by address
+	outbound map[string]struct{}
+
+	wake chan struct{}
+}
+
+// NewManager creates a Manager keeping target outbound connections through
+// the Connector. A zero target falls back to a default.
+// The Manager takes over the Addr and GetAddrs messages from the processor of
+// the Connector.
+func NewManager(c *Connector, book *addrbook.Book, target int) *Manager {
+	if target <= 0 {
+		target = defaultOutbound
+	}
+
+	m := &Manager{
+		connector: c,
+		book:      book,
+		target:    target,
+		outbound:  make(map[string]struct{}),
+		wake:      make(chan struct{}, 1),
+	}
+
+	c.lock.Lock()
+	c.manager = m
+	c.lock.Unlock()
+
+	c.processor.Register(topics.GetAddrs, m.ProcessGetAddrs)
+
+	return m
+}
+
+// Run the Manager until ctx is canceled. The address book is saved
+// periodically, and when Run returns.
+func (m *Manager) Run(ctx context.Context) {
+	fill := time.NewTicker(fillInterval)
+	defer fill.Stop()
+
+	save := time.NewTicker(saveInterval)
+	defer save.Stop()
+
+	defer m.save()
+
+	m.fill()
+
+	for {
+		select {
+		case <-m.wake:
+			m.fill()
+		case <-fill.C:
+			m.fill()
+		case <-save.C:
+			m.save()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Outbound returns the amount of outbound connections.
+func (m *Manager) Outbound() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return len(m.outbound)
+}
+
+// ProcessNewAddress adds the address of an Addr message to the book.
+// Satisfies the peer.ProcessorFunc interface.
+func (m *Manager) ProcessNewAddress(srcPeerID string, msg message.Message) ([]bytes.Buffer, error) {
+	a := msg.Payload().(message.Addr)
+
+	added, err := m.book.Add(a.NetAddr, srcPeerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if added {
+		m.signal()
+	}
+
+	return nil, nil
+}
+
+// ProcessGetAddrs answers a GetAddrs message with a sample of the book.
+// Satisfies the peer.ProcessorFunc interface.
+func (m *Manager) ProcessGetAddrs(srcPeerID string, _ message.Message) ([]bytes.Buffer, error) {
+	addrs := m.book.Sample(maxAddrs + 1)
+	bufs := make([]bytes.Buffer, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if addr == srcPeerID {
+			continue
+		}
+
+		buf := bytes.NewBufferString(addr)
+		if err := topics.Prepend(buf, topics.Addr); err != nil {
+			return nil, err
+		}
+
+		bufs = append(bufs, *buf)
+
+		if len(bufs) >= maxAddrs {
+			break
+		}
+	}
+
+	return bufs, nil
+}
+
+// fill dials addresses of the book until the target is reached, or no
+// eligible address is left.
+func (m *Manager) fill() {
+	// Groups of the current outbound peers, as well as of the addresses
+	// which failed this round, are excluded
+	m.lock.Lock()
+
+	exclude := make(map[string]struct{}, len(m.outbound))
+	for addr := range m.outbound {
+		exclude[addrbook.Group(addr)] = struct{}{}
+	}
+
+	missing := m.target - len(m.outbound)
+	m.lock.Unlock()
+
+	for missing > 0 {
+		addr, ok := m.book.Pick(exclude)
+		if !ok {
+			return
+		}
+
+		exclude[addrbook.Group(addr)] = struct{}{}
+
+		if m.connector.hasPeer(addr) {
+			continue
+		}
+
+		if err := m.dial(addr); err != nil {
+			plog.WithField("r_addr", addr).WithError(err).
+				Debugln("could not connect to address")
+
+			m.book.MarkFailed(addr)
+			continue
+		}
+
+		m.book.MarkGood(addr)
+		missing--
+	}
+}
+
+func (m *Manager) dial(addr string) error {
+	m.lock.Lock()
+	m.outbound[addr] = struct{}{}
+	m.lock.Unlock()
+
+	err := m.connector.connect(addr, func() {
+		m.drop(addr)
+	})
+	if err != nil {
+		m.lock.Lock()
+		delete(m.outbound, addr)
+		m.lock.Unlock()
+	}
+
+	return err
+}
+
+// drop forgets a terminated outbound connection, and wakes the Manager up to
+// replace it.
+func (m *Manager) drop(addr string) {
+	m.lock.Lock()
+	delete(m.outbound, addr)
+	m.lock.Unlock()
+
+	plog.WithField("r_addr", addr).Debugln("outbound peer dropped")
+	m.signal()
+}
+
+func (m *Manager) signal() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) save() {
+	if err := m.book.Save(); err != nil {
+		plog.WithError(err).Warnln("could not save address book")
+	}
+}
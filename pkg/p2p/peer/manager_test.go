@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/addrbook"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// TestManagerRedial ensures the Manager connects to the addresses of the
+// book, and replaces the outbound peers which drop.
+func TestManagerRedial(t *testing.T) {
+	eb := eventbus.New()
+
+	// Remote node, holding inbound connections until the test ends
+	quit := make(chan struct{})
+	defer close(quit)
+
+	remote := NewConnector(eb, protocol.NewGossip(), "0", NewMessageProcessor(eb), protocol.FullNode,
+		func(context.Context, *Reader, *Writer) { <-quit })
+	defer remote.Close()
+
+	addr := fmt.Sprintf("127.0.0.1:%d", remote.l.Addr().(*net.TCPAddr).Port)
+
+	// Local node, whose outbound connections terminate on demand
+	drop := make(chan struct{})
+	local := NewConnector(eb, protocol.NewGossip(), "0", NewMessageProcessor(eb), protocol.FullNode,
+		func(context.Context, *Reader, *Writer) { <-drop })
+	defer local.Close()
+
+	book, err := addrbook.New("")
+	require.NoError(t, err)
+
+	_, err = book.Add(addr, "seed")
+	require.NoError(t, err)
+
+	m := NewManager(local, book, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go m.Run(ctx)
+
+	// A single address is known, hence a single outbound peer
+	require.Eventually(t, func() bool {
+		return m.Outbound() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, tried := book.Len()
+	require.Equal(t, 1, tried)
+
+	// The peer drops and is dialed again
+	drop <- struct{}{}
+
+	require.Eventually(t, func() bool {
+		return m.Outbound() == 1 && local.GetConnectionsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(drop)
+}
+
+func TestManagerAddrs(t *testing.T) {
+	eb := eventbus.New()
+
+	c := NewConnector(eb, protocol.NewGossip(), "0", NewMessageProcessor(eb), protocol.FullNode,
+		func(context.Context, *Reader, *Writer) {})
+	defer c.Close()
+
+	book, err := addrbook.New("")
+	require.NoError(t, err)
+
+	NewManager(c, book, 1)
+
+	// Addr messages fill the book, instead of being dialed
+	for _, addr := range []string{"10.0.0.1:7000", "10.1.0.1:7000", "10.2.0.1:7000"} {
+		_, err = c.ProcessNewAddress("10.9.0.1:7000", message.New(topics.Addr, message.Addr{NetAddr: addr}))
+		require.NoError(t, err)
+	}
+
+	fresh, _ := book.Len()
+	require.Equal(t, 3, fresh)
+
+	// Invalid addresses are rejected
+	_, err = c.ProcessNewAddress("10.9.0.1:7000", message.New(topics.Addr, message.Addr{NetAddr: "pippo"}))
+	require.Error(t, err)
+
+	// GetAddrs is answered from the book, omitting the requesting peer
+	bufs, err := c.processor.processors[topics.GetAddrs]("10.1.0.1:7000", nil)
+	require.NoError(t, err)
+	require.Len(t, bufs, 2)
+
+	for _, buf := range bufs {
+		topic, err := topics.Extract(&buf)
+		require.NoError(t, err)
+		require.Equal(t, topics.Addr, topic)
+		require.NotEqual(t, "10.1.0.1:7000", buf.String())
+	}
+}
@@ -9,6 +9,7 @@ package peer
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -21,10 +22,14 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/traffic"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/container/ring"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 )
@@ -36,6 +41,10 @@ var l = log.WithField("process", "peer")
 const (
 	defaultTimeoutReadWrite = 60
 	defaultKeepAliveTime    = 30
+
+	// streamBufferLength is the length of the ring buffer of the messages
+	// gossiped to a peer.
+	streamBufferLength = 2000
 )
 
 // Connection holds the TCP connection to another node, and it's known protocol magic.
@@ -46,6 +55,29 @@ type Connection struct {
 	net.Conn
 	gossip   *protocol.Gossip
 	services protocol.ServiceFlag //nolint:structcheck
+
+	// version of the peer, and features supported by both ends, negotiated
+	// in the handshake
+	version  *protocol.Version
+	features protocol.Features
+
+	// encrypted transport, see transport.go
+	identity          *Identity
+	requireEncryption bool
+	pins              Pins
+	peerIdentity      ed25519.PublicKey
+
+	// version messages exchanged in the handshake, checked once encrypted
+	localVersion  []byte
+	remoteVersion []byte
+
+	// compression enables the compression, which is used once negotiated
+	// with the peer in the handshake.
+	compression bool
+	compressed  bool
+
+	// shaping of the outbound traffic, see EnableShaping
+	shaping *shaping.Config
 }
 
 // NewConnection creates a peer connection struct.
@@ -61,15 +93,39 @@ func NewConnection(conn net.Conn, gossip *protocol.Gossip) *Connection {
 // It absolves the function previously carried over by the Gossip preprocessor.
 type GossipConnector struct {
 	*Connection
+
+	// queue shapes the outbound messages. If nil, they are written right
+	// away.
+	queue *shaping.Queue
 }
 
+// Write a message to the peer. If the outbound traffic is shaped, the message
+// is queued according to its topic and priority.
 func (g *GossipConnector) Write(b []byte, _ *message.Metadata, priority byte) (int, error) {
-	if !canRoute(g.services, topics.Topic(b[0])) {
+	topic := topics.Topic(b[0])
+	if !canRoute(g.services, g.features, topic) {
 		return 0, nil
 	}
 
+	if g.queue == nil {
+		return g.write(b)
+	}
+
+	send := func() error {
+		_, err := g.write(b)
+		return err
+	}
+
+	if err := g.queue.Push(shaping.Classify(topic, priority), len(b), send); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+func (g *GossipConnector) write(b []byte) (int, error) {
 	buf := bytes.NewBuffer(b)
-	if err := g.gossip.Process(buf); err != nil {
+	if err := g.frame(buf); err != nil {
 		return 0, err
 	}
 
@@ -77,9 +133,11 @@ func (g *GossipConnector) Write(b []byte, _ *message.Metadata, priority byte) (i
 	if err != nil {
 		l.WithField("r_addr", g.RemoteAddr().String()).
 			WithError(err).Warn("failed to write")
+		return n, err
 	}
 
-	return n, err
+	traffic.Record(traffic.Gossip, util.Host(g.Addr()), traffic.Out, b, n)
+	return n, nil
 }
 
 // Writer abstracts all of the logic and fields needed to write messages to
@@ -116,6 +174,43 @@ func NewWriter(conn *Connection, subscriber eventbus.Subscriber, keepAlive ...ti
 	return pw
 }
 
+// EnableCompression makes the Connection advertise the compression in the
+// handshake, and compress the messages once the peer supports it too.
+func (c *Connection) EnableCompression() {
+	c.compression = true
+}
+
+// EnableShaping queues the outbound messages of the Connection by class,
+// within the limits of cfg.
+func (c *Connection) EnableShaping(cfg shaping.Config) {
+	c.shaping = &cfg
+}
+
+// Services returns the node type of the peer.
+func (c *Connection) Services() protocol.ServiceFlag {
+	return c.services
+}
+
+// PeerVersion returns the node version of the peer, or nil before the
+// handshake.
+func (c *Connection) PeerVersion() *protocol.Version {
+	return c.version
+}
+
+// Features returns the features negotiated with the peer.
+func (c *Connection) Features() protocol.Features {
+	return c.features
+}
+
+// frame wraps a message into a gossip frame, compressing it if negotiated.
+func (c *Connection) frame(buf *bytes.Buffer) error {
+	if c.compressed {
+		return c.gossip.ProcessCompressed(buf)
+	}
+
+	return c.gossip.Process(buf)
+}
+
 // ReadMessage reads from the connection.
 func (c *Connection) ReadMessage() ([]byte, error) {
 	length, err := c.gossip.UnpackLength(c.Conn)
@@ -219,8 +314,16 @@ func Create(ctx context.Context, reader *Reader, writer *Writer) {
 	pCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	g := &GossipConnector{writer.Connection}
+	g := &GossipConnector{Connection: writer.Connection}
+	traffic.Connected(traffic.Gossip, util.Host(g.Addr()))
+
+	// The messages are only sorted by priority when the traffic is shaped
 	listener := eventbus.NewStreamListener(g)
+	if writer.shaping != nil {
+		g.queue = shaping.NewQueue(pCtx, *writer.shaping)
+		listener = eventbus.NewStreamListenerWithParams(g, streamBufferLength, shaping.Priority)
+	}
+
 	writer.gossipID = writer.subscriber.Subscribe(topics.Gossip, listener)
 	ringBuf := ring.NewBuffer(1000)
 
@@ -248,6 +351,7 @@ func (w *Writer) onDisconnect() {
 	_ = w.Conn.Close()
 
 	w.subscriber.Unsubscribe(topics.Gossip, w.gossipID)
+	traffic.Disconnected(traffic.Gossip, util.Host(w.Addr()))
 
 	if config.Get().API.Enabled {
 		go func() {
@@ -307,7 +411,7 @@ func (p *Reader) ReadLoop(ctx context.Context, ringBuf *ring.Buffer) {
 			return
 		}
 
-		b, err := p.gossip.ReadMessage(p.Conn)
+		b, reserved, err := p.gossip.ReadMessageWithReserved(p.Conn)
 		if err != nil {
 			plog.WithError(err).Warnln("error reading message")
 			return
@@ -322,13 +426,29 @@ func (p *Reader) ReadLoop(ctx context.Context, ringBuf *ring.Buffer) {
 		if !checksum.Verify(message, cs) {
 			plog.WithError(errors.New("invalid checksum")).
 				Warnln("error verifying message cs")
+			p.processor.Penalize(p.Addr(), reputation.BadChecksum)
 			return
 		}
 
+		// The reserved field flags the compression only once negotiated
+		if p.compressed {
+			if message, err = protocol.Decompress(message, reserved); err != nil {
+				plog.WithError(err).Warnln("error decompressing message")
+				return
+			}
+		}
+
+		traffic.Record(traffic.Gossip, util.Host(p.Addr()), traffic.In, message, len(b)+int(protocol.HeaderLength))
+
 		go func() {
-			// TODO: error here should be checked in order to decrease reputation
-			// or blacklist spammers
-			if _, err = p.processor.Collect(p.Addr(), message, ringBuf, p.services, nil); err != nil {
+			// Disconnect the peer once banned, which stops the ReadLoop
+			defer func() {
+				if p.processor.IsBanned(p.Addr()) {
+					_ = p.Conn.Close()
+				}
+			}()
+
+			if _, err := p.processor.Collect(p.Addr(), message, ringBuf, p.services, p.features, nil); err != nil {
 				var topic string
 				if len(message) > 0 {
 					topic = topics.Topic(message[0]).String()
@@ -370,8 +490,13 @@ func (c *Connection) keepAlive() error {
 		return err
 	}
 
-	_, err := c.Write(buf.Bytes())
-	return err
+	n, err := c.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	traffic.Record(traffic.Gossip, util.Host(c.Addr()), traffic.Out, []byte{byte(topics.Ping)}, n)
+	return nil
 }
 
 // Write a message to the connection.
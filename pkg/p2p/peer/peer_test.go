@@ -9,6 +9,7 @@ package peer
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net"
 	"testing"
 	"time"
@@ -16,6 +17,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/shaping"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -81,6 +85,44 @@ func TestReader(t *testing.T) {
 	}
 }
 
+// Test that the MessageProcessor penalizes the peers blamed by a processor,
+// and drops their messages once banned.
+func TestProcessorReputation(t *testing.T) {
+	store, err := reputation.NewStore("")
+	require.NoError(t, err)
+
+	scorer, err := reputation.NewScorer(store, reputation.Config{Threshold: 50, BanDuration: time.Hour})
+	require.NoError(t, err)
+
+	processor := NewMessageProcessor(eventbus.New())
+	processor.SetScorer(scorer)
+
+	var calls int
+
+	processor.Register(topics.Agreement, func(_ string, _ message.Message) ([]bytes.Buffer, error) {
+		calls++
+		return nil, reputation.Blame(reputation.InvalidSignature, errors.New("invalid signature"))
+	})
+
+	collect := func() error {
+		buf, err := message.Marshal(makeAgreementGossip(10))
+		require.NoError(t, err)
+
+		_, err = processor.Collect("10.0.0.1:7000", buf.Bytes(), nil, protocol.FullNode, protocol.LocalFeatures, nil)
+		return err
+	}
+
+	require.Error(t, collect())
+	require.False(t, processor.IsBanned("10.0.0.1:7000"))
+
+	require.Error(t, collect())
+	require.True(t, processor.IsBanned("10.0.0.1:7000"))
+
+	// Messages of a banned peer are dropped
+	require.NoError(t, collect())
+	require.Equal(t, 2, calls)
+}
+
 // Test the functionality of the peer.Writer through the use of the ring buffer.
 func TestWriteRingBuffer(t *testing.T) {
 	bus := eventbus.New()
@@ -99,6 +141,43 @@ func TestWriteRingBuffer(t *testing.T) {
 	}
 }
 
+// TestShapedWrite ensures the messages written while the link is busy are
+// sent by decreasing class.
+func TestShapedWrite(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, srv := net.Pipe()
+	g := protocol.NewGossip()
+
+	pConn := NewConnection(client, g)
+	pConn.services = protocol.FullNode
+
+	gc := &GossipConnector{Connection: pConn, queue: shaping.NewQueue(ctx, shaping.Config{})}
+
+	// The pipe blocks the first message until it is read
+	for _, topic := range []topics.Topic{topics.Inv, topics.Tx, topics.Agreement} {
+		n, err := gc.Write([]byte{byte(topic), 1, 2, 3}, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, 4, n)
+
+		if topic == topics.Inv {
+			require.Eventually(t, func() bool { return gc.queue.Len(shaping.Inventory) == 0 }, time.Second, time.Millisecond)
+		}
+	}
+
+	for _, topic := range []topics.Topic{topics.Inv, topics.Agreement, topics.Tx} {
+		_ = srv.SetReadDeadline(time.Now().Add(time.Second))
+
+		b, err := g.ReadMessage(srv)
+		require.NoError(t, err)
+
+		m, _, err := checksum.Extract(b)
+		require.NoError(t, err)
+		require.Equal(t, topic, topics.Topic(m[0]))
+	}
+}
+
 func BenchmarkWriter(t *testing.B) {
 	bus := eventbus.New()
 
@@ -15,6 +15,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -31,6 +32,7 @@ type ProcessorFunc func(srcPeerID string, m message.Message) ([]bytes.Buffer, er
 type MessageProcessor struct {
 	dupeMap    *dupemap.DupeMap
 	processors map[topics.Topic]ProcessorFunc
+	scorer     *reputation.Scorer
 }
 
 // NewMessageProcessor returns an initialized MessageProcessor.
@@ -47,14 +49,35 @@ func (m *MessageProcessor) Register(topic topics.Topic, fn ProcessorFunc) {
 	m.processors[topic] = fn
 }
 
+// SetScorer sets the Scorer keeping the reputation of the peers. Messages
+// from banned peers are dropped, and the peers sending messages which fail
+// processing with a reputation.Offense are penalized.
+func (m *MessageProcessor) SetScorer(s *reputation.Scorer) {
+	m.scorer = s
+}
+
+// Penalize a peer for an Offense. It returns true if the peer is banned.
+func (m *MessageProcessor) Penalize(srcPeerID string, o reputation.Offense) bool {
+	return m.scorer.Penalize(srcPeerID, o)
+}
+
+// IsBanned returns true if the peer is banned.
+func (m *MessageProcessor) IsBanned(srcPeerID string) bool {
+	return m.scorer.IsBanned(srcPeerID)
+}
+
 // Collect a message from the network. The message is unmarshaled and passed down
 // to the processing function.
-func (m *MessageProcessor) Collect(srcPeerID string, packet []byte, respRingBuf *ring.Buffer, services protocol.ServiceFlag, metadata *message.Metadata) ([]bytes.Buffer, error) {
+func (m *MessageProcessor) Collect(srcPeerID string, packet []byte, respRingBuf *ring.Buffer, services protocol.ServiceFlag, features protocol.Features, metadata *message.Metadata) ([]bytes.Buffer, error) {
 	if len(packet) == 0 {
 		return nil, errors.New("empty packet provided")
 	}
 	defer m.trace("collected", srcPeerID, time.Now().UnixNano(), packet)
 
+	if m.scorer.IsBanned(srcPeerID) {
+		return nil, nil
+	}
+
 	b := bytes.NewBuffer(packet)
 	topic := topics.Topic(b.Bytes()[0])
 
@@ -63,7 +86,7 @@ func (m *MessageProcessor) Collect(srcPeerID string, packet []byte, respRingBuf
 		return nil, fmt.Errorf("error while unmarshaling: %s - topic: %s", err, topic)
 	}
 
-	return m.process(srcPeerID, msg, respRingBuf, services)
+	return m.process(srcPeerID, msg, respRingBuf, services, features)
 }
 
 func (m *MessageProcessor) trace(tag, srcAddr string, st int64, msg []byte) {
@@ -102,10 +125,10 @@ func (m *MessageProcessor) shouldBeCached(t topics.Topic) bool {
 	}
 }
 
-func (m *MessageProcessor) process(srcPeerID string, msg message.Message, respRingBuf *ring.Buffer, services protocol.ServiceFlag) ([]bytes.Buffer, error) {
+func (m *MessageProcessor) process(srcPeerID string, msg message.Message, respRingBuf *ring.Buffer, services protocol.ServiceFlag, features protocol.Features) ([]bytes.Buffer, error) {
 	category := msg.Category()
-	if !canRoute(services, category) {
-		return nil, fmt.Errorf("attempted to process an illegal topic %s for node type %v", category, services)
+	if !canRoute(services, features, category) {
+		return nil, fmt.Errorf("attempted to process an illegal topic %s for node type %v with features %s", category, services, features)
 	}
 
 	if m.shouldBeCached(category) {
@@ -120,8 +143,16 @@ func (m *MessageProcessor) process(srcPeerID string, msg message.Message, respRi
 		return nil, nil
 	}
 
+	if category == topics.Tx && !m.scorer.ObserveTx(srcPeerID) {
+		return nil, fmt.Errorf("transaction rate limit exceeded by %s", srcPeerID)
+	}
+
 	bufs, err := processFn(srcPeerID, msg)
 	if err != nil {
+		if o, ok := reputation.OffenseOf(err); ok {
+			m.scorer.Penalize(srcPeerID, o)
+		}
+
 		return nil, fmt.Errorf("error while processing: %s - topic %s", err, msg.Category())
 	}
 
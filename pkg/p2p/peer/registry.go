@@ -11,32 +11,65 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 )
 
-// TODO: implement light node registry.
+// fullNodeTopics are the topics exchanged with full nodes.
+var fullNodeTopics = map[topics.Topic]struct{}{
+	topics.Tx:            {},
+	topics.Candidate:     {},
+	topics.NewBlock:      {},
+	topics.Reduction:     {},
+	topics.Agreement:     {},
+	topics.AggrAgreement: {},
+	topics.Ping:          {},
+	topics.Pong:          {},
+	topics.GetData:       {},
+	topics.GetBlocks:     {},
+	topics.Block:         {},
+	topics.MemPool:       {},
+	topics.Inv:           {},
+	topics.GetCandidate:  {},
+	topics.Addr:          {},
+	topics.Challenge:     {},
+	topics.Response:      {},
+	topics.GetAddrs:      {},
+}
+
+// routingRegistry lists the topics exchanged with each node type.
 var routingRegistry = map[protocol.ServiceFlag]map[topics.Topic]struct{}{
-	// Full node
-	protocol.FullNode: {
-		topics.Tx:            {},
-		topics.Candidate:     {},
-		topics.NewBlock:      {},
-		topics.Reduction:     {},
-		topics.Agreement:     {},
-		topics.AggrAgreement: {},
-		topics.Ping:          {},
-		topics.Pong:          {},
-		topics.GetData:       {},
-		topics.GetBlocks:     {},
-		topics.Block:         {},
-		topics.MemPool:       {},
-		topics.Inv:           {},
-		topics.GetCandidate:  {},
-		topics.Addr:          {},
-		topics.Challenge:     {},
-		topics.Response:      {},
-		topics.GetAddrs:      {},
+	protocol.FullNode: fullNodeTopics,
+	// Pruned nodes relay the same messages as the full nodes
+	protocol.PrunedNode: fullNodeTopics,
+	// Light nodes follow the chain and the mempool, and take no part in the
+	// consensus
+	protocol.LightNode: {
+		topics.Tx:        {},
+		topics.Ping:      {},
+		topics.Pong:      {},
+		topics.GetData:   {},
+		topics.GetBlocks: {},
+		topics.Block:     {},
+		topics.MemPool:   {},
+		topics.Inv:       {},
+		topics.Addr:      {},
+		topics.Challenge: {},
+		topics.Response:  {},
+		topics.GetAddrs:  {},
 	},
 }
 
-func canRoute(services protocol.ServiceFlag, topic topics.Topic) bool {
-	_, ok := routingRegistry[services][topic]
-	return ok
+// featureRegistry lists the topics only exchanged with the peers which
+// negotiated a feature. A topic introduced by a protocol change is gated by
+// its feature, so that it is not sent to the peers unaware of it.
+var featureRegistry = map[topics.Topic]protocol.Features{
+	topics.AggrAgreement: protocol.FeatureAggrAgreement,
+}
+
+// canRoute returns true if a message of a topic can be exchanged with a peer
+// of the given node type, with which the given features were negotiated.
+func canRoute(services protocol.ServiceFlag, features protocol.Features, topic topics.Topic) bool {
+	if _, ok := routingRegistry[services][topic]; !ok {
+		return false
+	}
+
+	f, ok := featureRegistry[topic]
+	return !ok || features.Has(f)
 }
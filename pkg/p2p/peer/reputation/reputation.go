@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package reputation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Offense is a misbehavior of a peer, which decreases its reputation.
+type Offense uint8
+
+const (
+	// InvalidBlock is sent a block failing the hash or certificate
+	// verification.
+	InvalidBlock Offense = iota
+	// BadChecksum is sent a wire message with an invalid checksum.
+	BadChecksum
+	// InvalidSignature is sent a consensus message with an invalid signature.
+	InvalidSignature
+	// SyncTimeout is a peer not delivering the blocks requested during
+	// synchronization.
+	SyncTimeout
+	// TxFlooding is sent transactions above the allowed rate.
+	TxFlooding
+)
+
+var offenseNames = [...]string{"invalid_block", "bad_checksum", "invalid_signature", "sync_timeout", "tx_flooding"}
+
+// penalties is the score added to a peer for each Offense. A peer is banned
+// once its score reaches the threshold.
+var penalties = [...]float64{50, 20, 25, 30, 10}
+
+// String implements fmt.Stringer.
+func (o Offense) String() string {
+	if int(o) < len(offenseNames) {
+		return offenseNames[o]
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(o))
+}
+
+// MarshalText implements encoding.TextMarshaler, so that the offense is
+// reported by name.
+func (o Offense) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (o *Offense) UnmarshalText(text []byte) error {
+	for i, name := range offenseNames {
+		if name == string(text) {
+			*o = Offense(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown offense %q", text)
+}
+
+// Penalty returns the score added to a peer committing the Offense.
+func (o Offense) Penalty() float64 {
+	if int(o) < len(penalties) {
+		return penalties[o]
+	}
+
+	return 0
+}
+
+// Error is an error caused by a misbehaving peer.
+type Error struct {
+	Offense Offense
+	Err     error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Blame wraps err, so that the MessageProcessor penalizes the peer which
+// sent the message causing it.
+func Blame(o Offense, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Offense: o, Err: err}
+}
+
+// OffenseOf returns the Offense blamed in the chain of err, if any.
+func OffenseOf(err error) (Offense, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Offense, true
+	}
+
+	return 0, false
+}
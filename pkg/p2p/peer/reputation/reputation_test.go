@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package reputation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation/reputationpb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testCfg = Config{
+	Threshold:      100,
+	BanDuration:    time.Hour,
+	DecayPerMinute: 10,
+	TxFloodLimit:   3,
+}
+
+func newTestScorer(t *testing.T, store Store, now *time.Time) *Scorer {
+	s, err := NewScorer(store, testCfg)
+	require.NoError(t, err)
+
+	s.now = func() time.Time {
+		return *now
+	}
+
+	return s
+}
+
+func TestBlame(t *testing.T) {
+	base := errors.New("invalid certificate")
+	err := fmt.Errorf("accepting block: %w", Blame(InvalidBlock, base))
+
+	o, ok := OffenseOf(err)
+	require.True(t, ok)
+	require.Equal(t, InvalidBlock, o)
+	require.True(t, errors.Is(err, base))
+
+	_, ok = OffenseOf(base)
+	require.False(t, ok)
+	require.Nil(t, Blame(InvalidBlock, nil))
+}
+
+func TestPenalize(t *testing.T) {
+	store, err := NewStore("")
+	require.NoError(t, err)
+
+	now := time.Now()
+	s := newTestScorer(t, store, &now)
+
+	require.False(t, s.Penalize("10.0.0.1:7000", InvalidBlock))
+	require.False(t, s.IsBanned("10.0.0.1:7000"))
+
+	// The score decays over time
+	now = now.Add(time.Minute)
+	require.False(t, s.Penalize("10.0.0.1:7000", InvalidBlock))
+
+	// The ban applies to any port of the host
+	require.True(t, s.Penalize("10.0.0.1:7001", BadChecksum))
+	require.True(t, s.IsBanned("10.0.0.1:9000"))
+	require.False(t, s.IsBanned("10.0.0.2:7000"))
+
+	bans := s.Bans()
+	require.Len(t, bans, 1)
+	require.Equal(t, "10.0.0.1", bans[0].Address)
+	require.Equal(t, BadChecksum, bans[0].Offense)
+
+	// The ban expires
+	now = now.Add(testCfg.BanDuration)
+	require.False(t, s.IsBanned("10.0.0.1:7000"))
+	require.Empty(t, s.Bans())
+
+	// A nil Scorer never bans
+	var nilScorer *Scorer
+	require.False(t, nilScorer.Penalize("10.0.0.1:7000", InvalidBlock))
+	require.True(t, nilScorer.ObserveTx("10.0.0.1:7000"))
+}
+
+func TestObserveTx(t *testing.T) {
+	store, err := NewStore("")
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	s := newTestScorer(t, store, &now)
+
+	for i := 0; i < testCfg.TxFloodLimit; i++ {
+		require.True(t, s.ObserveTx("10.0.0.1:7000"))
+	}
+
+	require.False(t, s.ObserveTx("10.0.0.1:7000"))
+	require.False(t, s.ObserveTx("10.0.0.1:7000"))
+
+	// The limit applies per second, and the flood was penalized once
+	now = now.Add(time.Second)
+	require.True(t, s.ObserveTx("10.0.0.1:7000"))
+	require.InDelta(t, TxFlooding.Penalty(), s.peers["10.0.0.1"].score, 1)
+}
+
+func TestPersistedBans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.db")
+
+	store, err := NewStore(path)
+	require.NoError(t, err)
+
+	now := time.Now()
+	s := newTestScorer(t, store, &now)
+
+	s.Penalize("10.0.0.1:7000", InvalidBlock)
+	s.Penalize("10.0.0.1:7000", InvalidBlock)
+	require.True(t, s.IsBanned("10.0.0.1:7000"))
+	require.NoError(t, store.Close())
+
+	// The ban survives a restart
+	store, err = NewStore(path)
+	require.NoError(t, err)
+
+	defer store.Close()
+
+	s = newTestScorer(t, store, &now)
+	require.True(t, s.IsBanned("10.0.0.1:7000"))
+
+	require.NoError(t, s.Unban("10.0.0.1"))
+	require.False(t, s.IsBanned("10.0.0.1:7000"))
+	require.ErrorIs(t, s.Unban("10.0.0.1"), ErrNotBanned)
+
+	bans, err := store.All()
+	require.NoError(t, err)
+	require.Empty(t, bans)
+}
+
+func TestService(t *testing.T) {
+	store, err := NewStore("")
+	require.NoError(t, err)
+
+	now := time.Now()
+	s := newTestScorer(t, store, &now)
+	srv := NewService(s)
+
+	s.Penalize("10.0.0.1:7000", InvalidBlock)
+	s.Penalize("10.0.0.1:7000", InvalidBlock)
+
+	out, err := srv.ListBans(context.Background(), &reputationpb.ListBansRequest{})
+	require.NoError(t, err)
+	require.Len(t, out.GetBans(), 1)
+	require.Equal(t, "10.0.0.1", out.GetBans()[0].GetAddress())
+	require.Equal(t, "invalid_block", out.GetBans()[0].GetOffense())
+
+	_, err = srv.Unban(context.Background(), &reputationpb.UnbanRequest{Address: "10.0.0.1"})
+	require.NoError(t, err)
+
+	_, err = srv.Unban(context.Background(), &reputationpb.UnbanRequest{Address: "10.0.0.1"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
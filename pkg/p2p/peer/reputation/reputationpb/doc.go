@@ -0,0 +1,11 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+// Package reputationpb holds the protobuf messages and the gRPC service
+// administering the banned peers, generated from reputation.proto.
+package reputationpb
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. reputation.proto
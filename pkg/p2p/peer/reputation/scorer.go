@@ -0,0 +1,278 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package reputation
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "reputation")
+
+// ErrNotBanned is returned when unbanning a peer which is not banned.
+var ErrNotBanned = errors.New("peer is not banned")
+
+const (
+	defaultThreshold   = 100
+	defaultBanDuration = time.Hour
+)
+
+// Config of a Scorer.
+type Config struct {
+	// Threshold is the score at which a peer is banned.
+	Threshold float64
+	// BanDuration is how long a peer stays banned.
+	BanDuration time.Duration
+	// DecayPerMinute is the score a peer recovers each minute.
+	DecayPerMinute float64
+	// TxFloodLimit is the amount of transactions a peer is allowed to send
+	// each second. Zero disables the limit.
+	TxFloodLimit int
+}
+
+type entry struct {
+	score   float64
+	updated time.Time
+
+	txWindow time.Time
+	txCount  int
+}
+
+// Scorer keeps the reputation score of the peers, keyed by host. The score
+// grows with each Offense and decays over time. A peer reaching the threshold
+// is banned for a while. A nil Scorer never bans anyone.
+type Scorer struct {
+	lock  sync.Mutex
+	cfg   Config
+	store Store
+	peers map[string]*entry
+	bans  map[string]Ban
+
+	now func() time.Time
+}
+
+// NewScorer creates a Scorer, restoring the bans persisted in the Store.
+// A zero Threshold or BanDuration falls back to a default.
+func NewScorer(store Store, cfg Config) (*Scorer, error) {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultThreshold
+	}
+
+	if cfg.BanDuration <= 0 {
+		cfg.BanDuration = defaultBanDuration
+	}
+
+	s := &Scorer{
+		cfg:   cfg,
+		store: store,
+		peers: make(map[string]*entry),
+		bans:  make(map[string]Ban),
+		now:   time.Now,
+	}
+
+	bans, err := store.All()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range bans {
+		s.bans[b.Address] = b
+	}
+
+	return s, nil
+}
+
+// Penalize adds the penalty of an Offense to the score of a peer, and bans it
+// if the threshold is reached. It returns true if the peer is banned.
+func (s *Scorer) Penalize(addr string, o Offense) bool {
+	if s == nil || addr == "" {
+		return false
+	}
+
+	host := util.Host(addr)
+	now := s.now()
+
+	s.lock.Lock()
+
+	if s.isBanned(host, now) {
+		s.lock.Unlock()
+		return true
+	}
+
+	e := s.entry(host, now)
+	e.score += o.Penalty()
+
+	l := lg.WithField("addr", host).
+		WithField("offense", o).
+		WithField("score", e.score)
+
+	if e.score < s.cfg.Threshold {
+		s.lock.Unlock()
+		l.Debug("peer penalized")
+		return false
+	}
+
+	b := Ban{
+		Address: host,
+		Offense: o,
+		Score:   e.score,
+		Until:   now.Add(s.cfg.BanDuration),
+	}
+
+	s.bans[host] = b
+	delete(s.peers, host)
+	s.lock.Unlock()
+
+	if err := s.store.Put(b); err != nil {
+		l.WithError(err).Warn("could not persist ban")
+	}
+
+	l.WithField("until", b.Until).Warn("peer banned")
+	return true
+}
+
+// IsBanned returns true if the peer is currently banned.
+func (s *Scorer) IsBanned(addr string) bool {
+	if s == nil || addr == "" {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.isBanned(util.Host(addr), s.now())
+}
+
+// ObserveTx counts a transaction sent by a peer. It returns false, and
+// penalizes the peer, if the peer exceeds the allowed rate.
+func (s *Scorer) ObserveTx(addr string) bool {
+	if s == nil || addr == "" || s.cfg.TxFloodLimit <= 0 {
+		return true
+	}
+
+	host := util.Host(addr)
+	now := s.now()
+	window := now.Truncate(time.Second)
+
+	s.lock.Lock()
+
+	e := s.entry(host, now)
+	if !e.txWindow.Equal(window) {
+		e.txWindow = window
+		e.txCount = 0
+	}
+
+	e.txCount++
+	count := e.txCount
+	s.lock.Unlock()
+
+	if count <= s.cfg.TxFloodLimit {
+		return true
+	}
+
+	// Penalize once per window
+	if count == s.cfg.TxFloodLimit+1 {
+		s.Penalize(host, TxFlooding)
+	}
+
+	return false
+}
+
+// Bans returns the active bans, sorted by address.
+func (s *Scorer) Bans() []Ban {
+	if s == nil {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	res := make([]Ban, 0, len(s.bans))
+
+	for host := range s.bans {
+		if s.isBanned(host, now) {
+			res = append(res, s.bans[host])
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Address < res[j].Address
+	})
+
+	return res
+}
+
+// Unban lifts the ban of a peer, and resets its score.
+func (s *Scorer) Unban(addr string) error {
+	if s == nil {
+		return ErrNotBanned
+	}
+
+	host := util.Host(addr)
+
+	s.lock.Lock()
+
+	if !s.isBanned(host, s.now()) {
+		s.lock.Unlock()
+		return ErrNotBanned
+	}
+
+	delete(s.bans, host)
+	delete(s.peers, host)
+	s.lock.Unlock()
+
+	lg.WithField("addr", host).Info("peer unbanned")
+	return s.store.Delete(host)
+}
+
+// isBanned lifts the expired ban of host, if any.
+// The lock must be held.
+func (s *Scorer) isBanned(host string, now time.Time) bool {
+	b, ok := s.bans[host]
+	if !ok {
+		return false
+	}
+
+	if now.Before(b.Until) {
+		return true
+	}
+
+	delete(s.bans, host)
+
+	if err := s.store.Delete(host); err != nil {
+		lg.WithError(err).WithField("addr", host).Warn("could not delete expired ban")
+	}
+
+	return false
+}
+
+// entry returns the entry of host, with its score decayed up to now.
+// The lock must be held.
+func (s *Scorer) entry(host string, now time.Time) *entry {
+	e, ok := s.peers[host]
+	if !ok {
+		e = &entry{updated: now}
+		s.peers[host] = e
+	}
+
+	if elapsed := now.Sub(e.updated); elapsed > 0 {
+		e.score -= elapsed.Minutes() * s.cfg.DecayPerMinute
+		if e.score < 0 {
+			e.score = 0
+		}
+
+		e.updated = now
+	}
+
+	return e
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package reputation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/reputation/reputationpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service serves the bans of a Scorer.
+type Service struct {
+	scorer *Scorer
+}
+
+// NewService creates a Service administering the bans of scorer.
+func NewService(scorer *Scorer) *Service {
+	return &Service{scorer: scorer}
+}
+
+// ListBans returns the active bans.
+func (s *Service) ListBans(ctx context.Context, _ *reputationpb.ListBansRequest) (*reputationpb.BanList, error) {
+	bans := s.scorer.Bans()
+
+	list := &reputationpb.BanList{Bans: make([]*reputationpb.Ban, len(bans))}
+	for i, b := range bans {
+		list.Bans[i] = &reputationpb.Ban{
+			Address: b.Address,
+			Offense: b.Offense.String(),
+			Score:   b.Score,
+			Until:   b.Until.Unix(),
+		}
+	}
+
+	return list, nil
+}
+
+// Unban lifts the ban of the requested address.
+func (s *Service) Unban(ctx context.Context, req *reputationpb.UnbanRequest) (*reputationpb.UnbanResponse, error) {
+	err := s.scorer.Unban(req.GetAddress())
+
+	if errors.Is(err, ErrNotBanned) {
+		return nil, status.Errorf(codes.NotFound, "%s is not banned", req.GetAddress())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &reputationpb.UnbanResponse{}, nil
+}
+
+// Register the Service on a gRPC server.
+func Register(srv *grpc.Server, s reputationpb.ReputationServer) {
+	reputationpb.RegisterReputationServer(srv, s)
+}
+
+// ListBans requests the active bans to a node.
+func ListBans(ctx context.Context, conn *grpc.ClientConn) ([]Ban, error) {
+	resp, err := reputationpb.NewReputationClient(conn).ListBans(ctx, &reputationpb.ListBansRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]Ban, len(resp.GetBans()))
+	for i, b := range resp.GetBans() {
+		bans[i] = Ban{
+			Address: b.GetAddress(),
+			Score:   b.GetScore(),
+			Until:   time.Unix(b.GetUntil(), 0),
+		}
+
+		if err := bans[i].Offense.UnmarshalText([]byte(b.GetOffense())); err != nil {
+			return nil, err
+		}
+	}
+
+	return bans, nil
+}
+
+// Unban requests a node to lift the ban of an address.
+func Unban(ctx context.Context, conn *grpc.ClientConn, addr string) error {
+	_, err := reputationpb.NewReputationClient(conn).Unban(ctx, &reputationpb.UnbanRequest{Address: addr})
+	return err
+}
@@ -0,0 +1,114 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package reputation
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tidwall/buntdb"
+)
+
+const banPrefix = "ban:"
+
+// Ban is a peer which is refused until a given time.
+type Ban struct {
+	Address string    `json:"address"`
+	Offense Offense   `json:"offense"`
+	Score   float64   `json:"score"`
+	Until   time.Time `json:"until"`
+}
+
+// Store persists the bans, so that they survive a restart of the node.
+type Store interface {
+	// Put stores a Ban, replacing any previous Ban of the same address.
+	Put(Ban) error
+	// Delete removes the Ban of an address.
+	Delete(address string) error
+	// All returns all the stored bans which are not expired.
+	All() ([]Ban, error)
+	// Close the Store.
+	Close() error
+}
+
+type buntStore struct {
+	db *buntdb.DB
+}
+
+// NewStore opens (or creates) a buntdb backed Store at the specified path.
+// If path is empty, the Store is kept in memory.
+func NewStore(path string) (Store, error) {
+	if path == "" {
+		path = ":memory:"
+	}
+
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &buntStore{db: db}, nil
+}
+
+// Put implements Store. The Ban expires from the Store on its own.
+func (s *buntStore) Put(b Ban) error {
+	value, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(b.Until)
+	if ttl <= 0 {
+		return s.Delete(b.Address)
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(banPrefix+b.Address, string(value), &buntdb.SetOptions{Expires: true, TTL: ttl})
+		return err
+	})
+}
+
+// Delete implements Store.
+func (s *buntStore) Delete(address string) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(banPrefix + address)
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+
+		return err
+	})
+}
+
+// All implements Store.
+func (s *buntStore) All() ([]Ban, error) {
+	res := make([]Ban, 0)
+
+	var unmarshalErr error
+
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		return tx.AscendKeys(banPrefix+"*", func(key, value string) bool {
+			var b Ban
+			if unmarshalErr = json.Unmarshal([]byte(value), &b); unmarshalErr != nil {
+				return false
+			}
+
+			res = append(res, b)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, unmarshalErr
+}
+
+// Close implements Store.
+func (s *buntStore) Close() error {
+	return s.db.Close()
+}
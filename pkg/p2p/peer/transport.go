@@ -0,0 +1,365 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package peer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+)
+
+const identityPEMType = "PRIVATE KEY"
+
+var (
+	// ErrEncryptionRequired is returned by the handshake with a peer not
+	// supporting the encrypted transport, when encryption is required.
+	ErrEncryptionRequired = errors.New("peer does not support encryption")
+
+	// ErrIdentityMismatch is returned by the handshake with a peer whose
+	// identity differs from the one pinned for its address.
+	ErrIdentityMismatch = errors.New("peer identity does not match the pinned one")
+
+	// ErrTranscriptMismatch is returned by the handshake when the version
+	// messages seen by the peers differ, as when altered on the wire.
+	ErrTranscriptMismatch = errors.New("version messages altered before the encrypted transport")
+
+	errInvalidPeerIdentity = errors.New("peer did not present an ed25519 identity")
+)
+
+// Identity is the long-term ed25519 key of a node. It authenticates the node
+// on encrypted gossip connections, which are upgraded to TLS 1.3 with a
+// self-signed certificate of the key. As there is no certificate authority,
+// peers are identified by their key rather than by a name.
+//
+// The key of a peer is only checked against the Pins of the node. The
+// connections to the other peers are encrypted, but an active attacker can
+// still stand in the middle. Likewise, an attacker can strip the encrypted
+// transport feature from the version messages, which are exchanged in the
+// clear: only the pinned peers, or requireEncryption, prevent the connection
+// from falling back to the plain transport. Once encrypted, the peers compare
+// the version messages they exchanged, so that the other features can not be
+// altered.
+type Identity struct {
+	key  ed25519.PrivateKey
+	cert tls.Certificate
+}
+
+// NewIdentity creates an Identity out of an ed25519 key. If key is nil, a
+// new key is generated.
+func NewIdentity(key ed25519.PrivateKey) (*Identity, error) {
+	if key == nil {
+		var err error
+		if _, key, err = ed25519.GenerateKey(rand.Reader); err != nil {
+			return nil, err
+		}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dusk-node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		key: key,
+		cert: tls.Certificate{
+			Certificate: [][]byte{der},
+			PrivateKey:  key,
+		},
+	}, nil
+}
+
+// LoadIdentity loads the Identity stored in a PEM file. If the file does not
+// exist, a new Identity is generated and stored.
+func LoadIdentity(path string) (*Identity, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return createIdentity(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != identityPEMType {
+		return nil, errors.New("invalid identity file")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("identity is not an ed25519 key")
+	}
+
+	return NewIdentity(edKey)
+}
+
+func createIdentity(path string) (*Identity, error) {
+	id, err := NewIdentity(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(id.key)
+	if err != nil {
+		return nil, err
+	}
+
+	b := pem.EncodeToMemory(&pem.Block{Type: identityPEMType, Bytes: der})
+	if err := ioutil.WriteFile(path, b, 0o600); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// PublicKey returns the public key identifying the node.
+func (i *Identity) PublicKey() ed25519.PublicKey {
+	return i.key.Public().(ed25519.PublicKey)
+}
+
+func (i *Identity) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{i.cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// Peers present self-signed certificates, which can not be verified
+		// against a certificate authority. The TLS handshake proves that the
+		// peer owns the key of its certificate, and verifyPeerIdentity checks
+		// that it is an ed25519 key.
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: verifyPeerIdentity,
+	}
+}
+
+func verifyPeerIdentity(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return errInvalidPeerIdentity
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cert.PublicKey.(ed25519.PublicKey); !ok {
+		return errInvalidPeerIdentity
+	}
+
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
+
+// Pins are the identities expected from the peers, by address. The
+// connections dialed to a pinned address require the encrypted transport, and
+// fail unless the peer presents the pinned key.
+//
+// As the port of an inbound connection is ephemeral, only the outbound
+// connections are checked.
+type Pins map[string]ed25519.PublicKey
+
+// ParsePins parses the pins configured as "ip:port=key" entries, the key
+// being hex encoded.
+func ParsePins(entries []string) (Pins, error) {
+	pins := make(Pins, len(entries))
+
+	for _, e := range entries {
+		i := strings.LastIndexByte(e, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid pin %q, expected ip:port=key", e)
+		}
+
+		host, _, err := net.SplitHostPort(e[:i])
+		if err != nil || net.ParseIP(host) == nil {
+			return nil, fmt.Errorf("invalid pin address %q", e[:i])
+		}
+
+		key, err := hex.DecodeString(e[i+1:])
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid pin key %q", e[i+1:])
+		}
+
+		pins[e[:i]] = key
+	}
+
+	return pins, nil
+}
+
+// EnableEncryption makes the Connection advertise the encrypted transport in
+// the handshake, and upgrade to it when the peer supports it too. If required
+// is true, the handshake with peers not supporting it fails. The identities of
+// the dialed peers are checked against pins, which can be nil.
+func (c *Connection) EnableEncryption(id *Identity, required bool, pins Pins) {
+	c.identity = id
+	c.requireEncryption = required
+	c.pins = pins
+}
+
+// PeerIdentity returns the public key of the peer, if the Connection is
+// encrypted. It returns nil otherwise.
+func (c *Connection) PeerIdentity() ed25519.PublicKey {
+	return c.peerIdentity
+}
+
+// localFeatures adds the features enabled on the Connection to the features
+// supported by the node.
+func (c *Connection) localFeatures() protocol.Features {
+	features := protocol.LocalFeatures
+
+	if c.identity != nil {
+		features |= protocol.FeatureEncryptedTransport
+	}
+
+	if c.compression {
+		features |= protocol.FeatureCompressedFrames
+	}
+
+	return features
+}
+
+// pinned returns the identity pinned for the peer, if the Connection was
+// dialed to a pinned address.
+func (c *Connection) pinned(initiator bool) ed25519.PublicKey {
+	if !initiator || c.pins == nil {
+		return nil
+	}
+
+	return c.pins[c.Conn.RemoteAddr().String()]
+}
+
+// negotiateEncryption upgrades the Connection to TLS if both ends advertised
+// the encrypted transport. The initiator of the connection acts as the TLS
+// client. Once upgraded, the identity of a pinned peer and the version
+// messages exchanged in the clear are checked.
+func (c *Connection) negotiateEncryption(initiator bool) error {
+	pinned := c.pinned(initiator)
+
+	if !c.features.Has(protocol.FeatureEncryptedTransport) {
+		if c.requireEncryption || pinned != nil {
+			return ErrEncryptionRequired
+		}
+
+		return nil
+	}
+
+	var conn *tls.Conn
+	if initiator {
+		conn = tls.Client(c.Conn, c.identity.tlsConfig())
+	} else {
+		conn = tls.Server(c.Conn, c.identity.tlsConfig())
+	}
+
+	timeout := time.Duration(defaultTimeoutReadWrite) * time.Second
+	if err := c.Conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+
+	// verifyPeerIdentity ensured an ed25519 certificate is present
+	state := conn.ConnectionState()
+	peerIdentity := state.PeerCertificates[0].PublicKey.(ed25519.PublicKey)
+
+	if pinned != nil && !pinned.Equal(peerIdentity) {
+		return ErrIdentityMismatch
+	}
+
+	if err := c.checkTranscript(conn, initiator); err != nil {
+		return err
+	}
+
+	if err := c.Conn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	c.peerIdentity = peerIdentity
+
+	c.lock.Lock()
+	c.Conn = conn
+	c.lock.Unlock()
+
+	return nil
+}
+
+// checkTranscript exchanges the hash of the version messages over the
+// encrypted transport, and fails if the peer saw different ones. The
+// responder writes first, as the initiator reads the session tickets sent by
+// the TLS server at the end of the handshake.
+func (c *Connection) checkTranscript(conn *tls.Conn, initiator bool) error {
+	local := c.transcript(initiator)
+	remote := make([]byte, len(local))
+
+	if initiator {
+		if _, err := io.ReadFull(conn, remote); err != nil {
+			return err
+		}
+
+		if _, err := conn.Write(local); err != nil {
+			return err
+		}
+	} else {
+		if _, err := conn.Write(local); err != nil {
+			return err
+		}
+
+		if _, err := io.ReadFull(conn, remote); err != nil {
+			return err
+		}
+	}
+
+	if !bytes.Equal(local, remote) {
+		return ErrTranscriptMismatch
+	}
+
+	return nil
+}
+
+// transcript hashes the version messages exchanged in the handshake, the one
+// of the initiator first.
+func (c *Connection) transcript(initiator bool) []byte {
+	first, second := c.localVersion, c.remoteVersion
+	if !initiator {
+		first, second = second, first
+	}
+
+	h := sha256.New()
+	_, _ = h.Write(first)
+	_, _ = h.Write(second)
+
+	return h.Sum(nil)
+}
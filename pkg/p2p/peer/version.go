@@ -19,9 +19,12 @@ type VersionMessage struct {
 	Version   *protocol.Version
 	Timestamp int64
 	Services  protocol.ServiceFlag
+	// Features is appended to the message, so that it is ignored by the
+	// nodes predating it.
+	Features protocol.Features
 }
 
-func newVersionMessageBuffer(v *protocol.Version, services protocol.ServiceFlag) (*bytes.Buffer, error) {
+func newVersionMessageBuffer(v *protocol.Version, services protocol.ServiceFlag, features protocol.Features) (*bytes.Buffer, error) {
 	buffer := new(bytes.Buffer)
 	if err := v.Encode(buffer); err != nil {
 		return nil, err
@@ -35,6 +38,10 @@ func newVersionMessageBuffer(v *protocol.Version, services protocol.ServiceFlag)
 		return nil, err
 	}
 
+	if err := encoding.WriteUint64LE(buffer, uint64(features)); err != nil {
+		return nil, err
+	}
+
 	return buffer, nil
 }
 
@@ -59,5 +66,20 @@ func decodeVersionMessage(r *bytes.Buffer) (*VersionMessage, error) {
 	}
 
 	versionMessage.Services = protocol.ServiceFlag(services)
+
+	// The nodes predating the feature bits advertise their capabilities in
+	// the services only
+	versionMessage.Features = protocol.LegacyFeatures | versionMessage.Services.Features()
+
+	if r.Len() == 0 {
+		return versionMessage, nil
+	}
+
+	var features uint64
+	if err := encoding.ReadUint64LE(r, &features); err != nil {
+		return nil, err
+	}
+
+	versionMessage.Features = protocol.Features(features)
 	return versionMessage, nil
 }
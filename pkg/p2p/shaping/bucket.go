@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package shaping
+
+import (
+	"math"
+	"time"
+)
+
+// bucket is a token bucket, filled with rate bytes per second up to burst
+// bytes.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newBucket returns a full bucket. A rate of zero disables the limit. The
+// burst defaults to the rate.
+func newBucket(rate, burst int, now time.Time) *bucket {
+	if burst <= 0 {
+		burst = rate
+	}
+
+	return &bucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// delay returns how long to wait before size bytes can be sent. Messages
+// larger than the burst are sent once the bucket is full.
+func (b *bucket) delay(size int, now time.Time) time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+
+	need := math.Min(float64(size), b.burst)
+	if b.tokens >= need {
+		return 0
+	}
+
+	return time.Duration(math.Ceil((need - b.tokens) / b.rate * float64(time.Second)))
+}
+
+// take consumes size bytes. The tokens go negative when a message larger
+// than the burst is sent, delaying the next ones.
+func (b *bucket) take(size int) {
+	if b.rate > 0 {
+		b.tokens -= float64(size)
+	}
+}
@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package shaping
+
+import "github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+
+// Class of the outbound messages. Classes are served by decreasing priority.
+type Class uint8
+
+const (
+	// Inventory is the class of the inventories, and of the other requests.
+	Inventory Class = iota
+	// Txs is the class of the transactions.
+	Txs
+	// Blocks is the class of the blocks sent to synchronizing peers.
+	Blocks
+	// Consensus is the class of the consensus messages, and of the keepalive
+	// messages.
+	Consensus
+
+	numClasses = int(Consensus) + 1
+)
+
+func (c Class) String() string {
+	switch c {
+	case Inventory:
+		return "inventory"
+	case Txs:
+		return "txs"
+	case Blocks:
+		return "blocks"
+	case Consensus:
+		return "consensus"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassOf returns the Class of the messages of a topic.
+func ClassOf(t topics.Topic) Class {
+	switch t {
+	case topics.Candidate, topics.GetCandidate, topics.NewBlock,
+		topics.Reduction, topics.Agreement, topics.AggrAgreement,
+		topics.Ping, topics.Pong:
+		return Consensus
+	case topics.Block, topics.GetBlocks:
+		return Blocks
+	case topics.Tx:
+		return Txs
+	default:
+		return Inventory
+	}
+}
+
+// Priority maps a topic to the priority of its messages in a ring buffer. It
+// is meant as the mapper of eventbus.NewStreamListenerWithParams.
+func Priority(t topics.Topic) byte {
+	return byte(ClassOf(t))
+}
+
+// Classify returns the Class of a message of a topic, given the priority set
+// by the ring buffer. The priority can only raise the class of a message.
+func Classify(t topics.Topic, priority byte) Class {
+	c := ClassOf(t)
+	if p := Class(priority); p > c && p <= Consensus {
+		return p
+	}
+
+	return c
+}
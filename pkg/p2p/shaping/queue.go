@@ -0,0 +1,282 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package shaping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("process", "shaping")
+
+// ErrClosed is returned when pushing a message to a closed Queue.
+var ErrClosed = errors.New("outbound queue closed")
+
+// DefaultQueueSize is the number of messages queued per class, unless
+// configured otherwise.
+const DefaultQueueSize = 1000
+
+// Policy of a class for the messages which cannot be sent right away.
+type Policy uint8
+
+const (
+	// Defer keeps the messages queued until the class is within its limit.
+	Defer Policy = iota
+	// Drop discards the messages sent while the class is over its limit.
+	Drop
+)
+
+// ParsePolicy returns the Policy named s. An empty name is Defer.
+func ParsePolicy(s string) (Policy, error) {
+	switch s {
+	case "", "defer":
+		return Defer, nil
+	case "drop":
+		return Drop, nil
+	default:
+		return Defer, fmt.Errorf("unknown shaping policy %q", s)
+	}
+}
+
+// Limit of the outbound traffic of a Class.
+type Limit struct {
+	// Rate is the number of bytes sent each second. Zero disables the limit.
+	Rate int
+	// Burst is the number of bytes sent at once. It defaults to Rate.
+	Burst int
+	// QueueSize is the number of messages queued. Once full, the oldest
+	// message is dropped. It defaults to DefaultQueueSize.
+	QueueSize int
+	// MaxDelay is how long a message stays queued before being dropped. Zero
+	// keeps the messages until they are sent.
+	MaxDelay time.Duration
+	// Policy of the messages sent while the class is over its Rate.
+	Policy Policy
+}
+
+// Config of a Queue.
+type Config struct {
+	Consensus Limit
+	Blocks    Limit
+	Txs       Limit
+	Inventory Limit
+}
+
+func (c Config) limit(class Class) Limit {
+	switch class {
+	case Consensus:
+		return c.Consensus
+	case Blocks:
+		return c.Blocks
+	case Txs:
+		return c.Txs
+	default:
+		return c.Inventory
+	}
+}
+
+type item struct {
+	size   int
+	send   func() error
+	queued time.Time
+}
+
+type lane struct {
+	limit  Limit
+	bucket *bucket
+	items  []item
+}
+
+func (l *lane) pop() {
+	l.items[0] = item{}
+	l.items = l.items[1:]
+}
+
+// Queue shapes the outbound traffic of a connection. Messages are queued by
+// Class, and sent one at a time by decreasing Class, each Class being limited
+// by a token bucket. When the link is saturated, the messages pile up in
+// their queue, where the lower classes wait for the higher ones.
+//
+// A nil Queue sends the messages right away.
+type Queue struct {
+	ctx  context.Context
+	wake chan struct{}
+
+	lock    sync.Mutex
+	lanes   [numClasses]*lane
+	dropped [numClasses]uint64
+
+	now func() time.Time
+}
+
+// NewQueue returns a Queue sending its messages until ctx is canceled.
+func NewQueue(ctx context.Context, cfg Config) *Queue {
+	q := &Queue{
+		ctx:  ctx,
+		wake: make(chan struct{}, 1),
+		now:  time.Now,
+	}
+
+	for i := range q.lanes {
+		l := cfg.limit(Class(i))
+		if l.QueueSize <= 0 {
+			l.QueueSize = DefaultQueueSize
+		}
+
+		q.lanes[i] = &lane{
+			limit:  l,
+			bucket: newBucket(l.Rate, l.Burst, q.now()),
+		}
+	}
+
+	go q.run()
+
+	return q
+}
+
+// Push queues a message of size bytes, which send writes to the connection.
+// The errors of send are only returned by a nil Queue, which calls it right
+// away.
+func (q *Queue) Push(class Class, size int, send func() error) error {
+	if q == nil {
+		return send()
+	}
+
+	select {
+	case <-q.ctx.Done():
+		return ErrClosed
+	default:
+	}
+
+	if class > Consensus {
+		class = Consensus
+	}
+
+	q.lock.Lock()
+
+	l := q.lanes[class]
+	if len(l.items) >= l.limit.QueueSize {
+		l.pop()
+		q.drop(class, "queue full")
+	}
+
+	l.items = append(l.items, item{size: size, send: send, queued: q.now()})
+
+	q.lock.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Len returns the number of messages queued in a Class.
+func (q *Queue) Len(class Class) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return len(q.lanes[class].items)
+}
+
+// Dropped returns the number of messages of a Class dropped so far.
+func (q *Queue) Dropped(class Class) uint64 {
+	return atomic.LoadUint64(&q.dropped[class])
+}
+
+func (q *Queue) run() {
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		default:
+		}
+
+		it, wait := q.next()
+		if it != nil {
+			if err := it.send(); err != nil {
+				log.WithError(err).Debug("failed to send queued message")
+			}
+
+			continue
+		}
+
+		// Wait for a new message, or for a class to be within its limit
+		var timeout <-chan time.Time
+		if wait > 0 {
+			timeout = time.After(wait)
+		}
+
+		select {
+		case <-q.wake:
+		case <-timeout:
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+// next pops the message to send, from the highest class within its limit.
+// Otherwise, it returns how long to wait for a class to be within its limit,
+// or zero if no message is queued.
+func (q *Queue) next() (*item, time.Duration) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.now()
+
+	var wait time.Duration
+
+	for i := len(q.lanes) - 1; i >= 0; i-- {
+		l := q.lanes[i]
+
+		for len(l.items) > 0 {
+			it := l.items[0]
+
+			if l.limit.MaxDelay > 0 && now.Sub(it.queued) > l.limit.MaxDelay {
+				l.pop()
+				q.drop(Class(i), "expired")
+				continue
+			}
+
+			d := l.bucket.delay(it.size, now)
+			if d == 0 {
+				l.pop()
+				l.bucket.take(it.size)
+				return &it, 0
+			}
+
+			if l.limit.Policy == Drop {
+				l.pop()
+				q.drop(Class(i), "rate limited")
+				continue
+			}
+
+			if wait == 0 || d < wait {
+				wait = d
+			}
+
+			break
+		}
+	}
+
+	return nil, wait
+}
+
+func (q *Queue) drop(class Class, reason string) {
+	atomic.AddUint64(&q.dropped[class], 1)
+	log.WithField("class", class.String()).
+		WithField("reason", reason).
+		Debug("outbound message dropped")
+}
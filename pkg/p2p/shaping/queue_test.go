@@ -0,0 +1,176 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package shaping
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	require.Equal(t, Consensus, ClassOf(topics.Reduction))
+	require.Equal(t, Blocks, ClassOf(topics.Block))
+	require.Equal(t, Txs, ClassOf(topics.Tx))
+	require.Equal(t, Inventory, ClassOf(topics.Inv))
+
+	// The priority can only raise the class
+	require.Equal(t, Consensus, Classify(topics.Tx, byte(Consensus)))
+	require.Equal(t, Blocks, Classify(topics.Block, 0))
+	require.Equal(t, Txs, Classify(topics.Tx, 0xff))
+}
+
+func TestBucket(t *testing.T) {
+	now := time.Now()
+	b := newBucket(1000, 500, now)
+
+	require.Zero(t, b.delay(500, now))
+	b.take(500)
+
+	require.Equal(t, 100*time.Millisecond, b.delay(100, now))
+	require.Zero(t, b.delay(100, now.Add(100*time.Millisecond)))
+
+	// Messages larger than the burst wait for a full bucket
+	b.take(100)
+	require.Equal(t, 500*time.Millisecond, b.delay(2000, now.Add(100*time.Millisecond)))
+
+	// A zero rate disables the limit
+	require.Zero(t, newBucket(0, 0, now).delay(1<<20, now))
+}
+
+// TestPriority ensures the queued messages are sent by decreasing class.
+func TestPriority(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := NewQueue(ctx, Config{})
+
+	sent := make(chan Class, 8)
+	release := make(chan struct{})
+
+	// Block the queue on a first message, while the others are queued
+	require.NoError(t, q.Push(Inventory, 1, func() error {
+		<-release
+		return nil
+	}))
+
+	require.Eventually(t, func() bool { return q.Len(Inventory) == 0 }, time.Second, time.Millisecond)
+
+	for _, c := range []Class{Inventory, Txs, Blocks, Consensus, Txs} {
+		c := c
+		require.NoError(t, q.Push(c, 1, func() error {
+			sent <- c
+			return nil
+		}))
+	}
+
+	close(release)
+
+	for _, c := range []Class{Consensus, Blocks, Txs, Txs, Inventory} {
+		select {
+		case s := <-sent:
+			require.Equal(t, c, s)
+		case <-time.After(time.Second):
+			t.Fatal("message not sent")
+		}
+	}
+}
+
+// TestRateLimit ensures a class over its rate defers or drops its messages,
+// without holding up the other classes.
+func TestRateLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := NewQueue(ctx, Config{
+		Blocks:    Limit{Rate: 1000},
+		Inventory: Limit{Rate: 1000, Policy: Drop},
+	})
+
+	sent := make(chan Class, 8)
+	send := func(c Class) func() error {
+		return func() error {
+			sent <- c
+			return nil
+		}
+	}
+
+	// Empty the buckets
+	require.NoError(t, q.Push(Blocks, 1000, send(Blocks)))
+	require.NoError(t, q.Push(Inventory, 1000, send(Inventory)))
+	<-sent
+	<-sent
+
+	start := time.Now()
+
+	require.NoError(t, q.Push(Blocks, 100, send(Blocks)))
+	require.NoError(t, q.Push(Inventory, 100, send(Inventory)))
+	require.NoError(t, q.Push(Txs, 100, send(Txs)))
+
+	require.Equal(t, Txs, <-sent)
+	require.Equal(t, Blocks, <-sent)
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(90*time.Millisecond))
+
+	require.Eventually(t, func() bool { return q.Dropped(Inventory) == 1 }, time.Second, time.Millisecond)
+	require.Empty(t, sent)
+}
+
+// TestQueueSize ensures the oldest messages are dropped once a class is
+// full, and the expired ones before being sent.
+func TestQueueSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	q := NewQueue(ctx, Config{
+		Txs:       Limit{QueueSize: 2},
+		Inventory: Limit{MaxDelay: 10 * time.Millisecond},
+	})
+
+	release := make(chan struct{})
+	require.NoError(t, q.Push(Consensus, 1, func() error {
+		<-release
+		return nil
+	}))
+
+	require.Eventually(t, func() bool { return q.Len(Consensus) == 0 }, time.Second, time.Millisecond)
+
+	sent := make(chan int, 8)
+
+	for i := 0; i < 3; i++ {
+		i := i
+		require.NoError(t, q.Push(Txs, 1, func() error {
+			sent <- i
+			return nil
+		}))
+	}
+
+	require.NoError(t, q.Push(Inventory, 1, func() error {
+		sent <- -1
+		return nil
+	}))
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	require.Equal(t, 1, <-sent)
+	require.Equal(t, 2, <-sent)
+	require.Eventually(t, func() bool { return q.Dropped(Inventory) == 1 }, time.Second, time.Millisecond)
+	require.Equal(t, uint64(1), q.Dropped(Txs))
+
+	cancel()
+	require.Equal(t, ErrClosed, q.Push(Txs, 1, func() error { return nil }))
+}
+
+func TestNilQueue(t *testing.T) {
+	var q *Queue
+
+	err := errors.New("write failed")
+	require.Equal(t, err, q.Push(Consensus, 1, func() error { return err }))
+}
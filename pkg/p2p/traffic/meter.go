@@ -0,0 +1,227 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package traffic
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// Networks the messages travel on.
+const (
+	Gossip  = "gossip"
+	Kadcast = "kadcast"
+)
+
+const (
+	// Broadcast is the peer of the messages broadcast to the network, rather
+	// than sent to a specific peer.
+	Broadcast = "broadcast"
+
+	// OtherPeers is the peer the traffic is accounted to, once MaxPeers
+	// peers of a network are tracked.
+	OtherPeers = "other"
+
+	// MaxPeers is the number of peers tracked per network at once. It bounds
+	// the memory of the Meter, along with Disconnected.
+	MaxPeers = 1024
+)
+
+// Direction of a message.
+type Direction uint8
+
+const (
+	// In is the direction of the messages received from a peer.
+	In Direction = iota
+	// Out is the direction of the messages sent to a peer.
+	Out
+)
+
+// Counter of the messages exchanged with a peer on a topic, and of their
+// size on the wire.
+type Counter struct {
+	MessagesIn  uint64 `json:"messages_in"`
+	BytesIn     uint64 `json:"bytes_in"`
+	MessagesOut uint64 `json:"messages_out"`
+	BytesOut    uint64 `json:"bytes_out"`
+}
+
+// Sample is the Counter of a network, peer and topic.
+type Sample struct {
+	Network string `json:"network"`
+	Peer    string `json:"peer"`
+	Topic   string `json:"topic"`
+	Counter
+}
+
+type key struct {
+	network string
+	peer    string
+	topic   string
+}
+
+// Meter accounts the messages exchanged with the peers, by network, peer and
+// topic.
+type Meter struct {
+	lock     sync.Mutex
+	counters map[key]*Counter
+	peers    map[string]map[string]struct{}
+	// conns counts the open connections of each peer, see Connected
+	conns map[key]int
+}
+
+// NewMeter returns an empty Meter.
+func NewMeter() *Meter {
+	return &Meter{
+		counters: make(map[key]*Counter),
+		peers:    make(map[string]map[string]struct{}),
+		conns:    make(map[key]int),
+	}
+}
+
+// Record a message exchanged with peer, taking size bytes on the wire. The
+// topic is read from the first byte of msg.
+func (m *Meter) Record(network, peer string, dir Direction, msg []byte, size int) {
+	topic := "unknown"
+	if len(msg) > 0 {
+		topic = topics.Topic(msg[0]).String()
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	k := key{network: network, peer: m.track(network, peer), topic: topic}
+
+	c, ok := m.counters[k]
+	if !ok {
+		c = new(Counter)
+		m.counters[k] = c
+	}
+
+	switch dir {
+	case In:
+		c.MessagesIn++
+		c.BytesIn += uint64(size)
+	case Out:
+		c.MessagesOut++
+		c.BytesOut += uint64(size)
+	}
+}
+
+// track returns the peer the traffic is accounted to.
+func (m *Meter) track(network, peer string) string {
+	peers, ok := m.peers[network]
+	if !ok {
+		peers = make(map[string]struct{})
+		m.peers[network] = peers
+	}
+
+	if _, ok := peers[peer]; ok {
+		return peer
+	}
+
+	if len(peers) >= MaxPeers {
+		return OtherPeers
+	}
+
+	peers[peer] = struct{}{}
+	return peer
+}
+
+// Connected records a new connection with a peer. A peer may have several
+// connections, as when it is both dialed and dialing.
+func (m *Meter) Connected(network, peer string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.conns[key{network: network, peer: peer}]++
+}
+
+// Disconnected records the termination of a connection with a peer. The
+// counters of the peer are forgotten with its last connection, so that the
+// peers no longer connected do not take the room of the new ones.
+func (m *Meter) Disconnected(network, peer string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	k := key{network: network, peer: peer}
+	if m.conns[k]--; m.conns[k] > 0 {
+		return
+	}
+
+	delete(m.conns, k)
+
+	if _, ok := m.peers[network][peer]; !ok {
+		return
+	}
+
+	delete(m.peers[network], peer)
+
+	for k := range m.counters {
+		if k.network == network && k.peer == peer {
+			delete(m.counters, k)
+		}
+	}
+}
+
+// Snapshot returns the counters, sorted by network, peer and topic.
+func (m *Meter) Snapshot() []Sample {
+	m.lock.Lock()
+
+	samples := make([]Sample, 0, len(m.counters))
+	for k, c := range m.counters {
+		samples = append(samples, Sample{
+			Network: k.network,
+			Peer:    k.peer,
+			Topic:   k.topic,
+			Counter: *c,
+		})
+	}
+
+	m.lock.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i], samples[j]
+
+		if a.Network != b.Network {
+			return a.Network < b.Network
+		}
+
+		if a.Peer != b.Peer {
+			return a.Peer < b.Peer
+		}
+
+		return a.Topic < b.Topic
+	})
+
+	return samples
+}
+
+var global = NewMeter()
+
+// Record a message on the global Meter. See Meter.Record.
+func Record(network, peer string, dir Direction, msg []byte, size int) {
+	global.Record(network, peer, dir, msg, size)
+}
+
+// Connected records a connection on the global Meter. See Meter.Connected.
+func Connected(network, peer string) {
+	global.Connected(network, peer)
+}
+
+// Disconnected records a disconnection on the global Meter. See
+// Meter.Disconnected.
+func Disconnected(network, peer string) {
+	global.Disconnected(network, peer)
+}
+
+// Snapshot returns the counters of the global Meter.
+func Snapshot() []Sample {
+	return global.Snapshot()
+}
@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package traffic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	m := NewMeter()
+
+	tx := []byte{byte(topics.Tx), 1, 2, 3}
+	inv := []byte{byte(topics.Inv)}
+
+	m.Record(Gossip, "10.0.0.1:7000", In, tx, 100)
+	m.Record(Gossip, "10.0.0.1:7000", In, tx, 50)
+	m.Record(Gossip, "10.0.0.1:7000", Out, inv, 30)
+	m.Record(Kadcast, Broadcast, Out, tx, 80)
+	m.Record(Gossip, "10.0.0.2:7000", In, nil, 10)
+
+	require.Equal(t, []Sample{
+		{Network: Gossip, Peer: "10.0.0.1:7000", Topic: "inv", Counter: Counter{MessagesOut: 1, BytesOut: 30}},
+		{Network: Gossip, Peer: "10.0.0.1:7000", Topic: "tx", Counter: Counter{MessagesIn: 2, BytesIn: 150}},
+		{Network: Gossip, Peer: "10.0.0.2:7000", Topic: "unknown", Counter: Counter{MessagesIn: 1, BytesIn: 10}},
+		{Network: Kadcast, Peer: Broadcast, Topic: "tx", Counter: Counter{MessagesOut: 1, BytesOut: 80}},
+	}, m.Snapshot())
+}
+
+// TestMaxPeers ensures the peers beyond MaxPeers are accounted together.
+func TestMaxPeers(t *testing.T) {
+	m := NewMeter()
+	tx := []byte{byte(topics.Tx)}
+
+	for i := 0; i < MaxPeers+10; i++ {
+		m.Record(Gossip, fmt.Sprintf("10.0.%d.%d:7000", i/256, i%256), In, tx, 1)
+	}
+
+	// Kadcast peers are tracked separately
+	m.Record(Kadcast, "10.1.0.1:7000", In, tx, 1)
+
+	samples := m.Snapshot()
+	require.Len(t, samples, MaxPeers+2)
+
+	for _, s := range samples {
+		if s.Peer == OtherPeers {
+			require.Equal(t, uint64(10), s.MessagesIn)
+		}
+	}
+
+	// Known peers are still accounted on their own
+	m.Record(Gossip, "10.0.0.0:7000", In, tx, 1)
+	require.Equal(t, uint64(2), m.Snapshot()[0].MessagesIn)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	m := NewMeter()
+	m.Record(Gossip, "10.0.0.1:7000", In, []byte{byte(topics.Tx)}, 100)
+	m.Record(Kadcast, `a"b`, Out, []byte{byte(topics.Block)}, 20)
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheus(&buf, m.Snapshot()))
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "# HELP dusk_p2p_messages_total"))
+	require.Contains(t, out, "# TYPE dusk_p2p_bytes_total counter\n")
+	require.Contains(t, out, `dusk_p2p_messages_total{network="gossip",peer="10.0.0.1:7000",topic="tx",direction="in"} 1`+"\n")
+	require.Contains(t, out, `dusk_p2p_bytes_total{network="gossip",peer="10.0.0.1:7000",topic="tx",direction="in"} 100`+"\n")
+	require.Contains(t, out, `dusk_p2p_bytes_total{network="kadcast",peer="a\"b",topic="block",direction="out"} 20`+"\n")
+	require.NotContains(t, out, `direction="out"} 0`)
+}
+
+// TestDisconnected ensures a host is forgotten only once its last connection
+// closes, freeing its room for the new peers.
+func TestDisconnected(t *testing.T) {
+	m := NewMeter()
+	tx := []byte{byte(topics.Tx)}
+
+	for i := 0; i < MaxPeers; i++ {
+		m.Record(Gossip, fmt.Sprintf("10.0.%d.%d", i/256, i%256), In, tx, 1)
+	}
+
+	m.Record(Kadcast, "10.0.0.0:7000", In, tx, 1)
+
+	// Two connections from the same host
+	m.Connected(Gossip, "10.0.0.0")
+	m.Connected(Gossip, "10.0.0.0")
+
+	m.Disconnected(Gossip, "10.0.0.0")
+	require.Len(t, m.Snapshot(), MaxPeers+1)
+
+	m.Disconnected(Gossip, "10.0.0.0")
+	m.Disconnected(Gossip, "10.1.0.1")
+	require.Len(t, m.Snapshot(), MaxPeers)
+
+	m.Record(Gossip, "10.1.0.1", In, tx, 1)
+
+	for _, s := range m.Snapshot() {
+		require.NotEqual(t, OtherPeers, s.Peer)
+	}
+}
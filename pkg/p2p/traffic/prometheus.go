@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package traffic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WritePrometheus writes the samples in the Prometheus text exposition
+// format, as the dusk_p2p_messages_total and dusk_p2p_bytes_total counters
+// labeled by network, peer, topic and direction.
+func WritePrometheus(w io.Writer, samples []Sample) error {
+	bw := bufio.NewWriter(w)
+
+	metrics := []struct {
+		name string
+		help string
+		in   func(Counter) uint64
+		out  func(Counter) uint64
+	}{
+		{
+			name: "dusk_p2p_messages_total",
+			help: "Messages exchanged with the peers.",
+			in:   func(c Counter) uint64 { return c.MessagesIn },
+			out:  func(c Counter) uint64 { return c.MessagesOut },
+		},
+		{
+			name: "dusk_p2p_bytes_total",
+			help: "Bytes exchanged with the peers, framing included.",
+			in:   func(c Counter) uint64 { return c.BytesIn },
+			out:  func(c Counter) uint64 { return c.BytesOut },
+		},
+	}
+
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(bw, "# TYPE %s counter\n", m.name)
+
+		for _, s := range samples {
+			labels := fmt.Sprintf(`network="%s",peer="%s",topic="%s"`,
+				labelEscaper.Replace(s.Network), labelEscaper.Replace(s.Peer), labelEscaper.Replace(s.Topic))
+
+			if v := m.in(s.Counter); v > 0 {
+				fmt.Fprintf(bw, "%s{%s,direction=\"in\"} %d\n", m.name, labels, v)
+			}
+
+			if v := m.out(s.Counter); v > 0 {
+				fmt.Fprintf(bw, "%s{%s,direction=\"out\"} %d\n", m.name, labels, v)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
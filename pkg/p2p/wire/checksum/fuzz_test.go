@@ -0,0 +1,33 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package checksum_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
+)
+
+func FuzzExtract(f *testing.F) {
+	payload := []byte("pippo")
+	f.Add(append(checksum.Generate(payload), payload...))
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		message, cs, err := checksum.Extract(data)
+		if err != nil {
+			return
+		}
+
+		if len(cs) != checksum.Length || !bytes.Equal(append(cs, message...), data) {
+			t.Fatalf("invalid split of %x", data)
+		}
+
+		_ = checksum.Verify(message, cs)
+	})
+}
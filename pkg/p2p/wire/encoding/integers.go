@@ -11,13 +11,14 @@ package encoding
 import (
 	"bytes"
 	"encoding/binary"
+	"io"
 )
 
 // ReadUint8 will read a single byte into v.
 func ReadUint8(r *bytes.Buffer, v *uint8) error {
 	var b [1]byte
 
-	if _, err := r.Read(b[:]); err != nil {
+	if _, err := io.ReadFull(r, b[:]); err != nil {
 		return err
 	}
 
@@ -30,7 +31,7 @@ func ReadUint8(r *bytes.Buffer, v *uint8) error {
 func ReadUint16LE(r *bytes.Buffer, v *uint16) error {
 	var b [2]byte
 
-	if _, err := r.Read(b[:]); err != nil {
+	if _, err := io.ReadFull(r, b[:]); err != nil {
 		return err
 	}
 
@@ -43,7 +44,7 @@ func ReadUint16LE(r *bytes.Buffer, v *uint16) error {
 func ReadUint32LE(r *bytes.Buffer, v *uint32) error {
 	var b [4]byte
 
-	if _, err := r.Read(b[:]); err != nil {
+	if _, err := io.ReadFull(r, b[:]); err != nil {
 		return err
 	}
 
@@ -56,7 +57,7 @@ func ReadUint32LE(r *bytes.Buffer, v *uint32) error {
 func ReadUint64LE(r *bytes.Buffer, v *uint64) error {
 	var b [8]byte
 
-	if _, err := r.Read(b[:]); err != nil {
+	if _, err := io.ReadFull(r, b[:]); err != nil {
 		return err
 	}
 
@@ -13,6 +13,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 )
 
 // ReadBool will read a single byte from r, turn it into a bool
@@ -45,7 +46,7 @@ func Read256(r *bytes.Buffer, b []byte) error {
 		return errors.New("buffer for Read256 should be 32 bytes")
 	}
 
-	if _, err := r.Read(b); err != nil {
+	if _, err := io.ReadFull(r, b); err != nil {
 		return err
 	}
 
@@ -68,7 +69,7 @@ func Read512(r *bytes.Buffer, b []byte) error {
 		return errors.New("buffer for Read512 should be 64 bytes")
 	}
 
-	if _, err := r.Read(b); err != nil {
+	if _, err := io.ReadFull(r, b); err != nil {
 		return err
 	}
 
@@ -91,7 +92,7 @@ func ReadBLSPKey(r *bytes.Buffer, b []byte) error {
 		return errors.New("buffer for ReadBLSPKey should be 96 bytes")
 	}
 
-	if _, err := r.Read(b); err != nil {
+	if _, err := io.ReadFull(r, b); err != nil {
 		return err
 	}
 
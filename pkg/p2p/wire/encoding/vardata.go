@@ -11,6 +11,7 @@ package encoding
 import (
 	"bytes"
 	"fmt"
+	"io"
 )
 
 // ReadVarBytes will read a CompactSize int denoting the length, then
@@ -28,7 +29,7 @@ func ReadVarBytes(r *bytes.Buffer, b *[]byte) error {
 	}
 
 	*b = make([]byte, c)
-	if _, err := r.Read(*b); err != nil {
+	if _, err := io.ReadFull(r, *b); err != nil {
 		return err
 	}
 	return nil
@@ -49,7 +50,7 @@ func ReadVarBytesUint32LE(r *bytes.Buffer, b *[]byte) error {
 	}
 
 	*b = make([]byte, c)
-	if _, err := r.Read(*b); err != nil {
+	if _, err := io.ReadFull(r, *b); err != nil {
 		return err
 	}
 	return nil
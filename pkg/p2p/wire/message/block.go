@@ -61,8 +61,10 @@ func UnmarshalBlock(r *bytes.Buffer, b *block.Block) error {
 	}
 
 	// Maximum amount of transactions we can decode at once is
-	// math.MaxInt32 / 8, since they are pointers (uint64)
-	if lTxs > (math.MaxInt32 / 8) {
+	// math.MaxInt32 / 8, since they are pointers (uint64). Each of them
+	// takes some bytes of the buffer, which bounds the allocation to the
+	// size of the message.
+	if lTxs > (math.MaxInt32/8) || lTxs > uint64(r.Len()) {
 		return errors.New("block tx count too large")
 	}
 
@@ -0,0 +1,295 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package message_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config/genesis"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	crypto "github.com/dusk-network/dusk-crypto/hash"
+)
+
+// The fuzz targets below feed the decoders of the wire messages with
+// arbitrary bytes, as received from the network. They only check that the
+// decoders do not panic, nor allocate more memory than the input justifies.
+// The seed corpus is made of the test fixtures (see fixtures), and of the
+// inputs committed in testdata/fuzz: the encoded fixtures, and the inputs
+// which made the decoders panic.
+//
+// Run a target with:
+//   go test -run=^$ -fuzz=FuzzUnmarshal$ ./pkg/p2p/wire/message
+
+var (
+	fixturesOnce sync.Once
+	fixtureBufs  [][]byte
+	fixturesErr  error
+)
+
+// fixtures returns the wire encoding of a fixture of each topic, including
+// the topic byte. They are built once, as mocking the consensus messages is
+// expensive.
+func fixtures(tb testing.TB) [][]byte {
+	tb.Helper()
+
+	fixturesOnce.Do(func() {
+		fixtureBufs, fixturesErr = buildFixtures()
+	})
+
+	if fixturesErr != nil {
+		tb.Fatal(fixturesErr)
+	}
+
+	return fixtureBufs
+}
+
+func buildFixtures() ([][]byte, error) {
+	hash, _ := crypto.RandEntropy(32)
+
+	hdr := header.Mock()
+	hdr.BlockHash = hash
+
+	msgs := []message.Message{
+		message.New(topics.Block, *helper.RandomBlock(200, 2)),
+		message.New(topics.Candidate, *genesis.Decode()),
+		message.New(topics.Tx, transactions.MockTx()),
+		message.New(topics.NewBlock, message.MockNewBlock(hdr, *helper.RandomBlock(1, 1))),
+		message.New(topics.Reduction, newReductionEvent(1, 1)),
+		message.New(topics.Agreement, RandAgreement()),
+		message.New(topics.AggrAgreement, message.NewAggrAgreement(RandAgreement(), 7, make([]byte, 48))),
+	}
+
+	bufs := make([][]byte, 0, len(msgs)+6)
+
+	for _, m := range msgs {
+		buf, err := message.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+
+		bufs = append(bufs, buf.Bytes())
+	}
+
+	inv := &message.Inv{}
+	inv.AddItem(message.InvTypeBlock, hash)
+	inv.AddItem(message.InvTypeMempoolTx, hash)
+
+	getBlocks := &message.GetBlocks{Locators: [][]byte{hash, hash}}
+	resp := &message.Response{HashedChallenge: hash, Port: "7000"}
+
+	encoders := []struct {
+		topic  topics.Topic
+		encode func(*bytes.Buffer) error
+	}{
+		{topics.Inv, inv.Encode},
+		{topics.GetData, inv.Encode},
+		{topics.GetBlocks, getBlocks.Encode},
+		{topics.Response, resp.Encode},
+	}
+
+	for _, e := range encoders {
+		buf := e.topic.ToBuffer()
+		if err := e.encode(&buf); err != nil {
+			return nil, err
+		}
+
+		bufs = append(bufs, buf.Bytes())
+	}
+
+	for _, t := range []topics.Topic{topics.GetCandidate, topics.Challenge} {
+		buf := t.ToBuffer()
+		_, _ = buf.Write(hash)
+		bufs = append(bufs, buf.Bytes())
+	}
+
+	addr := topics.Addr.ToBuffer()
+	_, _ = addr.WriteString("127.0.0.1:7000")
+
+	return append(bufs, addr.Bytes()), nil
+}
+
+// addSeeds adds the fixtures of the given topics, stripped of the topic byte,
+// to the seed corpus of f.
+func addSeeds(f *testing.F, tps ...topics.Topic) {
+	for _, b := range fixtures(f) {
+		for _, t := range tps {
+			if topics.Topic(b[0]) == t {
+				f.Add(b[1:])
+			}
+		}
+	}
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, b := range fixtures(f) {
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := message.Unmarshal(bytes.NewBuffer(data), nil)
+		if err != nil {
+			return
+		}
+
+		_, _ = message.Marshal(m)
+	})
+}
+
+func FuzzUnmarshalBlock(f *testing.F) {
+	addSeeds(f, topics.Block, topics.Candidate)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		blk := block.NewBlock()
+		if err := message.UnmarshalBlock(bytes.NewBuffer(data), blk); err != nil {
+			return
+		}
+
+		_ = message.MarshalBlock(new(bytes.Buffer), blk)
+	})
+}
+
+func FuzzUnmarshalHeader(f *testing.F) {
+	addSeeds(f, topics.Block, topics.Candidate)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		h := block.NewHeader()
+		if err := message.UnmarshalHeader(bytes.NewBuffer(data), h); err != nil {
+			return
+		}
+
+		_ = message.MarshalHeader(new(bytes.Buffer), h)
+	})
+}
+
+func FuzzUnmarshalTx(f *testing.F) {
+	addSeeds(f, topics.Tx)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx := transactions.NewTransaction()
+		if err := transactions.Unmarshal(bytes.NewBuffer(data), tx); err != nil {
+			return
+		}
+
+		_ = transactions.Marshal(new(bytes.Buffer), tx)
+	})
+}
+
+func FuzzUnmarshalNewBlock(f *testing.F) {
+	addSeeds(f, topics.NewBlock)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		nb := message.NewNewBlock(header.Header{}, nil, *block.NewBlock())
+		if err := message.UnmarshalNewBlock(bytes.NewBuffer(data), nb); err != nil {
+			return
+		}
+
+		_ = message.MarshalNewBlock(new(bytes.Buffer), *nb)
+	})
+}
+
+func FuzzUnmarshalReduction(f *testing.F) {
+	addSeeds(f, topics.Reduction)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		red := message.NewReduction(header.Header{})
+		if err := message.UnmarshalReduction(bytes.NewBuffer(data), red); err != nil {
+			return
+		}
+
+		_ = message.MarshalReduction(new(bytes.Buffer), *red)
+	})
+}
+
+func FuzzUnmarshalVoteSet(f *testing.F) {
+	var buf bytes.Buffer
+	if err := message.MarshalVoteSet(&buf, []message.Reduction{newReductionEvent(1, 1), newReductionEvent(1, 1)}); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(buf.Bytes())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		evs, err := message.UnmarshalVoteSet(bytes.NewBuffer(data))
+		if err != nil {
+			return
+		}
+
+		_ = message.MarshalVoteSet(new(bytes.Buffer), evs)
+	})
+}
+
+func FuzzUnmarshalAgreement(f *testing.F) {
+	addSeeds(f, topics.Agreement)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := message.Unmarshal(bytes.NewBuffer(append([]byte{byte(topics.Agreement)}, data...)), nil)
+		if err != nil {
+			return
+		}
+
+		_ = message.MarshalAgreement(new(bytes.Buffer), m.Payload().(message.Agreement))
+	})
+}
+
+func FuzzUnmarshalAggrAgreement(f *testing.F) {
+	addSeeds(f, topics.AggrAgreement)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := message.Unmarshal(bytes.NewBuffer(append([]byte{byte(topics.AggrAgreement)}, data...)), nil)
+		if err != nil {
+			return
+		}
+
+		_ = message.MarshalAggrAgreement(new(bytes.Buffer), m.Payload().(message.AggrAgreement))
+	})
+}
+
+func FuzzDecodeInv(f *testing.F) {
+	addSeeds(f, topics.Inv)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		inv := &message.Inv{}
+		if err := inv.Decode(bytes.NewBuffer(data)); err != nil {
+			return
+		}
+
+		_ = inv.Encode(new(bytes.Buffer))
+	})
+}
+
+func FuzzDecodeGetBlocks(f *testing.F) {
+	addSeeds(f, topics.GetBlocks)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := &message.GetBlocks{}
+		if err := g.Decode(bytes.NewBuffer(data)); err != nil {
+			return
+		}
+
+		_ = g.Encode(new(bytes.Buffer))
+	})
+}
+
+func FuzzDecodeResponse(f *testing.F) {
+	addSeeds(f, topics.Response)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := &message.Response{}
+		if err := r.Decode(bytes.NewBuffer(data)); err != nil {
+			return
+		}
+
+		_ = r.Encode(new(bytes.Buffer))
+	})
+}
@@ -127,6 +127,11 @@ func UnmarshalVoteSet(r *bytes.Buffer) ([]Reduction, error) {
 		return nil, err
 	}
 
+	// Each Reduction takes some bytes of the buffer
+	if length > uint64(r.Len()) {
+		return nil, fmt.Errorf("attempting to decode too many reductions %d", length)
+	}
+
 	evs := make([]Reduction, length)
 
 	for i := uint64(0); i < length; i++ {
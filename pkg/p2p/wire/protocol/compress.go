@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package protocol
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// ReservedCompressed flags, in the reserved field of a frame, a payload
+	// compressed with snappy. It is only meaningful on connections which
+	// negotiated the compression, as the reserved field carries arbitrary
+	// values otherwise.
+	ReservedCompressed uint64 = 1
+
+	// CompressionThreshold is the size of a message, in bytes, above which it
+	// is compressed.
+	CompressionThreshold = 1024
+
+	// MaxDecompressedSize bounds the size of a decompressed message, so that
+	// a small frame can not expand into a large allocation. Compressed or
+	// not, a message never exceeds what an uncompressed frame can carry.
+	MaxDecompressedSize = MaxFrameSize
+)
+
+// ProcessCompressed is the same as Process, but compresses the message first.
+// Messages below CompressionThreshold, or which do not shrink, are sent raw.
+func (g *Gossip) ProcessCompressed(m *bytes.Buffer) error {
+	if m.Len() < CompressionThreshold {
+		return g.Process(m)
+	}
+
+	c := snappy.Encode(nil, m.Bytes())
+	if len(c) >= m.Len() {
+		return g.Process(m)
+	}
+
+	*m = *bytes.NewBuffer(c)
+	return g.ProcessWithReserved(m, ReservedCompressed)
+}
+
+// Decompress the payload of a frame, if its reserved field flags it as
+// compressed. The payload is returned untouched otherwise.
+func Decompress(payload []byte, reserved uint64) ([]byte, error) {
+	if reserved&ReservedCompressed == 0 {
+		return payload, nil
+	}
+
+	n, err := snappy.DecodedLen(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(n) > MaxDecompressedSize {
+		return nil, fmt.Errorf("decompressed size %d exceeds %d", n, MaxDecompressedSize)
+	}
+
+	return snappy.Decode(nil, payload)
+}
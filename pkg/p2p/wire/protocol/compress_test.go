@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package protocol_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+)
+
+func readCompressed(t *testing.T, g *protocol.Gossip, frame *bytes.Buffer) ([]byte, uint64) {
+	b, reserved, err := g.ReadMessageWithReserved(frame)
+	require.NoError(t, err)
+
+	m, cs, err := checksum.Extract(b)
+	require.NoError(t, err)
+	require.True(t, checksum.Verify(m, cs))
+
+	m, err = protocol.Decompress(m, reserved)
+	require.NoError(t, err)
+
+	return m, reserved
+}
+
+func TestCompression(t *testing.T) {
+	g := protocol.NewGossip()
+
+	// Large repetitive messages are compressed
+	msg := bytes.Repeat([]byte("dusk"), protocol.CompressionThreshold)
+	frame := bytes.NewBuffer(append([]byte{}, msg...))
+	require.NoError(t, g.ProcessCompressed(frame))
+	require.Less(t, frame.Len(), len(msg))
+
+	m, reserved := readCompressed(t, g, frame)
+	require.Equal(t, protocol.ReservedCompressed, reserved)
+	require.Equal(t, msg, m)
+
+	// Small messages are sent raw
+	msg = []byte("pippo")
+	frame = bytes.NewBuffer(append([]byte{}, msg...))
+	require.NoError(t, g.ProcessCompressed(frame))
+
+	m, reserved = readCompressed(t, g, frame)
+	require.Zero(t, reserved)
+	require.Equal(t, msg, m)
+
+	// Incompressible messages are sent raw
+	msg = make([]byte, 2*protocol.CompressionThreshold)
+	_, err := rand.Read(msg)
+	require.NoError(t, err)
+
+	frame = bytes.NewBuffer(append([]byte{}, msg...))
+	require.NoError(t, g.ProcessCompressed(frame))
+
+	m, reserved = readCompressed(t, g, frame)
+	require.Zero(t, reserved)
+	require.Equal(t, msg, m)
+}
+
+func TestDecompressBomb(t *testing.T) {
+	// A small payload expanding above the bound is rejected
+	bomb := snappy.Encode(nil, make([]byte, protocol.MaxDecompressedSize+1))
+	require.Less(t, uint64(len(bomb)), protocol.MaxFrameSize)
+
+	_, err := protocol.Decompress(bomb, protocol.ReservedCompressed)
+	require.Error(t, err)
+
+	// Garbage is rejected
+	_, err = protocol.Decompress([]byte{0xff, 0xff, 0xff}, protocol.ReservedCompressed)
+	require.Error(t, err)
+}
@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// Features is the set of optional protocol features a node supports. It is
+// advertised in the version message, and a connection only uses the features
+// supported by both ends, so that a protocol change can be rolled out behind
+// a feature bit while the network is upgraded.
+type Features uint64
+
+const (
+	// FeatureCompressedFrames indicates that the node supports compressed
+	// frames on gossip connections.
+	FeatureCompressedFrames Features = 1 << iota
+
+	// FeatureEncryptedTransport indicates that the node supports upgrading
+	// gossip connections to the encrypted transport.
+	FeatureEncryptedTransport
+
+	// FeatureAggrAgreement indicates that the node processes the
+	// AggrAgreement messages.
+	FeatureAggrAgreement
+)
+
+// LegacyFeatures are the features of the nodes predating the feature bits.
+// Their compression and encryption support is advertised by the Compressed
+// and Encrypted service flags instead.
+const LegacyFeatures = FeatureAggrAgreement
+
+// LocalFeatures are the features this node supports on any connection. The
+// compression and the encryption are only advertised by the connections
+// enabling them.
+const LocalFeatures = FeatureAggrAgreement
+
+var featureNames = []struct {
+	f    Features
+	name string
+}{
+	{FeatureCompressedFrames, "compressed"},
+	{FeatureEncryptedTransport, "encrypted"},
+	{FeatureAggrAgreement, "aggragreement"},
+}
+
+// Has returns true if all the features of o are set.
+func (f Features) Has(o Features) bool {
+	return f&o == o
+}
+
+// Negotiate returns the features supported by both f and o.
+func (f Features) Negotiate(o Features) Features {
+	return f & o
+}
+
+func (f Features) String() string {
+	names := make([]string, 0, len(featureNames))
+
+	for _, n := range featureNames {
+		if f.Has(n.f) {
+			names = append(names, n.name)
+			f &^= n.f
+		}
+	}
+
+	if f != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint64(f)))
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Features returns the features implied by the capability flags of the
+// services, as advertised by the nodes predating the feature bits.
+func (s ServiceFlag) Features() Features {
+	var f Features
+
+	if s.Has(Compressed) {
+		f |= FeatureCompressedFrames
+	}
+
+	if s.Has(Encrypted) {
+		f |= FeatureEncryptedTransport
+	}
+
+	return f
+}
+
+// MinNodeVer is the oldest node version this node connects to.
+var MinNodeVer = &Version{
+	Major: 0,
+	Minor: 4,
+	Patch: 0,
+}
+
+// ErrVersionMismatch is returned by CheckNodeVersion for incompatible node
+// versions.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// CheckProtocolVersion returns ErrVersionMismatch unless a frame of version v
+// can be read by this node: v must be of the same major version as
+// CurrentProtocolVersion, and not older than MinProtocolVersion. As for the
+// node versions, the frames of the compatible versions only differ by the
+// negotiated Features.
+func CheckProtocolVersion(v *semver.Version) error {
+	if v.Major() != CurrentProtocolVersion.Major() || v.LessThan(MinProtocolVersion) {
+		return fmt.Errorf("%w: message version %s, expected %s to %d.x", ErrVersionMismatch, v, MinProtocolVersion, CurrentProtocolVersion.Major())
+	}
+
+	return nil
+}
+
+// CheckNodeVersion returns ErrVersionMismatch unless a node of version v can
+// connect with this node: v must be of the same major version as NodeVer,
+// and not older than MinNodeVer. The differences between the compatible
+// versions are covered by the negotiated Features.
+func CheckNodeVersion(v *Version) error {
+	if v.Major != NodeVer.Major || v.Less(MinNodeVer) {
+		return fmt.Errorf("%w: %s, expected %s to %d.x", ErrVersionMismatch, v, MinNodeVer, NodeVer.Major)
+	}
+
+	return nil
+}
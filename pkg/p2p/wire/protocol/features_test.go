@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatures(t *testing.T) {
+	local := LocalFeatures | FeatureCompressedFrames
+	remote := LegacyFeatures | FeatureEncryptedTransport
+
+	require.Equal(t, FeatureAggrAgreement, local.Negotiate(remote))
+	require.True(t, local.Has(FeatureCompressedFrames|FeatureAggrAgreement))
+	require.False(t, remote.Has(FeatureCompressedFrames))
+
+	require.Equal(t, "compressed,aggragreement,0x100", (local | 1<<8).String())
+
+	// Legacy capability flags
+	require.Equal(t, FeatureCompressedFrames|FeatureEncryptedTransport, (FullNode | Compressed | Encrypted).Features())
+	require.Zero(t, FullNode.Features())
+}
+
+func TestCheckNodeVersion(t *testing.T) {
+	require.NoError(t, CheckNodeVersion(NodeVer))
+	require.NoError(t, CheckNodeVersion(MinNodeVer))
+	require.NoError(t, CheckNodeVersion(&Version{Major: NodeVer.Major, Minor: 255}))
+
+	for _, v := range []*Version{
+		{Major: MinNodeVer.Major, Minor: MinNodeVer.Minor - 1, Patch: 9},
+		{Major: NodeVer.Major + 1},
+	} {
+		require.ErrorIs(t, CheckNodeVersion(v), ErrVersionMismatch, v.String())
+	}
+}
+
+func TestCheckProtocolVersion(t *testing.T) {
+	require.NoError(t, CheckProtocolVersion(CurrentProtocolVersion))
+	require.NoError(t, CheckProtocolVersion(semver.MustParse("0.2.1")))
+
+	for _, v := range []string{"0.0.9", "1.0.0"} {
+		require.ErrorIs(t, CheckProtocolVersion(semver.MustParse(v)), ErrVersionMismatch, v)
+	}
+}
@@ -25,6 +25,10 @@ const (
 
 	// VersionLength is number of bytes the version uses.
 	VersionLength = uint64(8)
+
+	// HeaderLength is the number of bytes a frame adds in front of the
+	// checksum: length, version and reserved field.
+	HeaderLength = 8 + VersionLength + reservedFieldSize
 )
 
 // WriteFrame same as WriteFrameWithReserved but with reserved field
@@ -0,0 +1,93 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package protocol_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+)
+
+// frames returns gossip frames of the test fixtures, raw and compressed.
+func frames(tb testing.TB) [][]byte {
+	tb.Helper()
+
+	g := protocol.NewGossip()
+
+	payloads := [][]byte{
+		[]byte("pippo"),
+		bytes.Repeat([]byte("pippo"), protocol.CompressionThreshold),
+	}
+
+	var out [][]byte
+
+	for _, p := range payloads {
+		raw := bytes.NewBuffer(append([]byte{}, p...))
+		if err := g.Process(raw); err != nil {
+			tb.Fatal(err)
+		}
+
+		compressed := bytes.NewBuffer(append([]byte{}, p...))
+		if err := g.ProcessCompressed(compressed); err != nil {
+			tb.Fatal(err)
+		}
+
+		out = append(out, raw.Bytes(), compressed.Bytes())
+	}
+
+	return out
+}
+
+func FuzzReadMessage(f *testing.F) {
+	for _, b := range frames(f) {
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := protocol.NewGossip()
+
+		msg, reserved, err := g.ReadMessageWithReserved(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		if uint64(len(msg)) > protocol.MaxFrameSize {
+			t.Fatalf("message of %d bytes exceeds the max frame size", len(msg))
+		}
+
+		_, _ = protocol.Decompress(msg, reserved)
+	})
+}
+
+func FuzzReadFrame(f *testing.F) {
+	for _, b := range frames(f) {
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := protocol.NewGossip()
+		_, _ = g.ReadFrame(bytes.NewReader(data))
+	})
+}
+
+func FuzzDecompress(f *testing.F) {
+	for _, b := range frames(f) {
+		f.Add(b, protocol.ReservedCompressed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, reserved uint64) {
+		msg, err := protocol.Decompress(data, reserved)
+		if err != nil {
+			return
+		}
+
+		if uint64(len(msg)) > protocol.MaxDecompressedSize {
+			t.Fatalf("decompressed message of %d bytes exceeds the max size", len(msg))
+		}
+	})
+}
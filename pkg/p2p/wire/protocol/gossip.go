@@ -42,28 +42,35 @@ func (g *Gossip) ProcessWithReserved(m *bytes.Buffer, reserved uint64) error {
 
 // UnpackLength unwraps the incoming packet (likely from a net.Conn struct) and returns the length of the packet without reading the payload (which is left to the user of this method).
 func (g *Gossip) UnpackLength(r io.Reader) (uint64, error) {
+	ln, _, err := g.unpack(r)
+	return ln, err
+}
+
+// unpack is the same as UnpackLength, but also returns the reserved field.
+func (g *Gossip) unpack(r io.Reader) (uint64, uint64, error) {
 	packetLength, err := ReadFrame(r)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	version, err := ExtractVersion(r)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	if !VersionConstraint.Check(version) {
-		return 0, fmt.Errorf("invalid message version %s received, expected %s", version, VersionConstraintString)
+	if err := CheckProtocolVersion(version); err != nil {
+		return 0, 0, err
 	}
 
 	// if magic != g.Magic {
 	// 	return 0, fmt.Errorf("magic mismatch, received %s expected %s", magic, g.Magic)
 	// }
 
-	// Reserved field is the message timestamp in DevNet/TestNet
-	_, rfSize, err := g.extractReservedField(r)
+	// Reserved field is the message timestamp in DevNet/TestNet, or the
+	// compression flag on connections which negotiated it
+	reserved, rfSize, err := g.extractReservedField(r)
 	if err != nil {
-		return 0, errors.New("reserved field mismatch")
+		return 0, 0, errors.New("reserved field mismatch")
 	}
 
 	// Uncomment on measuring average arrival time
@@ -74,10 +81,10 @@ func (g *Gossip) UnpackLength(r io.Reader) (uint64, error) {
 	ln := packetLength - uint64(rfSize) - VersionLength
 
 	if ln > MaxFrameSize {
-		return 0, fmt.Errorf("invalid packet length %d", packetLength)
+		return 0, 0, fmt.Errorf("invalid packet length %d", packetLength)
 	}
 
-	return ln, nil
+	return ln, uint64(reserved), nil
 }
 
 // ReadMessage reads from the connection.
@@ -88,15 +95,23 @@ func (g *Gossip) ReadMessage(src io.Reader) ([]byte, error) {
 		return nil, err
 	}
 
-	// read a [length]byte from connection
-	buf := make([]byte, int(length))
+	return readPayload(src, length)
+}
 
-	_, err = io.ReadFull(src, buf)
+// ReadMessageWithReserved is the same as ReadMessage, but also returns the
+// reserved field of the frame.
+func (g *Gossip) ReadMessageWithReserved(src io.Reader) ([]byte, uint64, error) {
+	length, reserved, err := g.unpack(src)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	buf, err := readPayload(src, length)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return buf, err
+	return buf, reserved, nil
 }
 
 // ReadFrame extract message from gossip frame, if no errors found.
@@ -106,10 +121,7 @@ func (g *Gossip) ReadFrame(src io.Reader) ([]byte, error) {
 		return nil, err
 	}
 
-	// read a [length]byte from connection
-	buf := make([]byte, int(length))
-
-	_, err = io.ReadFull(src, buf)
+	buf, err := readPayload(src, length)
 	if err != nil {
 		return nil, err
 	}
@@ -120,12 +132,29 @@ func (g *Gossip) ReadFrame(src io.Reader) ([]byte, error) {
 	}
 
 	if !checksum.Verify(message, cs) {
-		return nil, fmt.Errorf("invalid checksum: %s", err.Error())
+		return nil, errors.New("invalid checksum")
 	}
 
 	return message, nil
 }
 
+// readPayload reads a [length]byte from src. The buffer grows with the bytes
+// actually received, rather than with the length announced by the frame.
+func readPayload(src io.Reader, length uint64) ([]byte, error) {
+	var buf bytes.Buffer
+
+	n, err := io.CopyN(&buf, src, int64(length))
+	if err != nil {
+		if err == io.EOF && n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 func (g *Gossip) extractReservedField(r io.Reader) (int64, int, error) {
 	size := 8
 	// Read timestamp
@@ -23,26 +23,62 @@ const (
 	// FullNode indicates that a user is running the full node implementation of Dusk.
 	FullNode ServiceFlag = 1
 
-	// LightNode indicates that a user is running a Dusk light node.
-	// LightNode ServiceFlag = 2 // Not implemented.
+	// LightNode indicates that a user is running a Dusk light node, which
+	// takes no part in the consensus.
+	LightNode ServiceFlag = 2
+
+	// PrunedNode indicates that a user is running a full node which only
+	// keeps the recent blocks.
+	PrunedNode ServiceFlag = 3
+
+	// Encrypted indicates that the node supports upgrading gossip
+	// connections to the encrypted transport. Unlike the node types, it is a
+	// capability combined with them. It is superseded by
+	// FeatureEncryptedTransport, and only read from the nodes predating the
+	// feature bits.
+	Encrypted ServiceFlag = 1 << 8
+
+	// Compressed indicates that the node supports compressed messages on
+	// gossip connections. It is superseded by FeatureCompressedFrames, and
+	// only read from the nodes predating the feature bits.
+	Compressed ServiceFlag = 1 << 9
+
+	nodeTypeMask ServiceFlag = 0xff
 )
 
+// NodeType returns the node type of the flags, without the capabilities.
+func (s ServiceFlag) NodeType() ServiceFlag {
+	return s & nodeTypeMask
+}
+
+// KnownNodeType returns true if the node type of the flags is known.
+func (s ServiceFlag) KnownNodeType() bool {
+	switch s.NodeType() {
+	case FullNode, LightNode, PrunedNode:
+		return true
+	default:
+		return false
+	}
+}
+
+// Has returns true if all the flags of f are set.
+func (s ServiceFlag) Has(f ServiceFlag) bool {
+	return s&f == f
+}
+
 // NodeVer is the current node version.
 // This is used only in the handshake, need to be removed.
 var NodeVer = &Version{
 	Major: 0,
-	Minor: 4,
-	Patch: 3,
+	Minor: 5,
+	Patch: 0,
 }
 
 // CurrentProtocolVersion indicates the protocol version used.
 var CurrentProtocolVersion = semver.MustParse("0.1.0")
 
-// VersionConstraintString represent the needed version.
-const VersionConstraintString = "~0.1.0"
-
-// VersionConstraint check incoming versions.
-var VersionConstraint, _ = semver.NewConstraint(VersionConstraintString)
+// MinProtocolVersion is the oldest frame version this node reads.
+var MinProtocolVersion = semver.MustParse("0.1.0")
 
 // VersionAsBuffer returns protocol version encoded as BytesBuffer.
 func VersionAsBuffer() bytes.Buffer {
@@ -24,6 +24,19 @@ func (v Version) String() string {
 	return strconv.Itoa(int(v.Major)) + "." + strconv.Itoa(int(v.Minor)) + "." + strconv.Itoa(int(v.Patch))
 }
 
+// Less returns true if v is older than o.
+func (v Version) Less(o *Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+
+	return v.Patch < o.Patch
+}
+
 // Encode will encode a Version struct to w.
 func (v *Version) Encode(w *bytes.Buffer) error {
 	if err := encoding.WriteUint8(w, v.Major); err != nil {
@@ -24,12 +24,32 @@ const DropSessionRoute = servicePrefix + "DropSession"
 // StatusRoute is the RPC to inquiry the status of the wallet.
 const StatusRoute = servicePrefix + "Status"
 
+// FinalityProofRoute is the RPC to get the finality proof of a block.
+const FinalityProofRoute = "/dusk.Finality/GetFinalityProof"
+
+// BlockHeaderRoute is the RPC to get the header of a block.
+const BlockHeaderRoute = "/dusk.Finality/GetBlockHeader"
+
+// ProvisionersRoute is the RPC to get the provisioners at a height.
+const ProvisionersRoute = "/dusk.Finality/GetProvisioners"
+
+// ListBansRoute is the RPC to list the banned peers.
+const ListBansRoute = "/dusk.Reputation/ListBans"
+
+// UnbanRoute is the RPC to lift the ban of a peer.
+const UnbanRoute = "/dusk.Reputation/Unban"
+
 // OpenRoutes is the set of RPC that do not require session authentication.
 var OpenRoutes = hashset.New()
 
 func init() {
 	OpenRoutes.Add([]byte(CreateSessionRoute))
 	OpenRoutes.Add([]byte(StatusRoute))
+	// Finality proofs, headers and provisioners are public, and requested by
+	// light clients
+	OpenRoutes.Add([]byte(FinalityProofRoute))
+	OpenRoutes.Add([]byte(BlockHeaderRoute))
+	OpenRoutes.Add([]byte(ProvisionersRoute))
 }
 
 // AuthToken is what we put in the authorization header.
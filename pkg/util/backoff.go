@@ -0,0 +1,44 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package util
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff computes exponentially growing delays between retries, from Min up
+// to Max. A random jitter of up to a fifth of the delay is added, so that
+// clients do not retry in lockstep. It is not safe for concurrent use.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+
+	cur time.Duration
+}
+
+// Next returns the delay before the next retry.
+func (b *Backoff) Next() time.Duration {
+	switch {
+	case b.cur < b.Min:
+		b.cur = b.Min
+	case b.cur < b.Max:
+		b.cur *= 2
+	}
+
+	if b.cur > b.Max {
+		b.cur = b.Max
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(b.cur)/5 + 1)) //nolint:gosec
+	return b.cur + jitter
+}
+
+// Reset the delay to Min, after a successful attempt.
+func (b *Backoff) Reset() {
+	b.cur = 0
+}
@@ -50,8 +50,9 @@ func (c *Consumer) run() {
 	for {
 		elems, closed := c.ring.GetAll()
 		if len(elems) > 0 {
+			// Elements of the same priority keep their order
 			if c.sortByPriority {
-				sort.Sort(elems)
+				sort.Stable(elems)
 			}
 
 			if !c.consume(elems, c.w) {
@@ -39,6 +39,9 @@ const (
 	// Messages are considered stale when more than staleTimeout seconds pass
 	// after receiving the first block of the message.
 	staleTimeout = int64(10)
+	// Max number of messages being re-assembled at once. Packets of further
+	// messages are dropped until the stale ones are cleaned up.
+	maxPendingMessages = 4096
 	// UDP Recv buffer size.
 	readBufferSize = 208 * 1024
 
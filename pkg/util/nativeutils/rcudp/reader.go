@@ -37,7 +37,9 @@ type MessageCollector func(reserved byte, addr string, decoded []byte) error
 
 // UDPReader that supports decoding Raptor codes packets.
 type UDPReader struct {
-	lAddr *net.UDPAddr
+	lAddr    *net.UDPAddr
+	listener *net.UDPConn
+	quit     chan struct{}
 
 	lock    sync.RWMutex
 	objects map[msgID]*message
@@ -50,21 +52,50 @@ func NewUDPReader(lAddr *net.UDPAddr, h MessageCollector) (*UDPReader, error) {
 	return &UDPReader{
 		objects:   make(map[msgID]*message),
 		lAddr:     lAddr,
+		quit:      make(chan struct{}),
 		collector: h,
 	}, nil
 }
 
-// Serve reads data from UDP socket and tries to re-assemble the sourceObject.
+// Serve reads data from UDP socket and tries to re-assemble the sourceObject,
+// until Close is called. The socket is bound first, unless Listen was called.
 func (r *UDPReader) Serve() {
+	if r.listener == nil {
+		if err := r.Listen(); err != nil {
+			log.Panic(err)
+		}
+	}
+
+	r.serve()
+}
+
+// LocalAddr returns the address the UDP socket is bound to.
+func (r *UDPReader) LocalAddr() *net.UDPAddr {
+	return r.listener.LocalAddr().(*net.UDPAddr)
+}
+
+// Close the UDP socket.
+func (r *UDPReader) Close() error {
+	close(r.quit)
+	return r.listener.Close()
+}
+
+// Listen binds the UDP socket, without serving it yet.
+func (r *UDPReader) Listen() error {
 	listener, err := net.ListenUDP("udp4", r.lAddr)
 	if err != nil {
-		log.Panic(err)
+		return err
 	}
 
 	if err := listener.SetReadBuffer(readBufferSize); err != nil {
 		log.WithError(err).Traceln("Failed to change UDP Recv Buffer Size")
 	}
 
+	r.listener = listener
+	return nil
+}
+
+func (r *UDPReader) serve() {
 	log.WithField("addr", r.lAddr.String()).
 		Infof("Start Raptor code UDPReader")
 
@@ -73,8 +104,14 @@ func (r *UDPReader) Serve() {
 	for {
 		b := make([]byte, maxPacketLen)
 
-		n, uAddr, err := listener.ReadFromUDP(b)
+		n, uAddr, err := r.listener.ReadFromUDP(b)
 		if err != nil {
+			select {
+			case <-r.quit:
+				return
+			default:
+			}
+
 			log.WithError(err).Warn("Error on packet read")
 			continue
 		}
@@ -111,6 +148,10 @@ func (r *UDPReader) processPacket(srcAddr net.UDPAddr, data []byte) error {
 	var ok bool
 
 	if m, ok = r.objects[p.messageID]; !ok {
+		if len(r.objects) >= maxPendingMessages {
+			return errors.New("too many pending messages")
+		}
+
 		// Instantiate a new decoder for handling the packet
 		// a decoder per packet
 		d := NewDecoder(int(p.NumSourceSymbols),
@@ -124,7 +165,6 @@ func (r *UDPReader) processPacket(srcAddr net.UDPAddr, data []byte) error {
 			bcastHeight: p.bcastHeight,
 		}
 
-		// TODO: Limit max number of objects stored
 		r.objects[p.messageID] = m
 	}
 
@@ -186,7 +226,11 @@ func (r *UDPReader) processPacket(srcAddr net.UDPAddr, data []byte) error {
 // Cleanup checks for stale and consumed messages. If found, deletes them.
 func (r *UDPReader) cleanup() {
 	for {
-		time.Sleep(time.Duration(staleTimeout) * time.Second)
+		select {
+		case <-time.After(time.Duration(staleTimeout) * time.Second):
+		case <-r.quit:
+			return
+		}
 
 		deletionList := make([][8]byte, 0)
 
@@ -54,9 +54,10 @@ func CompileRaptorRFC5053(height byte, message []byte, redundancyFactor uint8) (
 // It utilizes a simple back-off.
 func WriteBlocks(laddr, raddr *net.UDPAddr, blocks [][]byte, height byte) error {
 	// Send from same IP that the UDP listener is bound on but choose random port
-	laddr.Port = 0
+	src := *laddr
+	src.Port = 0
 
-	conn, err := net.DialUDP("udp4", laddr, raddr)
+	conn, err := net.DialUDP("udp4", &src, raddr)
 	if err != nil {
 		return err
 	}
@@ -42,6 +42,19 @@ func (c Cluster) Equal(other Cluster) bool {
 	return true
 }
 
+// Copy returns a deep copy of the cluster.
+func (c Cluster) Copy() Cluster {
+	elems := make(map[string]int, len(c.elements))
+	for k, v := range c.elements {
+		elems[k] = v
+	}
+
+	return Cluster{
+		Set:      c.Set.Copy(),
+		elements: elems,
+	}
+}
+
 // TotalOccurrences returns the amount of elements in the cluster.
 func (c Cluster) TotalOccurrences() int {
 	size := 0
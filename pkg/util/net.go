@@ -0,0 +1,21 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package util
+
+import "net"
+
+// Host returns the host of a peer address, or the address itself if it has
+// no port. Peers are keyed by host, as the port of an inbound connection is
+// ephemeral.
+func Host(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
@@ -0,0 +1,19 @@
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT License was not distributed with this
+// file, you can obtain one at https://opensource.org/licenses/MIT.
+//
+// Copyright (c) DUSK NETWORK. All rights reserved.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHost(t *testing.T) {
+	require.Equal(t, "10.0.0.1", Host("10.0.0.1:51234"))
+	require.Equal(t, "::1", Host("[::1]:7000"))
+	require.Equal(t, "broadcast", Host("broadcast"))
+}